@@ -19,6 +19,22 @@ func (il *IntegerLiteral) String() string {
 	return il.Token.Literal
 }
 
+// FloatLiteral represents float literals like `1.5` or `1e-3`
+type FloatLiteral struct {
+	*BaseNode
+	Value float64
+}
+
+func (fl *FloatLiteral) expressionNode() {}
+
+// TokenLiteral returns the literal as written in the source
+func (fl *FloatLiteral) TokenLiteral() string {
+	return fl.Token.Literal
+}
+func (fl *FloatLiteral) String() string {
+	return fl.Token.Literal
+}
+
 type StringLiteral struct {
 	*BaseNode
 	Value string
@@ -37,6 +53,73 @@ func (sl *StringLiteral) String() string {
 	return out.String()
 }
 
+// SymbolLiteral represents symbol literals like `:name` or `:+`
+type SymbolLiteral struct {
+	*BaseNode
+	Value string
+}
+
+func (sl *SymbolLiteral) expressionNode() {}
+
+// TokenLiteral returns token's literal
+func (sl *SymbolLiteral) TokenLiteral() string {
+	return sl.Token.Literal
+}
+func (sl *SymbolLiteral) String() string {
+	return ":" + sl.Value
+}
+
+// RegexpLiteral represents regexp literals like `/ab+c/i`
+type RegexpLiteral struct {
+	*BaseNode
+	Value string
+	Flags string
+}
+
+func (rl *RegexpLiteral) expressionNode() {}
+
+// TokenLiteral returns token's literal
+func (rl *RegexpLiteral) TokenLiteral() string {
+	return rl.Token.Literal
+}
+func (rl *RegexpLiteral) String() string {
+	return "/" + rl.Value + "/" + rl.Flags
+}
+
+// InterpolationExpression represents a double-quoted string with interpolations like `"Hello #{name}!"`,
+// its string segments are stored as StringLiterals in Parts.
+type InterpolationExpression struct {
+	*BaseNode
+	Parts []Expression
+}
+
+func (ie *InterpolationExpression) expressionNode() {}
+
+// TokenLiteral returns the string segment before the first interpolation
+func (ie *InterpolationExpression) TokenLiteral() string {
+	return ie.Token.Literal
+}
+
+func (ie *InterpolationExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("\"")
+
+	for _, part := range ie.Parts {
+		if sl, ok := part.(*StringLiteral); ok {
+			out.WriteString(sl.Value)
+			continue
+		}
+
+		out.WriteString("#{")
+		out.WriteString(part.String())
+		out.WriteString("}")
+	}
+
+	out.WriteString("\"")
+	return out.String()
+}
+
 type ArrayExpression struct {
 	*BaseNode
 	Elements []Expression
@@ -93,6 +176,8 @@ func (pe *PairExpression) String() string {
 type HashExpression struct {
 	*BaseNode
 	Data map[string]Expression
+	// Keys keeps the keys in the order they're written
+	Keys []string
 }
 
 func (he *HashExpression) expressionNode() {}
@@ -103,8 +188,8 @@ func (he *HashExpression) String() string {
 	var out bytes.Buffer
 	var pairs []string
 
-	for key, value := range he.Data {
-		pairs = append(pairs, fmt.Sprintf("%s: %s", key, value.String()))
+	for _, key := range he.Keys {
+		pairs = append(pairs, fmt.Sprintf("%s: %s", key, he.Data[key].String()))
 	}
 
 	out.WriteString("{ ")
@@ -280,13 +365,96 @@ func (ce *ConditionalExpression) String() string {
 	return out.String()
 }
 
+// BeginExpression represents a `begin` block with its `rescue`, `else` and `ensure` clauses
+type BeginExpression struct {
+	*BaseNode
+	Body    *BlockStatement
+	Rescues []*RescueExpression
+	Else    *BlockStatement
+	Ensure  *BlockStatement
+}
+
+func (be *BeginExpression) expressionNode() {}
+
+// TokenLiteral returns `begin`
+func (be *BeginExpression) TokenLiteral() string {
+	return be.Token.Literal
+}
+
+func (be *BeginExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("begin\n")
+	out.WriteString(be.Body.String())
+
+	for _, r := range be.Rescues {
+		out.WriteString("\n")
+		out.WriteString(r.String())
+	}
+
+	if be.Else != nil {
+		out.WriteString("\nelse\n")
+		out.WriteString(be.Else.String())
+	}
+
+	if be.Ensure != nil {
+		out.WriteString("\nensure\n")
+		out.WriteString(be.Ensure.String())
+	}
+
+	out.WriteString("\nend")
+
+	return out.String()
+}
+
+// RescueExpression represents a `rescue` clause, like `rescue ArgumentError, TypeError => e`
+type RescueExpression struct {
+	*BaseNode
+	ErrorClasses []Expression
+	Variable     *Identifier
+	Body         *BlockStatement
+}
+
+func (re *RescueExpression) expressionNode() {}
+
+// TokenLiteral returns `rescue`
+func (re *RescueExpression) TokenLiteral() string {
+	return re.Token.Literal
+}
+
+func (re *RescueExpression) String() string {
+	var out bytes.Buffer
+	var classes []string
+
+	for _, c := range re.ErrorClasses {
+		classes = append(classes, c.String())
+	}
+
+	out.WriteString("rescue")
+
+	if len(classes) > 0 {
+		out.WriteString(" ")
+		out.WriteString(strings.Join(classes, ", "))
+	}
+
+	if re.Variable != nil {
+		out.WriteString(" => ")
+		out.WriteString(re.Variable.String())
+	}
+
+	out.WriteString("\n")
+	out.WriteString(re.Body.String())
+
+	return out.String()
+}
+
 type CallExpression struct {
 	*BaseNode
 	Receiver       Expression
 	Method         string
 	Arguments      []Expression
 	Block          *BlockStatement
-	BlockArguments []*Identifier
+	BlockArguments []Expression // lambda literal's parameters can also be optional, splat or block parameters
 }
 
 func (ce *CallExpression) expressionNode() {}
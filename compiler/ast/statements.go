@@ -99,6 +99,7 @@ type DefStatement struct {
 	Name           *Identifier
 	Receiver       Expression
 	Parameters     []Expression
+	BlockParameter *Identifier
 	BlockStatement *BlockStatement
 }
 
@@ -120,6 +121,13 @@ func (ds *DefStatement) String() string {
 		}
 	}
 
+	if ds.BlockParameter != nil {
+		if len(ds.Parameters) > 0 {
+			out.WriteString(", ")
+		}
+		out.WriteString("&" + ds.BlockParameter.Value)
+	}
+
 	out.WriteString(") ")
 	out.WriteString("{\n")
 	out.WriteString(ds.BlockStatement.String())
@@ -0,0 +1,69 @@
+package bytecode
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Disassemble returns the readable bytecode of instruction sets, annotated with the source code they're compiled from.
+// The body of a method, class or block is listed under the instruction that defines it or passes it.
+func Disassemble(sets []*InstructionSet, source string) string {
+	var out bytes.Buffer
+	d := &disassembler{out: &out, lines: strings.Split(source, "\n")}
+	bodies := make(map[*InstructionSet]bool)
+
+	for _, is := range sets {
+		for _, i := range is.Instructions {
+			if i.Body != nil {
+				bodies[i.Body] = true
+			}
+		}
+	}
+
+	for _, is := range sets {
+		if !bodies[is] {
+			d.disassemble(is, 0)
+		}
+	}
+
+	return out.String()
+}
+
+type disassembler struct {
+	out   *bytes.Buffer
+	lines []string
+}
+
+func (d *disassembler) disassemble(is *InstructionSet, depth int) {
+	indent := strings.Repeat("  ", depth)
+	sourceLine := -1
+
+	if is.isType == Program {
+		fmt.Fprintf(d.out, "%s<%s>\n", indent, is.isType)
+	} else {
+		fmt.Fprintf(d.out, "%s<%s:%s>\n", indent, is.isType, is.name)
+	}
+
+	for _, i := range is.Instructions {
+		if i.sourceLine != sourceLine {
+			sourceLine = i.sourceLine
+			fmt.Fprintf(d.out, "%s  # %s\n", indent, d.sourceText(sourceLine))
+		}
+
+		d.out.WriteString(indent + "  " + i.compile())
+
+		if i.Body != nil {
+			d.disassemble(i.Body, depth+2)
+		}
+	}
+}
+
+// sourceText returns the source line with its line number, which starts from 1
+func (d *disassembler) sourceText(line int) string {
+	if line < 0 || line >= len(d.lines) {
+		return fmt.Sprintf("%d:", line+1)
+	}
+
+	return fmt.Sprintf("%d: %s", line+1, strings.TrimSpace(d.lines[line]))
+}
@@ -0,0 +1,52 @@
+package bytecode
+
+import (
+	"testing"
+)
+
+func TestDisassemble(t *testing.T) {
+	input := `class Foo
+  def bar
+    [1].map do |e|
+      e
+    end
+  end
+end
+
+Foo.new.bar`
+
+	expected := `
+<ProgramStart>
+  # 1: class Foo
+  0 putself
+  1 def_class class:Foo
+    <DefClass:Foo>
+      # 2: def bar
+      0 putself
+      1 putstring bar
+      2 def_method 0
+        <Def:bar>
+          # 3: [1].map do |e|
+          0 putobject 1
+          1 newarray 1
+          2 send map 0 block:0
+            <Block:0>
+              # 4: e
+              0 getlocal 0 0
+              # 3: [1].map do |e|
+              1 leave
+          # 2: def bar
+          3 leave
+      # 1: class Foo
+      3 leave
+  2 pop
+  # 9: Foo.new.bar
+  3 getconstant Foo false
+  4 send new 0
+  5 send bar 0
+  6 pop
+  7 leave
+`
+
+	compareBytecode(t, Disassemble(compileToInstructions(input), input), expected)
+}
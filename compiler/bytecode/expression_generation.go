@@ -2,6 +2,7 @@ package bytecode
 
 import (
 	"fmt"
+
 	"github.com/goby-lang/goby/compiler/ast"
 )
 
@@ -9,15 +10,27 @@ func (g *Generator) compileExpression(is *InstructionSet, exp ast.Expression, sc
 	sourceLine := exp.Line()
 	switch exp := exp.(type) {
 	case *ast.Constant:
-		is.define(GetConstant, sourceLine, exp.Value, fmt.Sprint(exp.IsNamespace))
+		is.define(GetConstant, sourceLine, exp.Value, exp.IsNamespace)
 	case *ast.InstanceVariable:
 		is.define(GetInstanceVariable, sourceLine, exp.Value)
 	case *ast.IntegerLiteral:
-		is.define(PutObject, sourceLine, fmt.Sprint(exp.Value))
+		is.define(PutObject, sourceLine, exp.Value)
+	case *ast.FloatLiteral:
+		is.define(PutObject, sourceLine, exp.Value, FloatObject)
 	case *ast.StringLiteral:
 		is.define(PutString, sourceLine, exp.Value)
+	case *ast.SymbolLiteral:
+		is.define(PutSymbol, sourceLine, exp.Value)
+	case *ast.RegexpLiteral:
+		if len(exp.Flags) > 0 {
+			is.define(PutRegexp, sourceLine, exp.Value, exp.Flags)
+		} else {
+			is.define(PutRegexp, sourceLine, exp.Value)
+		}
+	case *ast.InterpolationExpression:
+		g.compileInterpolationExpression(is, exp, scope, table)
 	case *ast.BooleanExpression:
-		is.define(PutObject, sourceLine, fmt.Sprint(exp.Value))
+		is.define(PutObject, sourceLine, exp.Value)
 	case *ast.NilExpression:
 		is.define(PutNull, sourceLine)
 	case *ast.RangeExpression:
@@ -30,9 +43,9 @@ func (g *Generator) compileExpression(is *InstructionSet, exp ast.Expression, sc
 		}
 		is.define(NewArray, sourceLine, len(exp.Elements))
 	case *ast.HashExpression:
-		for key, value := range exp.Data {
+		for _, key := range exp.Keys {
 			is.define(PutString, sourceLine, key)
-			g.compileExpression(is, value, scope, table)
+			g.compileExpression(is, exp.Data[key], scope, table)
 		}
 		is.define(NewHash, sourceLine, len(exp.Data)*2)
 	case *ast.SelfExpression:
@@ -49,6 +62,8 @@ func (g *Generator) compileExpression(is *InstructionSet, exp ast.Expression, sc
 		g.compileAssignExpression(is, exp, scope, table)
 	case *ast.IfExpression:
 		g.compileIfExpression(is, exp, scope, table)
+	case *ast.BeginExpression:
+		g.compileBeginExpression(is, exp, scope, table)
 	case *ast.YieldExpression:
 		g.compileYieldExpression(is, exp, scope, table)
 	case *ast.CallExpression:
@@ -66,7 +81,28 @@ func (g *Generator) compileIdentifier(is *InstructionSet, exp *ast.Identifier, s
 
 	// otherwise it's a method call
 	is.define(PutSelf, exp.Line())
-	is.define(Send, exp.Line(), exp.Value, 0, "")
+	i := is.define(Send, exp.Line(), exp.Value, 0, "")
+	i.SelfCall = true
+}
+
+// compileInterpolationExpression compiles string interpolation into concatenation, like `"a#{b}"` into `"a" + b.to_s`
+func (g *Generator) compileInterpolationExpression(is *InstructionSet, exp *ast.InterpolationExpression, scope *scope, table *localTable) {
+	is.define(PutString, exp.Line(), exp.Parts[0].(*ast.StringLiteral).Value)
+
+	for _, part := range exp.Parts[1:] {
+		if sl, ok := part.(*ast.StringLiteral); ok {
+			if sl.Value == "" {
+				continue
+			}
+
+			is.define(PutString, exp.Line(), sl.Value)
+		} else {
+			g.compileExpression(is, part, scope, table)
+			is.define(Send, exp.Line(), "to_s", 0, "")
+		}
+
+		is.define(Send, exp.Line(), "+", 1, "")
+	}
 }
 
 func (g *Generator) compileYieldExpression(is *InstructionSet, exp *ast.YieldExpression, scope *scope, table *localTable) {
@@ -81,16 +117,28 @@ func (g *Generator) compileYieldExpression(is *InstructionSet, exp *ast.YieldExp
 
 func (g *Generator) compileCallExpression(is *InstructionSet, exp *ast.CallExpression, scope *scope, table *localTable) {
 	var blockInfo string
+	var blockArg ast.Expression
+	var block *InstructionSet
+	args := exp.Arguments
+
+	// Proc argument like `foo(&blk)` is passed as the method's block
+	if len(args) > 0 {
+		if pe, ok := args[len(args)-1].(*ast.PrefixExpression); ok && pe.Operator == "&" {
+			blockArg = pe.Right
+			args = args[:len(args)-1]
+		}
+	}
+
 	argSet := &ArgSet{
-		names: make([]string, len(exp.Arguments)),
-		types: make([]int, len(exp.Arguments)),
+		names: make([]string, len(args)),
+		types: make([]int, len(args)),
 	}
 
 	// Compile receiver
 	g.compileExpression(is, exp.Receiver, scope, table)
 
 	// Compile arguments
-	for i, arg := range exp.Arguments {
+	for i, arg := range args {
 		switch arg := arg.(type) {
 		case *ast.Identifier:
 			argSet.setArg(i, arg.Value, NormalArg)
@@ -118,18 +166,23 @@ func (g *Generator) compileCallExpression(is *InstructionSet, exp *ast.CallExpre
 	}
 
 	// Compile block
-	if exp.Block != nil {
+	if blockArg != nil {
+		g.compileExpression(is, blockArg, scope, table)
+		blockInfo = ProcBlock
+	} else if exp.Block != nil {
 		// Inside block should be one level deeper than outside
 		newTable := newLocalTable(table.depth + 1)
 		newTable.upper = table
 		blockIndex := g.blockCounter
 		blockInfo = fmt.Sprintf("block:%d", blockIndex)
 		g.blockCounter++
-		g.compileBlockArgExpression(blockIndex, exp, scope, newTable)
+		block = g.compileBlockArgExpression(blockIndex, exp, scope, newTable)
 	}
 
-	i := is.define(Send, exp.Line(), exp.Method, len(exp.Arguments), blockInfo)
+	i := is.define(Send, exp.Line(), exp.Method, len(args), blockInfo)
 	i.ArgSet = argSet
+	i.Body = block
+	_, i.SelfCall = exp.Receiver.(*ast.SelfExpression)
 }
 
 func (g *Generator) compileAssignExpression(is *InstructionSet, exp *ast.AssignExpression, scope *scope, table *localTable) {
@@ -171,18 +224,55 @@ func (g *Generator) compileAssignExpression(is *InstructionSet, exp *ast.AssignE
 	}
 }
 
-func (g *Generator) compileBlockArgExpression(index int, exp *ast.CallExpression, scope *scope, table *localTable) {
+func (g *Generator) compileBlockArgExpression(index int, exp *ast.CallExpression, scope *scope, table *localTable) *InstructionSet {
 	is := &InstructionSet{}
 	is.name = fmt.Sprint(index)
 	is.isType = Block
+	is.argTypes = &ArgSet{
+		names: make([]string, len(exp.BlockArguments)),
+		types: make([]int, len(exp.BlockArguments)),
+	}
+
+	// Parameters are set first so they take the first local indexes in order, then optional parameters' default values are compiled
+	for i, arg := range exp.BlockArguments {
+		switch arg := arg.(type) {
+		case *ast.Identifier:
+			table.set(arg.Value)
+			is.argTypes.setArg(i, arg.Value, NormalArg)
+		case *ast.AssignExpression:
+			name := arg.Variables[0].(*ast.Identifier).Value
+			table.set(name)
+			is.argTypes.setArg(i, name, OptionedArg)
+		case *ast.PrefixExpression:
+			name := arg.Right.(*ast.Identifier).Value
+			table.set(name)
 
-	for i := 0; i < len(exp.BlockArguments); i++ {
-		table.set(exp.BlockArguments[i].Value)
+			if arg.Operator == "&" {
+				is.argTypes.setArg(i, name, BlockArg)
+			} else {
+				is.argTypes.setArg(i, name, SplatArg)
+			}
+		}
+	}
+
+	for _, arg := range exp.BlockArguments {
+		if assign, ok := arg.(*ast.AssignExpression); ok {
+			assign.Optioned = 1
+			g.compileAssignExpression(is, assign, scope, table)
+		}
 	}
 
+	// Block has its own call frame, so it doesn't share error handlers with the method that calls it
+	handlers, loopHandlers := scope.handlers, scope.loopHandlers
+	scope.handlers, scope.loopHandlers = nil, 0
+
 	g.compileCodeBlock(is, exp.Block, scope, table)
+
+	scope.handlers, scope.loopHandlers = handlers, loopHandlers
 	g.endInstructions(is, exp.Line())
 	g.instructionSets = append(g.instructionSets, is)
+
+	return is
 }
 
 func (g *Generator) compileIfExpression(is *InstructionSet, exp *ast.IfExpression, scope *scope, table *localTable) {
@@ -217,6 +307,133 @@ func (g *Generator) compileIfExpression(is *InstructionSet, exp *ast.IfExpressio
 	anchorLast.line = is.count
 }
 
+/*
+	A begin expression is compiled like:
+
+	```
+	push_handler <ensure error>  # only when there's an ensure clause
+	push_handler <rescue>        # only when there're rescue clauses
+	<body>
+	pop_handler
+	jump <done>
+	<rescue>:                    # error is on the stack
+	<error classes>
+	match_error <count>
+	branchunless <next rescue>
+	<rescue body>
+	jump <done>
+	throw                        # no rescue clause matched
+	<done>:
+	pop_handler
+	<ensure body>
+	jump <end>
+	<ensure error>:              # error is on the stack
+	<ensure body>
+	throw
+	<end>:
+	```
+*/
+func (g *Generator) compileBeginExpression(is *InstructionSet, exp *ast.BeginExpression, scope *scope, table *localTable) {
+	anchorDone := &anchor{}
+	anchorRescue := &anchor{}
+	anchorEnsureErr := &anchor{}
+	handlerCount := len(scope.handlers)
+
+	if exp.Ensure != nil {
+		is.define(PushHandler, exp.Line(), anchorEnsureErr)
+		scope.handlers = append(scope.handlers, &handler{ensure: exp.Ensure})
+	}
+
+	if len(exp.Rescues) > 0 {
+		is.define(PushHandler, exp.Line(), anchorRescue)
+		scope.handlers = append(scope.handlers, &handler{})
+	}
+
+	g.compileBlockValue(is, exp.Body, exp.Line(), scope, table)
+
+	if len(exp.Rescues) > 0 {
+		is.define(PopHandler, exp.Line())
+		scope.handlers = scope.handlers[:len(scope.handlers)-1]
+	}
+
+	if exp.Else != nil {
+		is.define(Pop, exp.Line())
+		g.compileBlockValue(is, exp.Else, exp.Line(), scope, table)
+	}
+
+	is.define(Jump, exp.Line(), anchorDone)
+
+	if len(exp.Rescues) > 0 {
+		anchorRescue.line = is.count
+
+		for _, r := range exp.Rescues {
+			anchorNext := &anchor{}
+
+			if len(r.ErrorClasses) > 0 {
+				for _, c := range r.ErrorClasses {
+					g.compileExpression(is, c, scope, table)
+				}
+
+				is.define(MatchError, r.Line(), len(r.ErrorClasses))
+				is.define(BranchUnless, r.Line(), anchorNext)
+			}
+
+			if r.Variable != nil {
+				index, depth := table.setLCL(r.Variable.Value, table.depth)
+				is.define(SetLocal, r.Line(), depth, index)
+			}
+
+			is.define(Pop, r.Line())
+			g.compileBlockValue(is, r.Body, r.Line(), scope, table)
+			is.define(Jump, r.Line(), anchorDone)
+			anchorNext.line = is.count
+		}
+
+		is.define(Throw, exp.Line())
+	}
+
+	anchorDone.line = is.count
+
+	if exp.Ensure == nil {
+		return
+	}
+
+	anchorEnd := &anchor{}
+	scope.handlers = scope.handlers[:handlerCount]
+
+	is.define(PopHandler, exp.Line())
+	g.compileEnsureBlock(is, exp.Ensure, scope, table)
+	is.define(Jump, exp.Line(), anchorEnd)
+
+	anchorEnsureErr.line = is.count
+	g.compileEnsureBlock(is, exp.Ensure, scope, table)
+	is.define(Throw, exp.Line())
+
+	anchorEnd.line = is.count
+}
+
+// compileBlockValue compiles the block statement and makes sure it leaves a value on the stack
+func (g *Generator) compileBlockValue(is *InstructionSet, stmt *ast.BlockStatement, sourceLine int, scope *scope, table *localTable) {
+	if stmt.IsEmpty() {
+		is.define(PutNull, sourceLine)
+		return
+	}
+
+	g.compileCodeBlock(is, stmt, scope, table)
+}
+
+// compileEnsureBlock compiles the ensure clause, which shouldn't leave any value on the stack
+func (g *Generator) compileEnsureBlock(is *InstructionSet, stmt *ast.BlockStatement, scope *scope, table *localTable) {
+	for _, s := range stmt.Statements {
+		g.compileStatement(is, s, scope, table)
+
+		// In REPL mode expression statements' values are kept, so we need to pop them manually
+		if _, ok := s.(*ast.ExpressionStatement); ok && g.REPL {
+			is.define(Pop, s.Line())
+		}
+	}
+}
+
 func (g *Generator) compilePrefixExpression(is *InstructionSet, exp *ast.PrefixExpression, scope *scope, table *localTable) {
 	switch exp.Operator {
 	case "!":
@@ -260,6 +477,6 @@ func (g *Generator) compileInfixExpression(is *InstructionSet, node *ast.InfixEx
 	default:
 		g.compileExpression(is, node.Left, scope, table)
 		g.compileExpression(is, node.Right, scope, table)
-		is.define(Send, node.Line(), node.Operator, "1", "")
+		is.define(Send, node.Line(), node.Operator, 1, "")
 	}
 }
@@ -190,6 +190,49 @@ func TestIfExpressionWithElsifAndAlternativeCompilation(t *testing.T) {
 	compareBytecode(t, bytecode, expected)
 }
 
+func TestBeginExpressionCompilation(t *testing.T) {
+	input := `
+	x = begin
+	  10
+	rescue ArgumentError => e
+	  20
+	ensure
+	  x = 1
+	end
+	`
+
+	expected := `
+<ProgramStart>
+0 push_handler 18
+1 push_handler 5
+2 putobject 10
+3 pop_handler
+4 jump 13
+5 getconstant ArgumentError false
+6 match_error 1
+7 branchunless 12
+8 setlocal 0 0
+9 pop
+10 putobject 20
+11 jump 13
+12 throw
+13 pop_handler
+14 putobject 1
+15 setlocal 0 1
+16 pop
+17 jump 22
+18 putobject 1
+19 setlocal 0 1
+20 pop
+21 throw
+22 setlocal 0 1
+23 leave
+`
+
+	bytecode := compileToBytecode(input)
+	compareBytecode(t, bytecode, expected)
+}
+
 func TestMultipleVariableAssignmentCompilation(t *testing.T) {
 	input := `
 
@@ -275,6 +318,105 @@ func TestBooleanCompilation(t *testing.T) {
 	compareBytecode(t, bytecode, expected)
 }
 
+func TestFloatCompilation(t *testing.T) {
+	input := `
+	a = 1.5
+	b = 1e-3
+	a + b + 0x10
+	`
+
+	expected := `
+<ProgramStart>
+0 putobject 1.5 float
+1 setlocal 0 0
+2 pop
+3 putobject 0.001 float
+4 setlocal 0 1
+5 pop
+6 getlocal 0 0
+7 getlocal 0 1
+8 send + 1
+9 putobject 16
+10 send + 1
+11 leave
+`
+
+	bytecode := compileToBytecode(input)
+	compareBytecode(t, bytecode, expected)
+}
+
+func TestSymbolCompilation(t *testing.T) {
+	input := `
+	a = :foo
+	[1].map(&:to_s)
+	`
+
+	expected := `
+<ProgramStart>
+0 putsymbol foo
+1 setlocal 0 0
+2 pop
+3 putobject 1
+4 newarray 1
+5 putsymbol to_s
+6 send map 0 block:&
+7 leave
+`
+
+	bytecode := compileToBytecode(input)
+	compareBytecode(t, bytecode, expected)
+}
+
+func TestRegexpCompilation(t *testing.T) {
+	input := `
+	a = /ab+c/i
+	"abc" =~ /b/
+	`
+
+	expected := `
+<ProgramStart>
+0 putregexp ab+c i
+1 setlocal 0 0
+2 pop
+3 putstring abc
+4 putregexp b
+5 send =~ 1
+6 leave
+`
+
+	bytecode := compileToBytecode(input)
+	compareBytecode(t, bytecode, expected)
+}
+
+func TestInterpolationCompilation(t *testing.T) {
+	input := `
+	name = "Goby"
+	"Hello #{name}, #{1 + 2}"
+	`
+
+	expected := `
+<ProgramStart>
+0 putstring Goby
+1 setlocal 0 0
+2 pop
+3 putstring Hello 
+4 getlocal 0 0
+5 send to_s 0
+6 send + 1
+7 putstring , 
+8 send + 1
+9 putobject 1
+10 putobject 2
+11 send + 1
+12 send to_s 0
+13 send + 1
+14 leave
+`
+
+	bytecode := compileToBytecode(input)
+	compareBytecode(t, bytecode, expected)
+}
+
 func TestArrayCompilation(t *testing.T) {
 	input := `
 	a = [1, 2, "bar"]
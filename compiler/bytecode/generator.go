@@ -13,6 +13,15 @@ type scope struct {
 	localTable *localTable
 	line       int
 	anchors    map[string]*anchor
+	// handlers are the error handlers pushed by enclosing `begin` expressions, innermost last
+	handlers []*handler
+	// loopHandlers is the number of handlers that were pushed outside of current loop
+	loopHandlers int
+}
+
+// handler represents a pushed error handler and the `ensure` body needs to run when it's removed
+type handler struct {
+	ensure *ast.BlockStatement
 }
 
 func newScope(stmt ast.Statement) *scope {
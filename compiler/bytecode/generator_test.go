@@ -305,6 +305,38 @@ func TestMethodDefWithDefaultValueArgument(t *testing.T) {
 	compareBytecode(t, bytecode, expected)
 }
 
+func TestMethodDefWithBlockParameter(t *testing.T) {
+	input := `
+	def foo(x, &block)
+	  bar(x, &block)
+	end
+
+	foo(100)
+	`
+
+	expected := `
+<Def:foo>
+0 getblock
+1 setlocal 0 1 1
+2 putself
+3 getlocal 0 0
+4 getlocal 0 1
+5 send bar 1 block:&
+6 leave
+<ProgramStart>
+0 putself
+1 putstring foo
+2 def_method 1
+3 putself
+4 putobject 100
+5 send foo 1
+6 leave
+`
+
+	bytecode := compileToBytecode(input)
+	compareBytecode(t, bytecode, expected)
+}
+
 func compileToBytecode(input string) string {
 	l := lexer.New(input)
 	p := parser.New(l)
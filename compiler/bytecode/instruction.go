@@ -14,44 +14,119 @@ const (
 	Program   = "ProgramStart"
 )
 
-// instruction actions
+// Opcode is the numeric code of an instruction's action, the VM uses it to index its jump table
+type Opcode uint8
+
+// instruction opcodes
 const (
-	GetLocal            = "getlocal"
-	GetConstant         = "getconstant"
-	GetInstanceVariable = "getinstancevariable"
-	SetLocal            = "setlocal"
-	SetConstant         = "setconstant"
-	SetInstanceVariable = "setinstancevariable"
-	PutString           = "putstring"
-	PutSelf             = "putself"
-	PutObject           = "putobject"
-	PutNull             = "putnil"
-	NewArray            = "newarray"
-	ExpandArray         = "expand_array"
-	SplatArray          = "splat_array"
-	NewHash             = "newhash"
-	NewRange            = "newrange"
-	BranchUnless        = "branchunless"
-	BranchIf            = "branchif"
-	Jump                = "jump"
-	DefMethod           = "def_method"
-	DefSingletonMethod  = "def_singleton_method"
-	DefClass            = "def_class"
-	Send                = "send"
-	InvokeBlock         = "invokeblock"
-	Pop                 = "pop"
-	Dup                 = "dup"
-	Leave               = "leave"
+	GetLocal Opcode = iota
+	GetConstant
+	GetInstanceVariable
+	SetLocal
+	SetConstant
+	SetInstanceVariable
+	PutString
+	PutSymbol
+	PutRegexp
+	PutSelf
+	PutObject
+	PutNull
+	NewArray
+	ExpandArray
+	SplatArray
+	NewHash
+	NewRange
+	BranchUnless
+	BranchIf
+	Jump
+	DefMethod
+	DefSingletonMethod
+	DefClass
+	Send
+	InvokeBlock
+	GetBlock
+	Pop
+	Dup
+	Leave
+	PushHandler
+	PopHandler
+	MatchError
+	Throw
+	// CallGoBlock calls a Go function as the block's body, it's only created by the VM for the blocks
+	// that built-in methods pass to Goby methods, so it's never compiled from source code
+	CallGoBlock
+	// OpcodeCount is the number of opcodes, it's not an instruction
+	OpcodeCount
 )
 
-// Instruction represents compiled bytecode instruction
+// instructionNames are the opcodes' names used in the readable bytecode
+var instructionNames = [OpcodeCount]string{
+	GetLocal:            "getlocal",
+	GetConstant:         "getconstant",
+	GetInstanceVariable: "getinstancevariable",
+	SetLocal:            "setlocal",
+	SetConstant:         "setconstant",
+	SetInstanceVariable: "setinstancevariable",
+	PutString:           "putstring",
+	PutSymbol:           "putsymbol",
+	PutRegexp:           "putregexp",
+	PutSelf:             "putself",
+	PutObject:           "putobject",
+	PutNull:             "putnil",
+	NewArray:            "newarray",
+	ExpandArray:         "expand_array",
+	SplatArray:          "splat_array",
+	NewHash:             "newhash",
+	NewRange:            "newrange",
+	BranchUnless:        "branchunless",
+	BranchIf:            "branchif",
+	Jump:                "jump",
+	DefMethod:           "def_method",
+	DefSingletonMethod:  "def_singleton_method",
+	DefClass:            "def_class",
+	Send:                "send",
+	InvokeBlock:         "invokeblock",
+	GetBlock:            "getblock",
+	Pop:                 "pop",
+	Dup:                 "dup",
+	Leave:               "leave",
+	PushHandler:         "push_handler",
+	PopHandler:          "pop_handler",
+	MatchError:          "match_error",
+	Throw:               "throw",
+	CallGoBlock:         "call_go_block",
+}
+
+// String returns the opcode's name in the readable bytecode
+func (op Opcode) String() string {
+	if op >= OpcodeCount {
+		return fmt.Sprintf("unknown(%d)", uint8(op))
+	}
+
+	return instructionNames[op]
+}
+
+// ProcBlock is send's block flag when the block is given by a Proc object like `foo(&blk)`.
+// The Proc object is pushed right after the arguments.
+const ProcBlock = "block:&"
+
+// FloatObject is the type tag of putobject's float value, like `putobject 1.5 float`
+const FloatObject = "float"
+
+// Instruction represents compiled bytecode instruction.
+// Its params are typed operands, which can be strings, ints, bools or float64s.
 type Instruction struct {
-	Action     string
-	Params     []string
+	Action     Opcode
+	Params     []interface{}
 	line       int
 	anchor     *anchor
 	sourceLine int
 	ArgSet     *ArgSet
+	// Body is the instruction set of the method or class defined by the instruction, or the block passed by a send instruction
+	Body *InstructionSet
+	// SelfCall is true if a send instruction's receiver is self, no matter it's implicit like `foo` or written as `self.foo`.
+	// Private methods can only be called this way.
+	SelfCall bool
 }
 
 // AnchorLine returns instruction anchor's line number if it has an anchor
@@ -78,15 +153,19 @@ func (i *Instruction) compile() string {
 		return fmt.Sprintf("%d %s %d\n", i.line, i.Action, i.anchor.line)
 	}
 	if len(i.Params) > 0 {
-		lastParam := i.Params[len(i.Params)-1]
+		params := []string{}
+
+		for _, param := range i.Params {
+			params = append(params, fmt.Sprint(param))
+		}
 
 		// If the send action doesn't have a block (block info), we'll have a trailing space after join.
 		// So we need to remove that empty string element
-		if i.Action == Send && len(lastParam) == 0 {
-			return fmt.Sprintf("%d %s %s\n", i.line, i.Action, strings.Join(i.Params[:len(i.Params)-1], " "))
+		if i.Action == Send && len(params[len(params)-1]) == 0 {
+			params = params[:len(params)-1]
 		}
 
-		return fmt.Sprintf("%d %s %s\n", i.line, i.Action, strings.Join(i.Params, " "))
+		return fmt.Sprintf("%d %s %s\n", i.line, i.Action, strings.Join(params, " "))
 	}
 
 	return fmt.Sprintf("%d %s\n", i.line, i.Action)
@@ -111,6 +190,11 @@ type ArgSet struct {
 	types []int
 }
 
+// NewArgSet returns an ArgSet with given argument names and types
+func NewArgSet(names []string, types []int) *ArgSet {
+	return &ArgSet{names: names, types: types}
+}
+
 // Types are the getter method of *ArgSet's types attribute
 func (as *ArgSet) Types() []int {
 	return as.types
@@ -151,17 +235,15 @@ func (is *InstructionSet) Type() string {
 	return is.isType
 }
 
-func (is *InstructionSet) define(action string, sourceLine int, params ...interface{}) *Instruction {
-	ps := []string{}
+func (is *InstructionSet) define(action Opcode, sourceLine int, params ...interface{}) *Instruction {
+	ps := []interface{}{}
 	i := &Instruction{Action: action, Params: ps, line: is.count, sourceLine: sourceLine}
 	for _, param := range params {
 		switch p := param.(type) {
-		case string:
-			ps = append(ps, p)
 		case *anchor:
 			i.anchor = p
-		case int:
-			ps = append(ps, fmt.Sprint(p))
+		default:
+			ps = append(ps, p)
 		}
 	}
 
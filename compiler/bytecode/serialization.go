@@ -0,0 +1,399 @@
+package bytecode
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// FileExtension is the extension of precompiled bytecode files
+const FileExtension = "gbc"
+
+// FormatVersion is the version of the binary bytecode format.
+// It should be bumped whenever the opcodes or the encoding change, so outdated files are recompiled instead of being executed.
+const FormatVersion uint16 = 1
+
+// magic is the beginning of every bytecode file
+var magic = []byte("GBC\x00")
+
+// operand type tags
+const (
+	stringOperand byte = iota
+	intOperand
+	boolOperand
+	floatOperand
+)
+
+// valueOperand matches the int, bool and float operands, which are the values of `putobject`
+const valueOperand byte = 0xff
+
+// operandSpec is the types of an opcode's operands, the operands after the required ones are optional
+type operandSpec struct {
+	required int
+	types    []byte
+}
+
+// operandSpecs are the operands of each opcode, the omitted ones have no operands
+var operandSpecs = [OpcodeCount]operandSpec{
+	GetLocal:            {2, []byte{intOperand, intOperand}},
+	GetConstant:         {2, []byte{stringOperand, boolOperand}},
+	GetInstanceVariable: {1, []byte{stringOperand}},
+	SetLocal:            {2, []byte{intOperand, intOperand, intOperand}},
+	SetConstant:         {1, []byte{stringOperand}},
+	SetInstanceVariable: {1, []byte{stringOperand}},
+	PutString:           {1, []byte{stringOperand}},
+	PutSymbol:           {1, []byte{stringOperand}},
+	PutRegexp:           {1, []byte{stringOperand, stringOperand}},
+	PutObject:           {1, []byte{valueOperand, stringOperand}},
+	NewArray:            {1, []byte{intOperand}},
+	ExpandArray:         {1, []byte{intOperand}},
+	NewHash:             {1, []byte{intOperand}},
+	NewRange:            {1, []byte{intOperand}},
+	DefMethod:           {1, []byte{intOperand}},
+	DefSingletonMethod:  {1, []byte{intOperand}},
+	DefClass:            {1, []byte{stringOperand, stringOperand}},
+	Send:                {3, []byte{stringOperand, intOperand, stringOperand}},
+	InvokeBlock:         {1, []byte{intOperand}},
+	MatchError:          {1, []byte{intOperand}},
+}
+
+// noBody marks an instruction without a method, class or block body
+const noBody = -1
+
+// Marshal encodes instruction sets into the binary bytecode format.
+// The file starts with a header, which contains the format version and the source file's modification time.
+func Marshal(sets []*InstructionSet, sourceModTime int64) ([]byte, error) {
+	e := &encoder{}
+	indexes := make(map[*InstructionSet]int)
+
+	for i, is := range sets {
+		indexes[is] = i
+	}
+
+	e.buf.Write(magic)
+	binary.Write(&e.buf, binary.BigEndian, FormatVersion)
+	e.writeInt(sourceModTime)
+	e.writeInt(int64(len(sets)))
+
+	for _, is := range sets {
+		e.writeString(is.name)
+		e.writeString(is.isType)
+		e.writeArgSet(is.argTypes)
+		e.writeInt(int64(len(is.Instructions)))
+
+		for _, i := range is.Instructions {
+			err := e.writeInstruction(i, indexes)
+
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return e.buf.Bytes(), nil
+}
+
+// Unmarshal decodes the binary bytecode into instruction sets,
+// and returns the source file's modification time recorded in the header.
+func Unmarshal(data []byte) ([]*InstructionSet, int64, error) {
+	d := &decoder{data: data}
+
+	if !bytes.HasPrefix(data, magic) {
+		return nil, 0, fmt.Errorf("Invalid bytecode file")
+	}
+
+	d.pos = len(magic)
+
+	if len(data) < d.pos+2 {
+		return nil, 0, fmt.Errorf("Invalid bytecode file")
+	}
+
+	version := binary.BigEndian.Uint16(data[d.pos:])
+	d.pos += 2
+
+	if version != FormatVersion {
+		return nil, 0, fmt.Errorf("Bytecode format version %d is not supported. expect: %d", version, FormatVersion)
+	}
+
+	modTime := d.readInt()
+	sets := make([]*InstructionSet, d.readLength())
+
+	// Instructions can refer to bodies after them, so all sets are created before decoding instructions
+	for i := range sets {
+		sets[i] = &InstructionSet{}
+	}
+
+	for _, is := range sets {
+		is.name = d.readString()
+		is.isType = d.readString()
+		is.argTypes = d.readArgSet()
+		count := d.readLength()
+
+		for j := 0; j < count && d.err == nil; j++ {
+			is.Instructions = append(is.Instructions, d.readInstruction(sets))
+		}
+
+		is.count = len(is.Instructions)
+	}
+
+	if d.err != nil {
+		return nil, 0, d.err
+	}
+
+	return sets, modTime, nil
+}
+
+type encoder struct {
+	buf bytes.Buffer
+}
+
+func (e *encoder) writeInstruction(i *Instruction, indexes map[*InstructionSet]int) error {
+	e.buf.WriteByte(byte(i.Action))
+	e.writeInt(int64(i.line))
+	e.writeInt(int64(i.sourceLine))
+
+	if i.anchor != nil {
+		e.writeBool(true)
+		e.writeInt(int64(i.anchor.line))
+	} else {
+		e.writeBool(false)
+	}
+
+	e.writeInt(int64(len(i.Params)))
+
+	for _, param := range i.Params {
+		switch p := param.(type) {
+		case string:
+			e.buf.WriteByte(stringOperand)
+			e.writeString(p)
+		case int:
+			e.buf.WriteByte(intOperand)
+			e.writeInt(int64(p))
+		case bool:
+			e.buf.WriteByte(boolOperand)
+			e.writeBool(p)
+		case float64:
+			e.buf.WriteByte(floatOperand)
+			binary.Write(&e.buf, binary.BigEndian, math.Float64bits(p))
+		default:
+			return fmt.Errorf("Can't serialize operand %v of %s. line: %d", param, i.Action, i.line)
+		}
+	}
+
+	e.writeArgSet(i.ArgSet)
+	e.writeBool(i.SelfCall)
+
+	if i.Body == nil {
+		e.writeInt(noBody)
+		return nil
+	}
+
+	index, ok := indexes[i.Body]
+
+	if !ok {
+		return fmt.Errorf("Can't find the body of %s. line: %d", i.Action, i.line)
+	}
+
+	e.writeInt(int64(index))
+
+	return nil
+}
+
+func (e *encoder) writeArgSet(as *ArgSet) {
+	if as == nil {
+		e.writeBool(false)
+		return
+	}
+
+	e.writeBool(true)
+	e.writeInt(int64(len(as.names)))
+
+	for i, name := range as.names {
+		e.writeString(name)
+		e.writeInt(int64(as.types[i]))
+	}
+}
+
+func (e *encoder) writeInt(i int64) {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(b[:], i)
+	e.buf.Write(b[:n])
+}
+
+func (e *encoder) writeBool(b bool) {
+	if b {
+		e.buf.WriteByte(1)
+	} else {
+		e.buf.WriteByte(0)
+	}
+}
+
+func (e *encoder) writeString(s string) {
+	e.writeInt(int64(len(s)))
+	e.buf.WriteString(s)
+}
+
+// decoder reads the binary bytecode, it stops reading after the first error
+type decoder struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+func (d *decoder) readInstruction(sets []*InstructionSet) *Instruction {
+	i := &Instruction{Action: Opcode(d.readByte())}
+	i.line = int(d.readInt())
+	i.sourceLine = int(d.readInt())
+
+	if d.readBool() {
+		i.anchor = &anchor{line: int(d.readInt())}
+	}
+
+	// CallGoBlock is only created by the VM, so it can't be in a file
+	if i.Action >= OpcodeCount || i.Action == CallGoBlock {
+		d.fail("Unknown opcode %d", uint8(i.Action))
+		return i
+	}
+
+	spec := operandSpecs[i.Action]
+	count := d.readLength()
+
+	if d.err == nil && (count < spec.required || count > len(spec.types)) {
+		d.fail("Invalid operand count %d for %s. line: %d", count, i.Action, i.line)
+	}
+
+	for j := 0; j < count && d.err == nil; j++ {
+		tag := d.readByte()
+
+		if !operandMatches(spec.types[j], tag) {
+			d.fail("Invalid type of operand %d for %s. line: %d", j, i.Action, i.line)
+			break
+		}
+
+		switch tag {
+		case stringOperand:
+			i.Params = append(i.Params, d.readString())
+		case intOperand:
+			i.Params = append(i.Params, int(d.readInt()))
+		case boolOperand:
+			i.Params = append(i.Params, d.readBool())
+		case floatOperand:
+			if len(d.data) < d.pos+8 {
+				d.fail("Unexpected end of bytecode")
+				break
+			}
+
+			i.Params = append(i.Params, math.Float64frombits(binary.BigEndian.Uint64(d.data[d.pos:])))
+			d.pos += 8
+		default:
+			d.fail("Unknown operand type %d", tag)
+		}
+	}
+
+	i.ArgSet = d.readArgSet()
+	i.SelfCall = d.readBool()
+
+	if index := d.readInt(); index != noBody {
+		if index < 0 || index >= int64(len(sets)) {
+			d.fail("Can't find the body of %s. line: %d", i.Action, i.line)
+		} else {
+			i.Body = sets[index]
+		}
+	}
+
+	return i
+}
+
+// operandMatches reports whether the operand type tag is accepted by the expected type
+func operandMatches(expected, tag byte) bool {
+	if expected == valueOperand {
+		return tag == intOperand || tag == boolOperand || tag == floatOperand
+	}
+
+	return tag == expected
+}
+
+func (d *decoder) readArgSet() *ArgSet {
+	if !d.readBool() {
+		return nil
+	}
+
+	count := d.readLength()
+	as := &ArgSet{names: []string{}, types: []int{}}
+
+	for i := 0; i < count && d.err == nil; i++ {
+		as.names = append(as.names, d.readString())
+		as.types = append(as.types, int(d.readInt()))
+	}
+
+	return as
+}
+
+func (d *decoder) fail(format string, args ...interface{}) {
+	if d.err == nil {
+		d.err = fmt.Errorf(format, args...)
+	}
+}
+
+func (d *decoder) readByte() byte {
+	if d.err != nil {
+		return 0
+	}
+
+	if d.pos >= len(d.data) {
+		d.fail("Unexpected end of bytecode")
+		return 0
+	}
+
+	b := d.data[d.pos]
+	d.pos++
+
+	return b
+}
+
+func (d *decoder) readInt() int64 {
+	if d.err != nil {
+		return 0
+	}
+
+	i, n := binary.Varint(d.data[d.pos:])
+
+	if n <= 0 {
+		d.fail("Unexpected end of bytecode")
+		return 0
+	}
+
+	d.pos += n
+
+	return i
+}
+
+// readLength reads a count or a length, which can't be larger than the remaining bytes
+func (d *decoder) readLength() int {
+	l := d.readInt()
+
+	if l < 0 || l > int64(len(d.data)-d.pos) {
+		d.fail("Invalid length %d in bytecode", l)
+		return 0
+	}
+
+	return int(l)
+}
+
+func (d *decoder) readBool() bool {
+	return d.readByte() == 1
+}
+
+func (d *decoder) readString() string {
+	l := d.readLength()
+
+	if d.err != nil {
+		return ""
+	}
+
+	s := string(d.data[d.pos : d.pos+l])
+	d.pos += l
+
+	return s
+}
@@ -0,0 +1,160 @@
+package bytecode
+
+import (
+	"github.com/goby-lang/goby/compiler/lexer"
+	"github.com/goby-lang/goby/compiler/parser"
+	"testing"
+)
+
+func TestMarshalAndUnmarshal(t *testing.T) {
+	input := `
+	class Foo < Bar
+	  def bar(x, y = 1.5, z:, *args, &blk)
+	    [x, y, "str", :sym, true, nil].map do |e|
+	      e.to_s
+	    end
+	  end
+	end
+
+	module Baz; end
+
+	def self.qux; end
+
+	begin
+	  Foo.new.bar(1, z: 2, &blk)
+	rescue StandardError => e
+	  qux
+	end
+	`
+
+	sets := compileToInstructions(input)
+	data, err := Marshal(sets, 12345)
+
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	decoded, modTime, err := Unmarshal(data)
+
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if modTime != 12345 {
+		t.Fatalf("Expect source modification time to be 12345. got: %d", modTime)
+	}
+
+	if len(decoded) != len(sets) {
+		t.Fatalf("Expect %d instruction sets. got: %d", len(sets), len(decoded))
+	}
+
+	for i, is := range sets {
+		compareBytecode(t, decoded[i].compile(), is.compile())
+
+		if is.argTypes != nil {
+			compareArgSet(t, decoded[i].argTypes, is.argTypes)
+		}
+
+		for j, ins := range is.Instructions {
+			d := decoded[i].Instructions[j]
+
+			if d.SelfCall != ins.SelfCall {
+				t.Fatalf("Expect %s's SelfCall to be %t", ins.Action, ins.SelfCall)
+			}
+
+			if ins.ArgSet != nil {
+				compareArgSet(t, d.ArgSet, ins.ArgSet)
+			}
+
+			for k, p := range ins.Params {
+				if d.Params[k] != p {
+					t.Fatalf("Expect %s's param to be %#v. got: %#v", ins.Action, p, d.Params[k])
+				}
+			}
+
+			if ins.Body == nil {
+				if d.Body != nil {
+					t.Fatalf("Expect %s to have no body", ins.Action)
+				}
+				continue
+			}
+
+			if d.Body != decoded[indexOfSet(sets, ins.Body)] {
+				t.Fatalf("Expect %s's body to be %s", ins.Action, ins.Body.name)
+			}
+		}
+	}
+}
+
+func TestUnmarshalFail(t *testing.T) {
+	data, _ := Marshal(compileToInstructions(`puts("foo")`), 0)
+	outdated := append([]byte{}, data...)
+	outdated[len(magic)+1]++
+
+	sets := compileToInstructions(`puts("foo")`)
+	send := sets[0].Instructions[2]
+	send.Params = send.Params[:2]
+	badArity, _ := Marshal(sets, 0)
+
+	send.Params = []interface{}{"puts", "1", ""}
+	badType, _ := Marshal(sets, 0)
+
+	send.Action = CallGoBlock
+	goBlock, _ := Marshal(sets, 0)
+
+	tests := []struct {
+		data     []byte
+		expected string
+	}{
+		{[]byte("puts(1)"), "Invalid bytecode file"},
+		{data[:len(magic)], "Invalid bytecode file"},
+		{outdated, "Bytecode format version 2 is not supported. expect: 1"},
+		{data[:len(data)-3], "Unexpected end of bytecode"},
+		{badArity, "Invalid operand count 2 for send. line: 2"},
+		{badType, "Invalid type of operand 1 for send. line: 2"},
+		{goBlock, "Unknown opcode 33"},
+	}
+
+	for i, tt := range tests {
+		_, _, err := Unmarshal(tt.data)
+
+		if err == nil || err.Error() != tt.expected {
+			t.Fatalf("At case %d expect error %q. got: %v", i, tt.expected, err)
+		}
+	}
+}
+
+func compileToInstructions(input string) []*InstructionSet {
+	l := lexer.New(input)
+	p := parser.New(l)
+	p.Mode = parser.NormalMode
+	program, err := p.ParseProgram()
+	if err != nil {
+		panic(err.Message)
+	}
+	g := NewGenerator()
+	g.InitTopLevelScope(program)
+	return g.GenerateInstructions(program.Statements)
+}
+
+func compareArgSet(t *testing.T, value, expected *ArgSet) {
+	if value == nil || len(value.names) != len(expected.names) {
+		t.Fatalf("Expect arg set %v. got: %v", expected, value)
+	}
+
+	for i, name := range expected.names {
+		if value.names[i] != name || value.types[i] != expected.types[i] {
+			t.Fatalf("Expect arg set %v. got: %v", expected, value)
+		}
+	}
+}
+
+func indexOfSet(sets []*InstructionSet, is *InstructionSet) int {
+	for i, s := range sets {
+		if s == is {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -13,6 +13,8 @@ const (
 	SplatArg
 	RequiredKeywordArg
 	OptionalKeywordArg
+	// BlockArg is only used by lambda literals, methods take their block parameters by GetBlock instruction
+	BlockArg
 )
 
 func (g *Generator) compileStatements(stmts []ast.Statement, scope *scope, table *localTable) {
@@ -58,13 +60,14 @@ func (g *Generator) compileStatement(is *InstructionSet, statement ast.Statement
 		g.compileModuleStmt(is, stmt, scope)
 	case *ast.ReturnStatement:
 		g.compileExpression(is, stmt.ReturnValue, scope, table)
+		g.removeHandlers(is, stmt, 0, scope, table)
 		g.endInstructions(is, stmt.Line())
 	case *ast.WhileStatement:
 		g.compileWhileStmt(is, stmt, scope, table)
 	case *ast.NextStatement:
-		g.compileNextStatement(is, stmt, scope)
+		g.compileNextStatement(is, stmt, scope, table)
 	case *ast.BreakStatement:
-		g.compileBreakStatement(is, stmt, scope)
+		g.compileBreakStatement(is, stmt, scope, table)
 	}
 }
 
@@ -79,8 +82,13 @@ func (g *Generator) compileWhileStmt(is *InstructionSet, stmt *ast.WhileStatemen
 	scope.anchors["next"] = anchor1
 	scope.anchors["break"] = breakAnchor
 
+	loopHandlers := scope.loopHandlers
+	scope.loopHandlers = len(scope.handlers)
+
 	g.compileCodeBlock(is, stmt.Body, scope, table)
 
+	scope.loopHandlers = loopHandlers
+
 	anchor1.line = is.count
 
 	g.compileExpression(is, stmt.Condition, scope, table)
@@ -90,47 +98,70 @@ func (g *Generator) compileWhileStmt(is *InstructionSet, stmt *ast.WhileStatemen
 	breakAnchor.line = is.count
 }
 
-func (g *Generator) compileNextStatement(is *InstructionSet, stmt ast.Statement, scope *scope) {
+func (g *Generator) compileNextStatement(is *InstructionSet, stmt ast.Statement, scope *scope, table *localTable) {
+	g.removeHandlers(is, stmt, scope.loopHandlers, scope, table)
 	is.define(Jump, stmt.Line(), scope.anchors["next"])
 }
 
-func (g *Generator) compileBreakStatement(is *InstructionSet, stmt ast.Statement, scope *scope) {
+func (g *Generator) compileBreakStatement(is *InstructionSet, stmt ast.Statement, scope *scope, table *localTable) {
+	g.removeHandlers(is, stmt, scope.loopHandlers, scope, table)
 	is.define(Jump, stmt.Line(), scope.anchors["break"])
 }
 
+// removeHandlers pops the error handlers above given depth and runs their ensure clauses,
+// this is needed when `next`, `break` or `return` jumps out of a begin expression.
+func (g *Generator) removeHandlers(is *InstructionSet, stmt ast.Statement, depth int, scope *scope, table *localTable) {
+	handlers := scope.handlers
+
+	for i := len(handlers) - 1; i >= depth; i-- {
+		is.define(PopHandler, stmt.Line())
+
+		if handlers[i].ensure != nil {
+			scope.handlers = handlers[:i]
+			g.compileEnsureBlock(is, handlers[i].ensure, scope, table)
+		}
+	}
+
+	scope.handlers = handlers
+}
+
 func (g *Generator) compileClassStmt(is *InstructionSet, stmt *ast.ClassStatement, scope *scope, table *localTable) {
 	is.define(PutSelf, stmt.Line())
 
+	// compile class's content
+	newIS := &InstructionSet{}
+	newIS.name = stmt.Name.Value
+	newIS.isType = ClassDef
+
+	var def *Instruction
+
 	if stmt.SuperClass != nil {
 		g.compileExpression(is, stmt.SuperClass, scope, table)
-		is.define(DefClass, stmt.Line(), "class:"+stmt.Name.Value, stmt.SuperClassName)
+		def = is.define(DefClass, stmt.Line(), "class:"+stmt.Name.Value, stmt.SuperClassName)
 	} else {
-		is.define(DefClass, stmt.Line(), "class:"+stmt.Name.Value)
+		def = is.define(DefClass, stmt.Line(), "class:"+stmt.Name.Value)
 	}
 
+	def.Body = newIS
 	is.define(Pop, stmt.Line())
 
 	scope = newScope(stmt)
 
-	// compile class's content
-	newIS := &InstructionSet{}
-	newIS.name = stmt.Name.Value
-	newIS.isType = ClassDef
-
 	g.compileCodeBlock(newIS, stmt.Body, scope, scope.localTable)
 	newIS.define(Leave, stmt.Line())
 	g.instructionSets = append(g.instructionSets, newIS)
 }
 
 func (g *Generator) compileModuleStmt(is *InstructionSet, stmt *ast.ModuleStatement, scope *scope) {
+	newIS := &InstructionSet{}
+	newIS.name = stmt.Name.Value
+	newIS.isType = ClassDef
+
 	is.define(PutSelf, stmt.Line())
-	is.define(DefClass, stmt.Line(), "module:"+stmt.Name.Value)
+	is.define(DefClass, stmt.Line(), "module:"+stmt.Name.Value).Body = newIS
 	is.define(Pop, stmt.Line())
 
 	scope = newScope(stmt)
-	newIS := &InstructionSet{}
-	newIS.name = stmt.Name.Value
-	newIS.isType = ClassDef
 
 	g.compileCodeBlock(newIS, stmt.Body, scope, scope.localTable)
 	newIS.define(Leave, stmt.Line())
@@ -138,15 +169,17 @@ func (g *Generator) compileModuleStmt(is *InstructionSet, stmt *ast.ModuleStatem
 }
 
 func (g *Generator) compileDefStmt(is *InstructionSet, stmt *ast.DefStatement, scope *scope) {
+	var def *Instruction
+
 	switch stmt.Receiver.(type) {
 	case nil:
 		is.define(PutSelf, stmt.Line())
 		is.define(PutString, stmt.Line(), stmt.Name.Value)
-		is.define(DefMethod, stmt.Line(), len(stmt.Parameters))
+		def = is.define(DefMethod, stmt.Line(), len(stmt.Parameters))
 	default:
 		g.compileExpression(is, stmt.Receiver, scope, scope.localTable)
 		is.define(PutString, stmt.Line(), stmt.Name.Value)
-		is.define(DefSingletonMethod, stmt.Line(), len(stmt.Parameters))
+		def = is.define(DefSingletonMethod, stmt.Line(), len(stmt.Parameters))
 	}
 
 	scope = newScope(stmt)
@@ -160,6 +193,7 @@ func (g *Generator) compileDefStmt(is *InstructionSet, stmt *ast.DefStatement, s
 			types: make([]int, len(stmt.Parameters)),
 		},
 	}
+	def.Body = newIS
 
 	for i := 0; i < len(stmt.Parameters); i++ {
 		switch exp := stmt.Parameters[i].(type) {
@@ -197,6 +231,13 @@ func (g *Generator) compileDefStmt(is *InstructionSet, stmt *ast.DefStatement, s
 		}
 	}
 
+	// Block parameter like `&block` takes the method's block as a Proc object
+	if stmt.BlockParameter != nil {
+		index, depth := scope.localTable.setLCL(stmt.BlockParameter.Value, scope.localTable.depth)
+		newIS.define(GetBlock, stmt.Line())
+		newIS.define(SetLocal, stmt.Line(), depth, index, 1)
+	}
+
 	if len(stmt.BlockStatement.Statements) == 0 {
 		newIS.define(PutNull, stmt.Line())
 	} else {
@@ -33,3 +33,12 @@ func CompileToInstructions(input string, parserMode int) ([]*bytecode.Instructio
 	g.InitTopLevelScope(program)
 	return g.GenerateInstructions(program.Statements), nil
 }
+
+// CompileToBinary compiles input source code into the binary bytecode format, which records the source's modification time
+func CompileToBinary(input string, sourceModTime int64) ([]byte, error) {
+	instructionSets, err := CompileToInstructions(input, parser.NormalMode)
+	if err != nil {
+		return nil, err
+	}
+	return bytecode.Marshal(instructionSets, sourceModTime)
+}
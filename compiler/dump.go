@@ -0,0 +1,123 @@
+package compiler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/goby-lang/goby/compiler/ast"
+	"github.com/goby-lang/goby/compiler/bytecode"
+	"github.com/goby-lang/goby/compiler/lexer"
+	"github.com/goby-lang/goby/compiler/parser"
+	"github.com/goby-lang/goby/compiler/token"
+)
+
+// DumpTokens returns the lexer tokens of input source code with their line numbers, one token per line
+func DumpTokens(input string) string {
+	var out bytes.Buffer
+	l := lexer.New(input)
+
+	for {
+		tok := l.NextToken()
+		fmt.Fprintf(&out, "%4d  %-20s %q\n", tok.Line+1, tok.Type, tok.Literal)
+
+		if tok.Type == token.EOF {
+			return out.String()
+		}
+	}
+}
+
+// DumpAST returns the AST of input source code as a tree, every node is printed with its type, line number and source representation
+func DumpAST(input string) (string, error) {
+	l := lexer.New(input)
+	p := parser.New(l)
+	program, err := p.ParseProgram()
+	if err != nil {
+		return "", errors.New(err.Message)
+	}
+
+	var out bytes.Buffer
+	out.WriteString("Program\n")
+
+	for i, stmt := range program.Statements {
+		dumpNode(&out, fmt.Sprintf("Statements[%d]", i), reflect.ValueOf(stmt), 1)
+	}
+
+	return out.String(), nil
+}
+
+// DumpBytecode returns the readable instructions of input source code, annotated with their source lines
+func DumpBytecode(input string) (string, error) {
+	instructionSets, err := CompileToInstructions(input, parser.NormalMode)
+	if err != nil {
+		return "", err
+	}
+
+	return bytecode.Disassemble(instructionSets, input), nil
+}
+
+// dumpNode prints the node and walks through its fields which are nodes or lists of nodes
+func dumpNode(out *bytes.Buffer, label string, v reflect.Value, depth int) {
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+
+	if !v.IsValid() || (v.Kind() == reflect.Ptr && v.IsNil()) {
+		return
+	}
+
+	indent := strings.Repeat("  ", depth)
+
+	switch n := v.Interface().(type) {
+	case ast.Statement:
+		fmt.Fprintf(out, "%s%s: %s (line %d) %s\n", indent, label, v.Elem().Type().Name(), n.Line()+1, summarizeNode(n.String()))
+	case ast.Expression:
+		fmt.Fprintf(out, "%s%s: %s (line %d) %s\n", indent, label, v.Elem().Type().Name(), n.Line()+1, summarizeNode(n.String()))
+	default:
+		return
+	}
+
+	s := v.Elem()
+
+	for i := 0; i < s.NumField(); i++ {
+		field := s.Type().Field(i)
+
+		if field.PkgPath != "" || field.Anonymous {
+			continue
+		}
+
+		f := s.Field(i)
+
+		switch f.Kind() {
+		case reflect.Slice:
+			for j := 0; j < f.Len(); j++ {
+				dumpNode(out, fmt.Sprintf("%s[%d]", field.Name, j), f.Index(j), depth+1)
+			}
+		case reflect.Map:
+			keys := f.MapKeys()
+			sort.Slice(keys, func(a, b int) bool {
+				return fmt.Sprint(keys[a]) < fmt.Sprint(keys[b])
+			})
+
+			for _, key := range keys {
+				dumpNode(out, fmt.Sprintf("%s[%v]", field.Name, key), f.MapIndex(key), depth+1)
+			}
+		default:
+			dumpNode(out, field.Name, f, depth+1)
+		}
+	}
+}
+
+// summarizeNode joins a node's source representation into one line, and truncates it since its children are printed below it
+func summarizeNode(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+
+	if r := []rune(s); len(r) > 60 {
+		return string(r[:57]) + "..."
+	}
+
+	return s
+}
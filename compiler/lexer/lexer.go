@@ -1,6 +1,8 @@
 package lexer
 
 import (
+	"strings"
+
 	"github.com/goby-lang/goby/compiler/token"
 	"github.com/looplab/fsm"
 )
@@ -13,6 +15,10 @@ type Lexer struct {
 	ch           rune
 	line         int
 	FSM          *fsm.FSM
+	// interpolations stores the unclosed braces' count of each `#{` we're currently in
+	interpolations []int
+	// lastToken is the last token we returned except comments, it decides whether '/' starts a regexp
+	lastToken token.Token
 }
 
 // New initializes a new lexer with input string
@@ -39,13 +45,26 @@ func New(input string) *Lexer {
 
 // NextToken makes lexer tokenize next character(s)
 func (l *Lexer) NextToken() token.Token {
+	tok := l.readToken()
+
+	if tok.Type != token.Comment {
+		l.lastToken = tok
+	}
+
+	return tok
+}
+
+func (l *Lexer) readToken() token.Token {
 
 	var tok token.Token
 	l.resetNosymbol()
 
 	l.skipWhitespace()
 	switch l.ch {
-	case '"', '\'':
+	case '"':
+		l.readChar()
+		return l.readStringPart(token.InterpolationStart, token.String)
+	case '\'':
 		tok.Literal = l.readString(l.ch)
 		tok.Type = token.String
 		tok.Line = l.line
@@ -55,6 +74,12 @@ func (l *Lexer) NextToken() token.Token {
 			currentByte := l.ch
 			l.readChar()
 			tok = token.Token{Type: token.Eq, Literal: string(currentByte) + string(l.ch), Line: l.line}
+		} else if l.peekChar() == '>' {
+			l.readChar()
+			tok = token.Token{Type: token.HashRocket, Literal: "=>", Line: l.line}
+		} else if l.peekChar() == '~' {
+			l.readChar()
+			tok = token.Token{Type: token.Match, Literal: "=~", Line: l.line}
 		} else {
 			tok = newToken(token.Assign, l.ch, l.line)
 		}
@@ -73,6 +98,13 @@ func (l *Lexer) NextToken() token.Token {
 			l.readChar()
 			l.readChar()
 			return tok
+		} else if l.peekChar() == '>' {
+			tok.Literal = "->"
+			tok.Line = l.line
+			tok.Type = token.Arrow
+			l.readChar()
+			l.readChar()
+			return tok
 		}
 		tok = newToken(token.Minus, l.ch, l.line)
 	case '!':
@@ -84,6 +116,13 @@ func (l *Lexer) NextToken() token.Token {
 			tok = newToken(token.Bang, l.ch, l.line)
 		}
 	case '/':
+		if l.regexpAllowed() {
+			tok.Literal = l.readRegexp()
+			tok.Type = token.Regexp
+			tok.Line = l.line
+			return tok
+		}
+
 		tok = newToken(token.Slash, l.ch, l.line)
 	case '*':
 		if l.peekChar() == '*' {
@@ -137,8 +176,25 @@ func (l *Lexer) NextToken() token.Token {
 		}
 		tok = newToken(token.Plus, l.ch, l.line)
 	case '{':
+		if len(l.interpolations) > 0 {
+			l.interpolations[len(l.interpolations)-1]++
+		}
+
 		tok = newToken(token.LBrace, l.ch, l.line)
 	case '}':
+		if len(l.interpolations) > 0 {
+			last := len(l.interpolations) - 1
+
+			// This brace closes the interpolation, so we continue reading the string
+			if l.interpolations[last] == 0 {
+				l.interpolations = l.interpolations[:last]
+				l.readChar()
+				return l.readStringPart(token.InterpolationMid, token.InterpolationEnd)
+			}
+
+			l.interpolations[last]--
+		}
+
 		tok = newToken(token.RBrace, l.ch, l.line)
 	case '[':
 		tok = newToken(token.LBracket, l.ch, l.line)
@@ -164,12 +220,18 @@ func (l *Lexer) NextToken() token.Token {
 				l.readChar()
 				tok = token.Token{Type: token.ResolutionOperator, Literal: "::", Line: l.line}
 
-			} else if isLetter(l.peekChar()) {
+			} else if isLetter(l.peekChar()) || isInstanceVariable(l.peekChar()) {
 				tok.Literal = string(l.readSymbol())
-				tok.Type = token.String
+				tok.Type = token.Symbol
 				tok.Line = l.line
 				return tok
 
+			} else if op := l.peekOperatorSymbol(); len(op) > 0 {
+				// Operator symbols like :+ or :<=>
+				for range op {
+					l.readChar()
+				}
+				tok = token.Token{Type: token.Symbol, Literal: op, Line: l.line}
 			} else {
 				tok = newToken(token.Colon, l.ch, l.line)
 			}
@@ -190,6 +252,8 @@ func (l *Lexer) NextToken() token.Token {
 		if l.peekChar() == '&' {
 			l.readChar()
 			tok = token.Token{Type: token.And, Literal: "&&", Line: l.line}
+		} else {
+			tok = newToken(token.Ampersand, l.ch, l.line)
 		}
 	case '%':
 		tok = newToken(token.Modulo, l.ch, l.line)
@@ -243,8 +307,9 @@ func (l *Lexer) NextToken() token.Token {
 
 			return newToken(token.Illegal, l.ch, l.line)
 		} else if isDigit(l.ch) {
-			tok.Literal = string(l.readNumber())
-			tok.Type = token.Int
+			literal, tokenType := l.readNumber()
+			tok.Literal = string(literal)
+			tok.Type = tokenType
 			tok.Line = l.line
 			return tok
 		}
@@ -274,12 +339,61 @@ func (l *Lexer) resetNosymbol() {
 
 }
 
-func (l *Lexer) readNumber() []rune {
+// readNumber reads integer literals like `10`, `1_000`, `0x1F` or `0b1010`,
+// and float literals like `1.5` or `1e-3`
+func (l *Lexer) readNumber() ([]rune, token.Type) {
 	position := l.position
-	for isDigit(l.ch) {
+
+	// Hexadecimal and binary literals
+	if l.ch == '0' {
+		switch l.peekChar() {
+		case 'x', 'X':
+			l.readChar()
+			l.readChar()
+			l.readDigits(isHexDigit)
+			return l.input[position:l.position], token.Int
+		case 'b', 'B':
+			l.readChar()
+			l.readChar()
+			l.readDigits(isBinaryDigit)
+			return l.input[position:l.position], token.Int
+		}
+	}
+
+	var tokenType token.Type = token.Int
+	l.readDigits(isDigit)
+
+	// Only treat '.' as decimal point when it's followed by a digit, so `1.to_s` and `1..5` still work
+	if l.ch == '.' && isDigit(l.peekChar()) {
+		tokenType = token.Float
+		l.readChar()
+		l.readDigits(isDigit)
+	}
+
+	if l.ch == 'e' || l.ch == 'E' {
+		next := l.peekChar()
+		exponentSign := next == '+' || next == '-'
+
+		if isDigit(next) || (exponentSign && l.readPosition+1 < len(l.input) && isDigit(l.input[l.readPosition+1])) {
+			tokenType = token.Float
+			l.readChar()
+
+			if exponentSign {
+				l.readChar()
+			}
+
+			l.readDigits(isDigit)
+		}
+	}
+
+	return l.input[position:l.position], tokenType
+}
+
+// readDigits reads digits and the underscores between them
+func (l *Lexer) readDigits(isValidDigit func(rune) bool) {
+	for isValidDigit(l.ch) || (l.ch == '_' && isValidDigit(l.peekChar())) {
 		l.readChar()
 	}
-	return l.input[position:l.position]
 }
 
 func (l *Lexer) readIdentifier() []rune {
@@ -342,12 +456,104 @@ func (l *Lexer) readString(ch rune) string {
 	return result
 }
 
+// readStringPart reads a double-quoted string until its closing quote or an interpolation's `#{`.
+// It returns a token of interpolatedType if it meets `#{`, otherwise a token of endType.
+func (l *Lexer) readStringPart(interpolatedType, endType token.Type) token.Token {
+	line := l.line
+	result := ""
+
+	for l.ch != '"' && l.ch != 0 {
+		if l.ch == '#' && l.peekChar() == '{' {
+			l.readChar()
+			l.readChar()
+			l.interpolations = append(l.interpolations, 0)
+
+			return token.Token{Type: interpolatedType, Literal: result, Line: line}
+		}
+
+		if isEscapedChar(l.ch) {
+			result += escapedCharResult('"', l.peekChar())
+			l.readChar()
+		} else {
+			result += string(l.ch)
+		}
+
+		l.readChar()
+	}
+
+	l.readChar() // move over string's latter quote
+
+	return token.Token{Type: endType, Literal: result, Line: line}
+}
+
+// regexpAllowed returns true if current '/' starts a regexp literal instead of being a division operator.
+// That's when the last token can't end an operand, or it's an identifier followed by a space like `split /,/`,
+// and the regexp is closed in the same line.
+func (l *Lexer) regexpAllowed() bool {
+	if !l.hasClosingSlash() {
+		return false
+	}
+
+	switch l.lastToken.Type {
+	case token.Ident:
+		return isSpace(l.input[l.position-1]) && !isSpace(l.peekChar())
+	case token.Constant, token.InstanceVariable, token.Int, token.Float, token.String, token.InterpolationEnd,
+		token.Symbol, token.Regexp, token.RParen, token.RBracket, token.RBrace, token.True, token.False,
+		token.Null, token.Self, token.Def, token.Dot:
+		return false
+	}
+
+	return true
+}
+
+// hasClosingSlash returns true if there's an unescaped '/' after current '/' in the same line
+func (l *Lexer) hasClosingSlash() bool {
+	for i := l.readPosition; i < len(l.input) && l.input[i] != '\n'; i++ {
+		switch l.input[i] {
+		case '\\':
+			i++
+		case '/':
+			return true
+		}
+	}
+
+	return false
+}
+
+// readRegexp reads a regexp literal like `/ab+c/i` and returns its source, including the slashes and flags
+func (l *Lexer) readRegexp() string {
+	position := l.position
+	l.readChar()
+
+	for l.ch != '/' {
+		// Escaped characters, including an escaped slash, are kept as they are
+		if isEscapedChar(l.ch) {
+			l.readChar()
+		}
+
+		l.readChar()
+	}
+
+	l.readChar() // move over the closing slash
+
+	for isLetter(l.ch) {
+		l.readChar()
+	}
+
+	return string(l.input[position:l.position])
+}
+
 func (l *Lexer) readSymbol() []rune {
 	l.readChar()
 
 	position := l.position // currently at string's first letter
 
-	for isLetter(l.peekChar()) || isDigit(l.peekChar()) {
+	for isLetter(l.peekChar()) || isDigit(l.peekChar()) || isInstanceVariable(l.peekChar()) {
+		l.readChar()
+	}
+
+	// Symbols of predicate methods like :empty?
+	if l.peekChar() == '?' {
 		l.readChar()
 	}
 
@@ -356,6 +562,29 @@ func (l *Lexer) readSymbol() []rune {
 	return result
 }
 
+// operatorSymbols are the operators that can be written as symbols, longer ones come first
+var operatorSymbols = []string{"<=>", "[]=", "**", "==", "!=", "<=", ">=", "[]", "+", "-", "*", "/", "%", "<", ">", "!"}
+
+// peekOperatorSymbol returns the operator right after current ':', or an empty string if there's none
+func (l *Lexer) peekOperatorSymbol() string {
+	start := l.position + 1
+	end := start + 3
+
+	if end > len(l.input) {
+		end = len(l.input)
+	}
+
+	rest := string(l.input[start:end])
+
+	for _, op := range operatorSymbols {
+		if strings.HasPrefix(rest, op) {
+			return op
+		}
+	}
+
+	return ""
+}
+
 func (l *Lexer) absorbComment() []rune {
 	p := l.position
 	for l.ch != '\n' && l.ch != 0 {
@@ -389,10 +618,22 @@ func isDigit(ch rune) bool {
 	return '0' <= ch && ch <= '9'
 }
 
+func isHexDigit(ch rune) bool {
+	return isDigit(ch) || 'a' <= ch && ch <= 'f' || 'A' <= ch && ch <= 'F'
+}
+
+func isBinaryDigit(ch rune) bool {
+	return ch == '0' || ch == '1'
+}
+
 func isLetter(ch rune) bool {
 	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || ch == '_'
 }
 
+func isSpace(ch rune) bool {
+	return ch == ' ' || ch == '\t'
+}
+
 func isInstanceVariable(ch rune) bool {
 	return ch == '@'
 }
@@ -420,6 +661,8 @@ func escapedCharResult(quotedChar rune, peeked rune) string {
 			return "\""
 		case '\'':
 			return "'"
+		case '#':
+			return "#"
 		default:
 			return "\\" + string(peeked)
 		}
@@ -131,6 +131,18 @@ func TestNextToken(t *testing.T) {
 	'\"string\"'
 	"\'string\'"
 	'\'string\''
+
+	begin
+	  raise(ArgumentError)
+	rescue ArgumentError => e
+	ensure
+	end
+
+	1.5 1e-3 2.5E+2 0x1F 0b1010 1_000 1.to_s 1..2
+	"a#{b}c#{ {d: 1} }e" "\#{f}" '#{g}'
+	->(x) { x } foo(&b) a && b
+	:empty? :<=> :+ :@foo Foo::Bar
+	s =~ /a\/b+/i a / b x/2 split /,/ (a) / 2
 	`
 
 	tests := []struct {
@@ -381,7 +393,7 @@ func TestNextToken(t *testing.T) {
 		{token.String, "", 91},
 
 		{token.Next, "next", 93},
-		{token.String, "apple", 94},
+		{token.Symbol, "apple", 94},
 
 		{token.LBrace, "{", 95},
 		{token.Ident, "test", 95},
@@ -392,7 +404,7 @@ func TestNextToken(t *testing.T) {
 		{token.LBrace, "{", 96},
 		{token.Ident, "test", 96},
 		{token.Colon, ":", 96},
-		{token.String, "abc", 96},
+		{token.Symbol, "abc", 96},
 		{token.RBrace, "}", 96},
 
 		{token.LBrace, "{", 97},
@@ -455,7 +467,85 @@ func TestNextToken(t *testing.T) {
 		{token.String, "'string'", 123},
 		{token.String, "'string'", 124},
 
-		{token.EOF, "", 125},
+		{token.Begin, "begin", 126},
+		{token.Ident, "raise", 127},
+		{token.LParen, "(", 127},
+		{token.Constant, "ArgumentError", 127},
+		{token.RParen, ")", 127},
+		{token.Rescue, "rescue", 128},
+		{token.Constant, "ArgumentError", 128},
+		{token.HashRocket, "=>", 128},
+		{token.Ident, "e", 128},
+		{token.Ensure, "ensure", 129},
+		{token.End, "end", 130},
+
+		{token.Float, "1.5", 132},
+		{token.Float, "1e-3", 132},
+		{token.Float, "2.5E+2", 132},
+		{token.Int, "0x1F", 132},
+		{token.Int, "0b1010", 132},
+		{token.Int, "1_000", 132},
+		{token.Int, "1", 132},
+		{token.Dot, ".", 132},
+		{token.Ident, "to_s", 132},
+		{token.Int, "1", 132},
+		{token.Range, "..", 132},
+		{token.Int, "2", 132},
+
+		{token.InterpolationStart, "a", 133},
+		{token.Ident, "b", 133},
+		{token.InterpolationMid, "c", 133},
+		{token.LBrace, "{", 133},
+		{token.Ident, "d", 133},
+		{token.Colon, ":", 133},
+		{token.Int, "1", 133},
+		{token.RBrace, "}", 133},
+		{token.InterpolationEnd, "e", 133},
+		{token.String, "#{f}", 133},
+		{token.String, "#{g}", 133},
+
+		{token.Arrow, "->", 134},
+		{token.LParen, "(", 134},
+		{token.Ident, "x", 134},
+		{token.RParen, ")", 134},
+		{token.LBrace, "{", 134},
+		{token.Ident, "x", 134},
+		{token.RBrace, "}", 134},
+		{token.Ident, "foo", 134},
+		{token.LParen, "(", 134},
+		{token.Ampersand, "&", 134},
+		{token.Ident, "b", 134},
+		{token.RParen, ")", 134},
+		{token.Ident, "a", 134},
+		{token.And, "&&", 134},
+		{token.Ident, "b", 134},
+
+		{token.Symbol, "empty?", 135},
+		{token.Symbol, "<=>", 135},
+		{token.Symbol, "+", 135},
+		{token.Symbol, "@foo", 135},
+		{token.Constant, "Foo", 135},
+		{token.ResolutionOperator, "::", 135},
+		{token.Constant, "Bar", 135},
+
+		{token.Ident, "s", 136},
+		{token.Match, "=~", 136},
+		{token.Regexp, "/a\\/b+/i", 136},
+		{token.Ident, "a", 136},
+		{token.Slash, "/", 136},
+		{token.Ident, "b", 136},
+		{token.Ident, "x", 136},
+		{token.Slash, "/", 136},
+		{token.Int, "2", 136},
+		{token.Ident, "split", 136},
+		{token.Regexp, "/,/", 136},
+		{token.LParen, "(", 136},
+		{token.Ident, "a", 136},
+		{token.RParen, ")", 136},
+		{token.Slash, "/", 136},
+		{token.Int, "2", 136},
+
+		{token.EOF, "", 137},
 	}
 	l := New(input)
 
@@ -1,15 +1,31 @@
 package parser
 
 import (
+	"fmt"
+
 	"github.com/goby-lang/goby/compiler/ast"
 	"github.com/goby-lang/goby/compiler/token"
 	"strconv"
+	"strings"
 )
 
 func (p *Parser) parseIntegerLiteral() ast.Expression {
 	lit := &ast.IntegerLiteral{BaseNode: &ast.BaseNode{Token: p.curToken}}
 
-	value, err := strconv.ParseInt(lit.TokenLiteral(), 0, 64)
+	literal := strings.Replace(lit.TokenLiteral(), "_", "", -1)
+	base := 0
+
+	// Base prefixes are handled here because ParseInt only accepts `0b` since Go 1.13
+	if len(literal) > 2 && literal[0] == '0' {
+		switch literal[1] {
+		case 'x', 'X':
+			literal, base = literal[2:], 16
+		case 'b', 'B':
+			literal, base = literal[2:], 2
+		}
+	}
+
+	value, err := strconv.ParseInt(literal, base, 64)
 	if err != nil {
 		p.error = newTypeParsingError(lit.TokenLiteral(), "integer", p.curToken.Line)
 		return nil
@@ -20,6 +36,20 @@ func (p *Parser) parseIntegerLiteral() ast.Expression {
 	return lit
 }
 
+func (p *Parser) parseFloatLiteral() ast.Expression {
+	lit := &ast.FloatLiteral{BaseNode: &ast.BaseNode{Token: p.curToken}}
+
+	value, err := strconv.ParseFloat(strings.Replace(lit.TokenLiteral(), "_", "", -1), 64)
+	if err != nil {
+		p.error = newTypeParsingError(lit.TokenLiteral(), "float", p.curToken.Line)
+		return nil
+	}
+
+	lit.Value = value
+
+	return lit
+}
+
 func (p *Parser) parseStringLiteral() ast.Expression {
 	lit := &ast.StringLiteral{BaseNode: &ast.BaseNode{Token: p.curToken}}
 	lit.Value = p.curToken.Literal
@@ -27,6 +57,46 @@ func (p *Parser) parseStringLiteral() ast.Expression {
 	return lit
 }
 
+func (p *Parser) parseSymbolLiteral() ast.Expression {
+	return &ast.SymbolLiteral{BaseNode: &ast.BaseNode{Token: p.curToken}, Value: p.curToken.Literal}
+}
+
+func (p *Parser) parseRegexpLiteral() ast.Expression {
+	lit := &ast.RegexpLiteral{BaseNode: &ast.BaseNode{Token: p.curToken}}
+
+	// The literal is like `/ab+c/i`, flags are after the last slash
+	source := p.curToken.Literal
+	end := strings.LastIndex(source, "/")
+	lit.Value = source[1:end]
+	lit.Flags = source[end+1:]
+
+	return lit
+}
+
+func (p *Parser) parseInterpolationExpression() ast.Expression {
+	exp := &ast.InterpolationExpression{BaseNode: &ast.BaseNode{Token: p.curToken}}
+	exp.Parts = append(exp.Parts, &ast.StringLiteral{BaseNode: &ast.BaseNode{Token: p.curToken}, Value: p.curToken.Literal})
+
+	for !p.curTokenIs(token.InterpolationEnd) {
+		// Empty interpolation like "#{}"
+		if !p.peekTokenIs(token.InterpolationMid) && !p.peekTokenIs(token.InterpolationEnd) {
+			p.nextToken()
+			exp.Parts = append(exp.Parts, p.parseExpression(NORMAL))
+		}
+
+		p.nextToken()
+
+		if !p.curTokenIs(token.InterpolationMid) && !p.curTokenIs(token.InterpolationEnd) {
+			p.error = &Error{Message: fmt.Sprintf("unexpected %s in string interpolation. Line: %d", p.curToken.Literal, p.curToken.Line), errType: UnexpectedTokenError}
+			return nil
+		}
+
+		exp.Parts = append(exp.Parts, &ast.StringLiteral{BaseNode: &ast.BaseNode{Token: p.curToken}, Value: p.curToken.Literal})
+	}
+
+	return exp
+}
+
 func (p *Parser) parseBooleanLiteral() ast.Expression {
 	lit := &ast.BooleanExpression{BaseNode: &ast.BaseNode{Token: p.curToken}}
 
@@ -46,35 +116,32 @@ func (p *Parser) parseNilExpression() ast.Expression {
 }
 
 func (p *Parser) parseHashExpression() ast.Expression {
-	hash := &ast.HashExpression{BaseNode: &ast.BaseNode{Token: p.curToken}}
-	hash.Data = p.parseHashPairs()
+	hash := &ast.HashExpression{BaseNode: &ast.BaseNode{Token: p.curToken}, Data: map[string]ast.Expression{}}
+	p.parseHashPairs(hash)
 	return hash
 }
 
-func (p *Parser) parseHashPairs() map[string]ast.Expression {
-	pairs := map[string]ast.Expression{}
-
+func (p *Parser) parseHashPairs(hash *ast.HashExpression) {
 	if p.peekTokenIs(token.RBrace) {
 		p.nextToken() // '}'
-		return pairs
+		return
 	}
 
-	p.parseHashPair(pairs)
+	p.parseHashPair(hash)
 
 	for p.peekTokenIs(token.Comma) {
 		p.nextToken()
 
-		p.parseHashPair(pairs)
+		p.parseHashPair(hash)
 	}
 
 	if !p.expectPeek(token.RBrace) {
-		return nil
+		hash.Data = nil
+		hash.Keys = nil
 	}
-
-	return pairs
 }
 
-func (p *Parser) parseHashPair(pairs map[string]ast.Expression) {
+func (p *Parser) parseHashPair(hash *ast.HashExpression) {
 	var key string
 	var value ast.Expression
 
@@ -93,7 +160,12 @@ func (p *Parser) parseHashPair(pairs map[string]ast.Expression) {
 
 	p.nextToken()
 	value = p.parseExpression(NORMAL)
-	pairs[key] = value
+
+	if _, ok := hash.Data[key]; !ok {
+		hash.Keys = append(hash.Keys, key)
+	}
+
+	hash.Data[key] = value
 }
 
 func (p *Parser) parseArrayExpression() ast.Expression {
@@ -8,19 +8,25 @@ import (
 )
 
 var arguments = map[token.Type]bool{
-	token.Int:              true,
-	token.String:           true,
-	token.True:             true,
-	token.False:            true,
-	token.Null:             true,
-	token.InstanceVariable: true,
-	token.Ident:            true,
-	token.Constant:         true,
+	token.Int:                true,
+	token.Float:              true,
+	token.String:             true,
+	token.Symbol:             true,
+	token.Regexp:             true,
+	token.InterpolationStart: true,
+	token.True:               true,
+	token.False:              true,
+	token.Null:               true,
+	token.InstanceVariable:   true,
+	token.Ident:              true,
+	token.Constant:           true,
+	token.Arrow:              true,
 }
 
 var precedence = map[token.Type]int{
 	token.Eq:                 EQUALS,
 	token.NotEq:              EQUALS,
+	token.Match:              EQUALS,
 	token.LT:                 COMPARE,
 	token.LTE:                COMPARE,
 	token.GT:                 COMPARE,
@@ -123,6 +129,11 @@ func (p *Parser) parseExpression(precedence int) ast.Expression {
 
 	leftExp := parseFn()
 
+	// An invalid expression can't be the left side of an infix expression
+	if p.error != nil || leftExp == nil {
+		return leftExp
+	}
+
 	/*
 		Precedence example:
 
@@ -146,6 +157,10 @@ func (p *Parser) parseExpression(precedence int) ast.Expression {
 		}
 		p.nextToken()
 		leftExp = infixFn(leftExp)
+
+		if p.error != nil {
+			return leftExp
+		}
 	}
 
 	if p.peekTokenIs(token.Semicolon) {
@@ -3,6 +3,7 @@ package parser
 import (
 	"github.com/goby-lang/goby/compiler/ast"
 	"github.com/goby-lang/goby/compiler/lexer"
+	"strings"
 	"testing"
 )
 
@@ -330,6 +331,93 @@ func TestIntegerLiteralExpressionFail(t *testing.T) {
 	// "could not parse 9223372036854775808 as integer. Line: 1"
 }
 
+func TestInterpolationExpression(t *testing.T) {
+	input := `"Hello #{name}, #{1 + 2}!"`
+
+	l := lexer.New(input)
+	p := New(l)
+	program, err := p.ParseProgram()
+
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	exp, ok := stmt.Expression.(*ast.InterpolationExpression)
+
+	if !ok {
+		t.Fatalf("expect expression to be *ast.InterpolationExpression. got=%T", stmt.Expression)
+	}
+
+	if len(exp.Parts) != 5 {
+		t.Fatalf("expect interpolation to have 5 parts. got=%d", len(exp.Parts))
+	}
+
+	testStringLiteral(t, exp.Parts[0], "Hello ")
+	testIdentifier(t, exp.Parts[1], "name")
+	testStringLiteral(t, exp.Parts[2], ", ")
+	testInfixExpression(t, exp.Parts[3], 1, "+", 2)
+	testStringLiteral(t, exp.Parts[4], "!")
+
+	expected := `"Hello #{name}, #{(1 + 2)}!"`
+
+	if exp.String() != expected {
+		t.Fatalf("expect interpolation's string to be %s. got=%s", expected, exp.String())
+	}
+}
+
+func TestNumberLiteralExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`1_000_000`, 1000000},
+		{`0x1F`, 31},
+		{`0b1010`, 10},
+		{`0B1111_0000`, 240},
+		{`0xff_ff`, 65535},
+		{`1.5`, 1.5},
+		{`1e-3`, 0.001},
+		{`2.5E2`, 250.0},
+		{`1_000.5`, 1000.5},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program, err := p.ParseProgram()
+
+		if err != nil {
+			t.Fatal(err.Message)
+		}
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			literal, ok := stmt.Expression.(*ast.IntegerLiteral)
+
+			if !ok {
+				t.Fatalf("expect %s to be *ast.IntegerLiteral. got=%T", tt.input, stmt.Expression)
+			}
+
+			if literal.Value != expected {
+				t.Fatalf("expect %s's value to be %d. got=%d", tt.input, expected, literal.Value)
+			}
+		case float64:
+			literal, ok := stmt.Expression.(*ast.FloatLiteral)
+
+			if !ok {
+				t.Fatalf("expect %s to be *ast.FloatLiteral. got=%T", tt.input, stmt.Expression)
+			}
+
+			if literal.Value != expected {
+				t.Fatalf("expect %s's value to be %f. got=%f", tt.input, expected, literal.Value)
+			}
+		}
+	}
+}
+
 func TestStringLiteralExpression(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -363,6 +451,86 @@ func TestStringLiteralExpression(t *testing.T) {
 	}
 }
 
+func TestSymbolLiteralExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: `:foo`, expected: "foo"},
+		{input: `:empty?`, expected: "empty?"},
+		{input: `:<=>`, expected: "<=>"},
+		{input: `:@bar`, expected: "@bar"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program, err := p.ParseProgram()
+
+		if err != nil {
+			t.Fatal(err.Message)
+		}
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		literal, ok := stmt.Expression.(*ast.SymbolLiteral)
+
+		if !ok {
+			t.Fatalf("expect expression to be SymbolLiteral. got=%T", stmt.Expression)
+		}
+
+		if literal.Value != tt.expected {
+			t.Fatalf("expect symbol's value to be %q. got=%q", tt.expected, literal.Value)
+		}
+	}
+}
+
+func TestRegexpLiteralExpression(t *testing.T) {
+	tests := []struct {
+		input         string
+		expectedValue string
+		expectedFlags string
+	}{
+		{input: `/ab+c/`, expectedValue: "ab+c", expectedFlags: ""},
+		{input: `/a\/b/im`, expectedValue: `a\/b`, expectedFlags: "im"},
+		{input: `"abc" =~ /b/`, expectedValue: "b", expectedFlags: ""},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program, err := p.ParseProgram()
+
+		if err != nil {
+			t.Fatal(err.Message)
+		}
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		exp := stmt.Expression
+
+		if infix, ok := exp.(*ast.InfixExpression); ok {
+			if infix.Operator != "=~" {
+				t.Fatalf("expect operator to be =~. got=%s", infix.Operator)
+			}
+
+			exp = infix.Right
+		}
+
+		literal, ok := exp.(*ast.RegexpLiteral)
+
+		if !ok {
+			t.Fatalf("expect expression to be RegexpLiteral. got=%T", exp)
+		}
+
+		if literal.Value != tt.expectedValue {
+			t.Fatalf("expect regexp's value to be %q. got=%q", tt.expectedValue, literal.Value)
+		}
+
+		if literal.Flags != tt.expectedFlags {
+			t.Fatalf("expect regexp's flags to be %q. got=%q", tt.expectedFlags, literal.Flags)
+		}
+	}
+}
+
 func TestParsingInfixExpression(t *testing.T) {
 	infixTests := []struct {
 		input      string
@@ -602,6 +770,88 @@ func TestCaseExpression(t *testing.T) {
 	}
 }
 
+func TestBeginExpression(t *testing.T) {
+	input := `
+	begin
+	  x + 1
+	rescue ArgumentError, TypeError => e
+	  x + 2
+	rescue
+	  x + 3
+	else
+	  x + 4
+	ensure
+	  x + 5
+	end
+	`
+
+	l := lexer.New(input)
+	p := New(l)
+	program, err := p.ParseProgram()
+
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("expect program's statements to be 1. got=%d", len(program.Statements))
+	}
+
+	stmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+
+	if !ok {
+		t.Fatalf("expect program.Statements[0] to be *ast.ExpressionStatement. got=%T", program.Statements[0])
+	}
+
+	exp, ok := stmt.Expression.(*ast.BeginExpression)
+
+	if !ok {
+		t.Fatalf("expect statement to be a BeginExpression. got=%T", stmt.Expression)
+	}
+
+	body := exp.Body.Statements[0].(*ast.ExpressionStatement)
+
+	if !testInfixExpression(t, body.Expression, "x", "+", 1) {
+		return
+	}
+
+	if len(exp.Rescues) != 2 {
+		t.Fatalf("expect the length of rescues to be 2. got=%d", len(exp.Rescues))
+	}
+
+	r0 := exp.Rescues[0]
+
+	if len(r0.ErrorClasses) != 2 {
+		t.Fatalf("expect the first rescue to have 2 error classes. got=%d", len(r0.ErrorClasses))
+	}
+
+	testConstant(t, r0.ErrorClasses[0], "ArgumentError")
+	testConstant(t, r0.ErrorClasses[1], "TypeError")
+	testIdentifier(t, r0.Variable, "e")
+
+	if !testInfixExpression(t, r0.Body.Statements[0].(*ast.ExpressionStatement).Expression, "x", "+", 2) {
+		return
+	}
+
+	r1 := exp.Rescues[1]
+
+	if len(r1.ErrorClasses) != 0 || r1.Variable != nil {
+		t.Fatalf("expect the second rescue to have no error classes and variable. got=%s", r1.String())
+	}
+
+	if !testInfixExpression(t, r1.Body.Statements[0].(*ast.ExpressionStatement).Expression, "x", "+", 3) {
+		return
+	}
+
+	if !testInfixExpression(t, exp.Else.Statements[0].(*ast.ExpressionStatement).Expression, "x", "+", 4) {
+		return
+	}
+
+	if !testInfixExpression(t, exp.Ensure.Statements[0].(*ast.ExpressionStatement).Expression, "x", "+", 5) {
+		return
+	}
+}
+
 func TestMethodParameterParsing(t *testing.T) {
 	tests := []struct {
 		input          string
@@ -732,6 +982,145 @@ func TestCallExpressionWithBlock(t *testing.T) {
 	testMethodName(t, exp, "puts")
 }
 
+func TestLambdaExpression(t *testing.T) {
+	tests := []struct {
+		input          string
+		expectedParams []string
+	}{
+		{`->(x, y) { x + y }`, []string{"x", "y"}},
+		{`
+		->(x, y) do
+		  x + y
+		end
+		`, []string{"x", "y"}},
+		{`->{ x + y }`, []string{}},
+	}
+
+	for i, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program, err := p.ParseProgram()
+
+		if err != nil {
+			t.Fatalf("At case %d: %s", i, err.Message)
+		}
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		callExpression, ok := stmt.Expression.(*ast.CallExpression)
+
+		if !ok {
+			t.Fatalf("At case %d: expect lambda literal to be a CallExpression. got=%T", i, stmt.Expression)
+		}
+
+		testMethodName(t, callExpression, "lambda")
+
+		if len(callExpression.BlockArguments) != len(tt.expectedParams) {
+			t.Fatalf("At case %d: expect %d block arguments. got=%d", i, len(tt.expectedParams), len(callExpression.BlockArguments))
+		}
+
+		for j, param := range tt.expectedParams {
+			testIdentifier(t, callExpression.BlockArguments[j], param)
+		}
+
+		exp := callExpression.Block.Statements[0].(*ast.ExpressionStatement).Expression
+		testInfixExpression(t, exp, "x", "+", "y")
+	}
+}
+
+func TestLambdaExpressionWithSpecialParameters(t *testing.T) {
+	tests := []struct {
+		input          string
+		expectedParams []string
+	}{
+		{`->(a, b = 2) { a + b }`, []string{"a", "b = 2"}},
+		{`l = ->(a, b = 2) { a + b }`, []string{"a", "b = 2"}},
+		{`->(a, *b) { b }`, []string{"a", "(*b)"}},
+		{`->(a, &b) { b }`, []string{"a", "(&b)"}},
+		{`->(a, b = 1, *c, &d) { b }`, []string{"a", "b = 1", "(*c)", "(&d)"}},
+	}
+
+	for i, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program, err := p.ParseProgram()
+
+		if err != nil {
+			t.Fatalf("At case %d: %s", i, err.Message)
+		}
+
+		var callExpression *ast.CallExpression
+
+		switch exp := program.Statements[0].(*ast.ExpressionStatement).Expression.(type) {
+		case *ast.CallExpression:
+			callExpression = exp
+		case *ast.AssignExpression:
+			callExpression = exp.Value.(*ast.CallExpression)
+		}
+
+		if len(callExpression.BlockArguments) != len(tt.expectedParams) {
+			t.Fatalf("At case %d: expect %d block arguments. got=%d", i, len(tt.expectedParams), len(callExpression.BlockArguments))
+		}
+
+		for j, param := range tt.expectedParams {
+			if callExpression.BlockArguments[j].String() != param {
+				t.Errorf("At case %d: expect parameter %d to be %s. got=%s", i, j, param, callExpression.BlockArguments[j].String())
+			}
+		}
+	}
+}
+
+func TestLambdaExpressionFail(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`->(a b) { a }`, "expected next token to be ), got IDENT instead"},
+		{`->(a, b: 1) { a }`, "Lambda can't have keyword parameter: b: 1"},
+		{`->(a, 1) { a }`, "Invalid lambda parameter: 1"},
+		{`x = ->(a, -b) { a }`, "Invalid lambda parameter: (-b)"},
+		{`->(a = 1, b) { a }`, "Normal argument \"b\" should be defined before Optioned argument"},
+		{`->(&a, b) { a }`, "Block argument should be the last parameter"},
+		{`->(*a, *b) { a }`, "Can't define splat argument more than once"},
+		{`->(a, a) { a }`, "Duplicate argument name: \"a\""},
+	}
+
+	for i, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		_, err := p.ParseProgram()
+
+		if err == nil || !strings.Contains(err.Message, tt.expected) {
+			t.Errorf("At case %d: expect error %q. got=%v", i, tt.expected, err)
+		}
+	}
+}
+
+func TestCallExpressionWithProcArgument(t *testing.T) {
+	input := `
+	foo(1, &bar)
+	`
+	l := lexer.New(input)
+	p := New(l)
+	program, err := p.ParseProgram()
+
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	callExpression := stmt.Expression.(*ast.CallExpression)
+
+	testIntegerLiteral(t, callExpression.Arguments[0], 1)
+
+	procArg, ok := callExpression.Arguments[1].(*ast.PrefixExpression)
+
+	if !ok || procArg.Operator != "&" {
+		t.Fatalf("Expect last argument to be a '&' PrefixExpression. got=%s", callExpression.Arguments[1].String())
+	}
+
+	testIdentifier(t, procArg.Right, "bar")
+}
+
 func TestAssignInfixExpressionWithLiteralValue(t *testing.T) {
 	tests := []struct {
 		input              string
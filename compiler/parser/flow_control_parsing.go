@@ -1,6 +1,8 @@
 package parser
 
 import (
+	"fmt"
+
 	"github.com/goby-lang/goby/compiler/ast"
 	"github.com/goby-lang/goby/compiler/token"
 )
@@ -122,3 +124,60 @@ func (p *Parser) parseConditionalExpression() *ast.ConditionalExpression {
 
 	return ce
 }
+
+func (p *Parser) parseBeginExpression() ast.Expression {
+	be := &ast.BeginExpression{BaseNode: &ast.BaseNode{Token: p.curToken}}
+	be.Body = p.parseBlockStatement()
+	be.Body.KeepLastValue()
+
+	for p.curTokenIs(token.Rescue) {
+		be.Rescues = append(be.Rescues, p.parseRescueExpression())
+	}
+
+	if p.curTokenIs(token.Else) {
+		be.Else = p.parseBlockStatement()
+		be.Else.KeepLastValue()
+	}
+
+	if p.curTokenIs(token.Ensure) {
+		be.Ensure = p.parseBlockStatement()
+	}
+
+	if !p.curTokenIs(token.End) && p.error == nil {
+		p.error = &Error{Message: fmt.Sprintf("unexpected %s in begin block. Line: %d", p.curToken.Literal, p.curToken.Line), errType: UnexpectedTokenError}
+	}
+
+	return be
+}
+
+// begin expression parsing helpers
+func (p *Parser) parseRescueExpression() *ast.RescueExpression {
+	re := &ast.RescueExpression{BaseNode: &ast.BaseNode{Token: p.curToken}}
+
+	// Error classes must be on the same line as `rescue`, like `rescue ArgumentError, TypeError`
+	if p.peekTokenIs(token.Constant) && p.peekTokenAtSameLine() {
+		p.nextToken()
+		re.ErrorClasses = append(re.ErrorClasses, p.parseExpression(NORMAL))
+
+		for p.peekTokenIs(token.Comma) {
+			p.nextToken()
+			p.nextToken()
+			re.ErrorClasses = append(re.ErrorClasses, p.parseExpression(NORMAL))
+		}
+	}
+
+	if p.peekTokenIs(token.HashRocket) {
+		p.nextToken()
+
+		if !p.expectPeek(token.Ident) {
+			return re
+		}
+
+		re.Variable = &ast.Identifier{BaseNode: &ast.BaseNode{Token: p.curToken}, Value: p.curToken.Literal}
+	}
+
+	re.Body = p.parseBlockStatement()
+	re.Body.KeepLastValue()
+
+	return re
+}
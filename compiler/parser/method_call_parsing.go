@@ -1,6 +1,8 @@
 package parser
 
 import (
+	"fmt"
+
 	"github.com/goby-lang/goby/compiler/ast"
 	"github.com/goby-lang/goby/compiler/token"
 )
@@ -119,7 +121,7 @@ func (p *Parser) parseBlockArgument(exp *ast.CallExpression) {
 
 	// Parse block arguments
 	if p.peekTokenIs(token.Bar) {
-		var params []*ast.Identifier
+		var params []ast.Expression
 
 		p.nextToken()
 		p.nextToken()
@@ -144,3 +146,111 @@ func (p *Parser) parseBlockArgument(exp *ast.CallExpression) {
 	exp.Block = p.parseBlockStatement()
 	exp.Block.KeepLastValue()
 }
+
+// parseLambdaExpression parses lambda literals like `->(x) { x * 2 }` or `->(x) do x * 2 end`.
+// They're syntax sugar of `lambda do |x| x * 2 end`, so we produce a `lambda` call with the block.
+func (p *Parser) parseLambdaExpression() ast.Expression {
+	line := p.curToken.Line
+	selfTok := token.Token{Type: token.Self, Literal: "self", Line: line}
+	exp := &ast.CallExpression{
+		BaseNode:  &ast.BaseNode{Token: token.Token{Type: token.Ident, Literal: "lambda", Line: line}},
+		Receiver:  &ast.SelfExpression{BaseNode: &ast.BaseNode{Token: selfTok}},
+		Method:    "lambda",
+		Arguments: []ast.Expression{},
+	}
+
+	// Parameters are parsed like method parameters, except keyword parameters which can't be passed to blocks
+	if p.peekTokenIs(token.LParen) {
+		p.nextToken()
+
+		if !p.peekTokenIs(token.RParen) {
+			exp.BlockArguments = p.parseParameters()
+
+			if p.error != nil {
+				return nil
+			}
+
+			for _, param := range exp.BlockArguments {
+				if _, ok := param.(*ast.PairExpression); ok {
+					p.error = &Error{Message: fmt.Sprintf("Lambda can't have keyword parameter: %s. Line: %d", param.String(), p.curToken.Line), errType: ArgumentError}
+					return nil
+				}
+
+				if !isLambdaParameter(param) {
+					p.error = &Error{Message: fmt.Sprintf("Invalid lambda parameter: %s. Line: %d", param.String(), p.curToken.Line), errType: ArgumentError}
+					return nil
+				}
+			}
+		}
+
+		if !p.expectPeek(token.RParen) {
+			return nil
+		}
+	}
+
+	if !p.peekTokenIs(token.LBrace) && !p.peekTokenIs(token.Do) {
+		p.error = &Error{Message: fmt.Sprintf("expected lambda body after '->', got %s. Line: %d", p.peekToken.Literal, p.peekToken.Line), errType: SyntaxError}
+		return nil
+	}
+
+	// Lambda's body is a brand new context, so we parse it like normal statements
+	oldState := p.fsm.Current()
+	oldAcceptBlock := p.acceptBlock
+	p.fsm.Event(backToNormal)
+	p.acceptBlock = true
+
+	p.nextToken()
+
+	if p.curTokenIs(token.LBrace) {
+		exp.Block = p.parseBraceBlockStatement()
+	} else {
+		exp.Block = p.parseBlockStatement()
+	}
+
+	p.fsm.Event(eventTable[oldState])
+	p.acceptBlock = oldAcceptBlock
+
+	exp.Block.KeepLastValue()
+	return exp
+}
+
+// isLambdaParameter returns true if the expression is a normal, optional, splat or block parameter
+func isLambdaParameter(param ast.Expression) bool {
+	switch param := param.(type) {
+	case *ast.Identifier:
+		return true
+	case *ast.AssignExpression:
+		_, ok := param.Variables[0].(*ast.Identifier)
+		return len(param.Variables) == 1 && ok
+	case *ast.PrefixExpression:
+		_, ok := param.Right.(*ast.Identifier)
+		return (param.Operator == "*" || param.Operator == "&") && ok
+	}
+
+	return false
+}
+
+// parseBraceBlockStatement parses block statement wrapped by braces, like lambda literal's body
+func (p *Parser) parseBraceBlockStatement() *ast.BlockStatement {
+	// curToken is '{'
+	bs := &ast.BlockStatement{BaseNode: &ast.BaseNode{Token: p.curToken}}
+	bs.Statements = []ast.Statement{}
+
+	p.nextToken()
+
+	for !p.curTokenIs(token.RBrace) {
+		if p.curTokenIs(token.EOF) {
+			p.error = &Error{Message: "Unexpected EOF", errType: EndOfFileError}
+			return bs
+		}
+
+		stmt := p.parseStatement()
+
+		if stmt != nil {
+			bs.Statements = append(bs.Statements, stmt)
+		}
+		p.nextToken()
+	}
+
+	return bs
+}
@@ -76,6 +76,7 @@ const (
 	SplatArg
 	RequiredKeywordArg
 	OptionalKeywordArg
+	BlockArg
 )
 
 // These are state machine's events
@@ -107,6 +108,7 @@ var argTable = map[int]string{
 	RequiredKeywordArg: "Keyword argument",
 	OptionalKeywordArg: "Optioned keyword argument",
 	SplatArg:           "Splat argument",
+	BlockArg:           "Block argument",
 }
 
 // New initializes a parser and returns it
@@ -132,16 +134,23 @@ func New(l *lexer.Lexer) *Parser {
 	p.registerPrefix(token.Constant, p.parseConstant)
 	p.registerPrefix(token.InstanceVariable, p.parseInstanceVariable)
 	p.registerPrefix(token.Int, p.parseIntegerLiteral)
+	p.registerPrefix(token.Float, p.parseFloatLiteral)
+	p.registerPrefix(token.InterpolationStart, p.parseInterpolationExpression)
 	p.registerPrefix(token.String, p.parseStringLiteral)
+	p.registerPrefix(token.Symbol, p.parseSymbolLiteral)
+	p.registerPrefix(token.Regexp, p.parseRegexpLiteral)
 	p.registerPrefix(token.True, p.parseBooleanLiteral)
 	p.registerPrefix(token.False, p.parseBooleanLiteral)
 	p.registerPrefix(token.Null, p.parseNilExpression)
 	p.registerPrefix(token.Minus, p.parsePrefixExpression)
 	p.registerPrefix(token.Asterisk, p.parsePrefixExpression)
 	p.registerPrefix(token.Bang, p.parsePrefixExpression)
+	p.registerPrefix(token.Ampersand, p.parsePrefixExpression)
+	p.registerPrefix(token.Arrow, p.parseLambdaExpression)
 	p.registerPrefix(token.LParen, p.parseGroupedExpression)
 	p.registerPrefix(token.If, p.parseIfExpression)
 	p.registerPrefix(token.Case, p.parseCaseExpression)
+	p.registerPrefix(token.Begin, p.parseBeginExpression)
 	p.registerPrefix(token.Self, p.parseSelfExpression)
 	p.registerPrefix(token.LBracket, p.parseArrayExpression)
 	p.registerPrefix(token.LBrace, p.parseHashExpression)
@@ -158,6 +167,7 @@ func New(l *lexer.Lexer) *Parser {
 	p.registerInfix(token.Pow, p.parseInfixExpression)
 	p.registerInfix(token.Eq, p.parseInfixExpression)
 	p.registerInfix(token.NotEq, p.parseInfixExpression)
+	p.registerInfix(token.Match, p.parseInfixExpression)
 	p.registerInfix(token.LT, p.parseInfixExpression)
 	p.registerInfix(token.LTE, p.parseInfixExpression)
 	p.registerInfix(token.GT, p.parseInfixExpression)
@@ -97,6 +97,14 @@ func (p *Parser) parseDefMethodStatement() *ast.DefStatement {
 		params = []ast.Expression{}
 	}
 
+	// Block parameter like `&block` is always the last one
+	if len(params) > 0 {
+		if pe, ok := params[len(params)-1].(*ast.PrefixExpression); ok && pe.Operator == "&" {
+			stmt.BlockParameter, _ = pe.Right.(*ast.Identifier)
+			params = params[:len(params)-1]
+		}
+	}
+
 	stmt.Parameters = params
 	stmt.BlockStatement = p.parseBlockStatement()
 	stmt.BlockStatement.KeepLastValue()
@@ -138,12 +146,18 @@ func (p *Parser) checkMethodParameters(params []ast.Expression) {
 		1 means previous arg is optioned argument
 		2 means previous arg is keyword argument
 		3 means previous arg is splat argument
+		4 means previous arg is block argument
 	*/
 	argState := NormalArg
 
 	checkedParams := []ast.Expression{}
 
 	for _, param := range params {
+		if argState == BlockArg {
+			p.error = &Error{Message: fmt.Sprintf("Block argument should be the last parameter. Line: %d", p.curToken.Line), errType: ArgumentError}
+			break
+		}
+
 		switch exp := param.(type) {
 		case *ast.Identifier:
 			switch argState {
@@ -185,6 +199,14 @@ func (p *Parser) checkMethodParameters(params []ast.Expression) {
 				argState = OptionalKeywordArg
 			}
 		case *ast.PrefixExpression:
+			if exp.Operator == "&" {
+				if _, ok := exp.Right.(*ast.Identifier); !ok {
+					p.error = &Error{Message: fmt.Sprintf("Invalid block argument: %s. Line: %d", exp.String(), p.curToken.Line), errType: ArgumentError}
+				}
+				argState = BlockArg
+				break
+			}
+
 			switch argState {
 			case SplatArg:
 				p.error = &Error{Message: fmt.Sprintf("Can't define splat argument more than once. Line: %d", p.curToken.Line), errType: ArgumentError}
@@ -286,7 +308,8 @@ func (p *Parser) parseBlockStatement() *ast.BlockStatement {
 		p.nextToken()
 	}
 
-	for !p.curTokenIs(token.End) && !p.curTokenIs(token.Else) && !p.curTokenIs(token.ElsIf) && !p.curTokenIs(token.When) {
+	for !p.curTokenIs(token.End) && !p.curTokenIs(token.Else) && !p.curTokenIs(token.ElsIf) && !p.curTokenIs(token.When) &&
+		!p.curTokenIs(token.Rescue) && !p.curTokenIs(token.Ensure) {
 
 		if p.curTokenIs(token.EOF) {
 			p.error = &Error{Message: "Unexpected EOF", errType: EndOfFileError}
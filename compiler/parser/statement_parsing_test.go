@@ -4,6 +4,7 @@ import (
 	"github.com/goby-lang/goby/compiler/ast"
 	"github.com/goby-lang/goby/compiler/lexer"
 	"github.com/goby-lang/goby/compiler/token"
+	"strings"
 	"testing"
 )
 
@@ -233,6 +234,44 @@ func TestDefStatementWithYield(t *testing.T) {
 	}
 }
 
+func TestDefStatementWithBlockParameter(t *testing.T) {
+	input := `
+	def foo(x, &block)
+	  block
+	end
+	`
+	l := lexer.New(input)
+	p := New(l)
+	program, err := p.ParseProgram()
+
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+
+	stmt := program.Statements[0].(*ast.DefStatement)
+
+	if len(stmt.Parameters) != 1 {
+		t.Fatalf("Expect block parameter not to be a normal parameter. got=%d parameters", len(stmt.Parameters))
+	}
+
+	testIdentifier(t, stmt.Parameters[0], "x")
+	testIdentifier(t, stmt.BlockParameter, "block")
+}
+
+func TestDefStatementWithBlockParameterFail(t *testing.T) {
+	input := `
+	def foo(&block, x)
+	end
+	`
+	l := lexer.New(input)
+	p := New(l)
+	_, err := p.ParseProgram()
+
+	if err == nil || !strings.Contains(err.Message, "Block argument should be the last parameter") {
+		t.Fatalf("Expect block parameter error. got=%v", err)
+	}
+}
+
 func TestWhileStatement(t *testing.T) {
 	input := `
 	while i < a.length do
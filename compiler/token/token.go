@@ -19,25 +19,37 @@ const (
 	Ident            = "IDENT"
 	InstanceVariable = "INSTANCE_VAR"
 	Int              = "INT"
+	Float            = "FLOAT"
 	String           = "STRING"
+	Symbol           = "SYMBOL"
+	Regexp           = "REGEXP"
 	Comment          = "COMMENT"
 
-	Assign   = "="
-	Plus     = "+"
-	PlusEq   = "+="
-	Minus    = "-"
-	MinusEq  = "-="
-	Bang     = "!"
-	Asterisk = "*"
-	Pow      = "**"
-	Slash    = "/"
-	Dot      = "."
-	Incr     = "++"
-	Decr     = "--"
-	And      = "&&"
-	Or       = "||"
-	OrEq     = "||="
-	Modulo   = "%"
+	// Double-quoted string with interpolations like "a#{b}c#{d}e"
+	// are tokenized as InterpolationStart("a"), <b's tokens>, InterpolationMid("c"), <d's tokens>, InterpolationEnd("e")
+	InterpolationStart = "INTERPOLATION_START"
+	InterpolationMid   = "INTERPOLATION_MID"
+	InterpolationEnd   = "INTERPOLATION_END"
+
+	Assign     = "="
+	HashRocket = "=>"
+	Plus       = "+"
+	PlusEq     = "+="
+	Minus      = "-"
+	MinusEq    = "-="
+	Arrow      = "->"
+	Bang       = "!"
+	Asterisk   = "*"
+	Pow        = "**"
+	Slash      = "/"
+	Dot        = "."
+	Incr       = "++"
+	Decr       = "--"
+	Ampersand  = "&"
+	And        = "&&"
+	Or         = "||"
+	OrEq       = "||="
+	Modulo     = "%"
 
 	LT   = "<"
 	LTE  = "<="
@@ -59,6 +71,7 @@ const (
 
 	Eq    = "=="
 	NotEq = "!="
+	Match = "=~"
 	Range = ".."
 
 	True   = "TRUE"
@@ -81,6 +94,9 @@ const (
 	Yield  = "YIELD"
 	Class  = "CLASS"
 	Module = "MODULE"
+	Begin  = "BEGIN"
+	Rescue = "RESCUE"
+	Ensure = "ENSURE"
 
 	ResolutionOperator = "::"
 )
@@ -106,6 +122,9 @@ var keywords = map[string]Type{
 	"class":  Class,
 	"module": Module,
 	"break":  Break,
+	"begin":  Begin,
+	"rescue": Rescue,
+	"ensure": Ensure,
 }
 
 // LookupIdent is used for keyword identification
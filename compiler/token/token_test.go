@@ -28,6 +28,9 @@ func TestLookupIdentTrue(t *testing.T) {
 		"do":     Do,
 		"yield":  Yield,
 		"nil":    Null,
+		"begin":  Begin,
+		"rescue": Rescue,
+		"ensure": Ensure,
 	}
 
 	for name, token := range keywords {
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/goby-lang/goby/compiler"
+	"github.com/goby-lang/goby/compiler/bytecode"
 	"github.com/goby-lang/goby/compiler/parser"
 	"github.com/goby-lang/goby/igb"
 	"github.com/goby-lang/goby/vm"
@@ -22,6 +23,8 @@ func main() {
 	versionOptionPtr := flag.Bool("v", false, "Show current Goby version")
 	interactiveOptionPtr := flag.Bool("i", false, "Run interactive goby")
 	issueOptionPtr := flag.Bool("e", false, "Run interactive goby")
+	compileOptionPtr := flag.Bool("c", false, "Compile the file into bytecode (.gbc) without running it")
+	dumpOptionPtr := flag.String("dump", "", "Print the file's tokens, ast or bytecode without running it")
 
 	flag.Parse()
 
@@ -55,41 +58,121 @@ func main() {
 		return
 	}
 
+	var instructionSets []*bytecode.InstructionSet
+
 	switch fileExt {
 	case "gb", "rb":
-		instructionSets, err := compiler.CompileToInstructions(string(file), parser.NormalMode)
-
-		if err != nil {
-			fmt.Println(err.Error())
+		if *compileOptionPtr {
+			compileFile(fp, file)
 			return
 		}
 
-		var v *vm.VM
-
-		if *issueOptionPtr {
-			fmt.Println("Will generate issue report on error...\n")
-			v, err = vm.InitIssueReportVM(dir, args)
-			defer vm.PrintError(v)
-		} else {
-			v, err = vm.New(dir, args)
+		if *dumpOptionPtr != "" {
+			if err := dump(*dumpOptionPtr, string(file)); err != nil {
+				fmt.Println(err.Error())
+				os.Exit(1)
+			}
+			return
 		}
 
+		var err error
+		instructionSets, err = compiler.CompileToInstructions(string(file), parser.NormalMode)
+
 		if err != nil {
 			fmt.Println(err.Error())
 			return
 		}
+	case bytecode.FileExtension:
+		if *compileOptionPtr || *dumpOptionPtr != "" {
+			fmt.Printf("Can't compile or dump a bytecode file: %s\n", fp)
+			os.Exit(1)
+		}
 
-		fp, err := filepath.Abs(fp)
+		var err error
+		instructionSets, _, err = bytecode.Unmarshal(file)
 
 		if err != nil {
 			fmt.Println(err.Error())
 			return
 		}
-
-		v.ExecInstructions(instructionSets, fp)
 	default:
 		fmt.Printf("Unknown file extension: %s", fileExt)
+		return
+	}
+
+	var v *vm.VM
+	var err error
+
+	if *issueOptionPtr {
+		fmt.Print("Will generate issue report on error...\n\n")
+		v, err = vm.InitIssueReportVM(dir, args)
+		defer vm.PrintError(v)
+	} else {
+		v, err = vm.New(dir, args)
 	}
+
+	if err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+
+	fp, err = filepath.Abs(fp)
+
+	if err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+
+	v.ExecInstructions(instructionSets, fp)
+	v.RunAtExitHooks()
+}
+
+// compileFile compiles the source file into a bytecode file next to it, like "foo.gb" to "foo.gbc"
+func compileFile(fp string, file []byte) {
+	info, err := os.Stat(fp)
+
+	if err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+
+	data, err := compiler.CompileToBinary(string(file), info.ModTime().UnixNano())
+
+	if err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+
+	out := strings.TrimSuffix(fp, filepath.Ext(fp)) + "." + bytecode.FileExtension
+	err = ioutil.WriteFile(out, data, 0644)
+
+	if err != nil {
+		fmt.Println(err.Error())
+	}
+}
+
+// dump prints the source's tokens, ast or bytecode for debugging the compiler
+func dump(mode, source string) error {
+	var out string
+	var err error
+
+	switch mode {
+	case "tokens":
+		out = compiler.DumpTokens(source)
+	case "ast":
+		out, err = compiler.DumpAST(source)
+	case "bytecode":
+		out, err = compiler.DumpBytecode(source)
+	default:
+		err = fmt.Errorf("Unknown dump mode: %s. available modes: tokens, ast, bytecode", mode)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(out)
+	return nil
 }
 
 func extractFileInfo(fp string) (dir, filename, fileExt string) {
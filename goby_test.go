@@ -0,0 +1,30 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+)
+
+func TestDumpUnknownMode(t *testing.T) {
+	// The command runs in the subprocess, which is this test itself
+	if os.Getenv("GOBY_TEST_DUMP") != "" {
+		os.Args = []string{"goby", "-dump=foo", "samples/print_name.gb"}
+		main()
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestDumpUnknownMode$")
+	cmd.Env = append(os.Environ(), "GOBY_TEST_DUMP=1")
+	out, err := cmd.Output()
+
+	if _, ok := err.(*exec.ExitError); !ok {
+		t.Fatalf("Expect goby to exit with a non-zero status. got: %v", err)
+	}
+
+	expected := "Unknown dump mode: foo. available modes: tokens, ast, bytecode\n"
+
+	if string(out) != expected {
+		t.Errorf("Expect output to be %q. got: %q", expected, string(out))
+	}
+}
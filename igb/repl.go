@@ -293,8 +293,6 @@ func newIVM() (ivm iVM, err error) {
 		return ivm, err
 	}
 	ivm.v = v
-	ivm.v.SetClassISIndexTable("")
-	ivm.v.SetMethodISIndexTable("")
 	ivm.v.InitForREPL()
 	// Initialize parser, lexer is not important here
 	ivm.p = parser.New(lexer.New(""))
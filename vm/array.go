@@ -2,6 +2,7 @@ package vm
 
 import (
 	"bytes"
+	"sort"
 	"strings"
 
 	"github.com/goby-lang/goby/vm/classes"
@@ -501,6 +502,94 @@ func builtinArrayInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Loop through the array in slices of the given size, passing each slice to the block.
+			// The last slice can be shorter than the size.
+			//
+			// ```ruby
+			// [1, 2, 3, 4, 5].each_slice(2) do |slice|
+			//   puts(slice)
+			// end
+			// # => [1, 2]
+			// # => [3, 4]
+			// # => [5]
+			// ```
+			//
+			// @param size [Integer]
+			// @return [Array]
+			Name: "each_slice",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect 1 argument. got=%d", len(args))
+					}
+
+					size, ok := args[0].(*IntegerObject)
+
+					if !ok {
+						return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.IntegerClass, args[0].Class().Name)
+					}
+
+					if size.value <= 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, "Invalid slice size: %d", size.value)
+					}
+
+					if blockFrame == nil {
+						return t.vm.initErrorObject(errors.InternalError, errors.CantYieldWithoutBlockFormat)
+					}
+
+					arr := receiver.(*ArrayObject)
+
+					// If it's an empty array, pop the block's call frame
+					if len(arr.Elements) == 0 {
+						t.callFrameStack.pop()
+					}
+
+					for _, slice := range arr.slices(size.value) {
+						t.builtinMethodYield(blockFrame, t.vm.initArrayObject(slice))
+					}
+
+					return arr
+				}
+			},
+		},
+		{
+			// Loop through each element with the given block, passing the element and its index.
+			//
+			// ```ruby
+			// ["a", "b"].each_with_index do |e, i|
+			//   puts(e + i.to_s)
+			// end
+			// # => "a0"
+			// # => "b1"
+			// ```
+			//
+			// @return [Array]
+			Name: "each_with_index",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect 0 argument. got=%d", len(args))
+					}
+
+					if blockFrame == nil {
+						return t.vm.initErrorObject(errors.InternalError, errors.CantYieldWithoutBlockFormat)
+					}
+
+					arr := receiver.(*ArrayObject)
+
+					// If it's an empty array, pop the block's call frame
+					if len(arr.Elements) == 0 {
+						t.callFrameStack.pop()
+					}
+
+					for i, obj := range arr.Elements {
+						t.builtinMethodYield(blockFrame, obj, t.vm.initIntegerObject(i))
+					}
+					return arr
+				}
+			},
+		},
 		{
 			// Returns if the array"s length is 0 or not.
 			//
@@ -527,6 +616,47 @@ func builtinArrayInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Returns the first element that the block returns true for, or nil if there's none.
+			//
+			// ```ruby
+			// [1, 2, 3, 4].find do |e|
+			//   e > 2
+			// end
+			// # => 3
+			// ```
+			//
+			// @return [Object]
+			Name: "find",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect 0 argument. got=%d", len(args))
+					}
+
+					if blockFrame == nil {
+						return t.vm.initErrorObject(errors.InternalError, errors.CantYieldWithoutBlockFormat)
+					}
+
+					arr := receiver.(*ArrayObject)
+
+					// If it's an empty array, pop the block's call frame
+					if len(arr.Elements) == 0 {
+						t.callFrameStack.pop()
+					}
+
+					for _, obj := range arr.Elements {
+						result := t.builtinMethodYield(blockFrame, obj)
+
+						if isTruthy(result.Target) {
+							return obj
+						}
+					}
+
+					return NULL
+				}
+			},
+		},
 		{
 			// Returns the first element of the array.
 			Name: "first",
@@ -564,6 +694,51 @@ func builtinArrayInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Calls the block with each element and concatenates the returned arrays into one array.
+			// Results that are not arrays are added as they are.
+			//
+			// ```ruby
+			// [1, 2].flat_map do |e|
+			//   [e, e * 10]
+			// end
+			// # => [1, 10, 2, 20]
+			// ```
+			//
+			// @return [Array]
+			Name: "flat_map",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect 0 argument. got=%d", len(args))
+					}
+
+					if blockFrame == nil {
+						return t.vm.initErrorObject(errors.InternalError, errors.CantYieldWithoutBlockFormat)
+					}
+
+					arr := receiver.(*ArrayObject)
+					elements := []Object{}
+
+					// If it's an empty array, pop the block's call frame
+					if len(arr.Elements) == 0 {
+						t.callFrameStack.pop()
+					}
+
+					for _, obj := range arr.Elements {
+						result := t.builtinMethodYield(blockFrame, obj)
+
+						if resultArr, ok := result.Target.(*ArrayObject); ok {
+							elements = append(elements, resultArr.Elements...)
+						} else {
+							elements = append(elements, result.Target)
+						}
+					}
+
+					return t.vm.initArrayObject(elements)
+				}
+			},
+		},
 		{
 			// Returns a new array that is a one-dimensional flattening of self.
 			//
@@ -589,6 +764,130 @@ func builtinArrayInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Groups the elements by the results of the block.
+			// Returns a hash whose keys are the results and values are arrays of the elements.
+			//
+			// ```ruby
+			// [1, 2, 3, 4].group_by do |e|
+			//   e % 2
+			// end
+			// # => { 1 => [1, 3], 0 => [2, 4] }
+			// ```
+			//
+			// @return [Hash]
+			Name: "group_by",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect 0 argument. got=%d", len(args))
+					}
+
+					if blockFrame == nil {
+						return t.vm.initErrorObject(errors.InternalError, errors.CantYieldWithoutBlockFormat)
+					}
+
+					arr := receiver.(*ArrayObject)
+					groups := t.vm.initEmptyHashObject()
+
+					// If it's an empty array, pop the block's call frame
+					if len(arr.Elements) == 0 {
+						t.callFrameStack.pop()
+					}
+
+					for _, obj := range arr.Elements {
+						key := t.builtinMethodYield(blockFrame, obj).Target
+
+						if group, ok := groups.get(t, key); ok {
+							group.(*ArrayObject).push([]Object{obj})
+						} else {
+							groups.set(t, key, t.vm.initArrayObject([]Object{obj}))
+						}
+					}
+
+					return groups
+				}
+			},
+		},
+		{
+			// Returns true if any element is `==` to the given object.
+			//
+			// ```ruby
+			// [1, "a", [2]].include?("a") # => true
+			// [1, "a", [2]].include?([2]) # => true
+			// [1, "a", [2]].include?(2)   # => false
+			// ```
+			//
+			// @param object [Object]
+			// @return [Boolean]
+			Name: "include?",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect 1 argument. got=%d", len(args))
+					}
+
+					arr := receiver.(*ArrayObject)
+
+					return toBooleanObject(arr.indexOf(t, args[0]) != -1)
+				}
+			},
+		},
+		{
+			// Returns the index of the first element that is `==` to the given object,
+			// or the first element that the block returns true for.
+			// Returns nil if there's no such element.
+			//
+			// ```ruby
+			// ["a", "b", "c"].index("b") # => 1
+			// ["a", "b", "c"].index("d") # => nil
+			//
+			// [1, 2, 3].index do |e|
+			//   e > 1
+			// end
+			// # => 1
+			// ```
+			//
+			// @param object [Object]
+			// @return [Integer]
+			Name: "index",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					arr := receiver.(*ArrayObject)
+
+					if blockFrame == nil {
+						if len(args) != 1 {
+							return t.vm.initErrorObject(errors.ArgumentError, "Expect 1 argument. got=%d", len(args))
+						}
+
+						if i := arr.indexOf(t, args[0]); i != -1 {
+							return t.vm.initIntegerObject(i)
+						}
+
+						return NULL
+					}
+
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect 0 argument with a block. got=%d", len(args))
+					}
+
+					// If it's an empty array, pop the block's call frame
+					if len(arr.Elements) == 0 {
+						t.callFrameStack.pop()
+					}
+
+					for i, obj := range arr.Elements {
+						result := t.builtinMethodYield(blockFrame, obj)
+
+						if isTruthy(result.Target) {
+							return t.vm.initIntegerObject(i)
+						}
+					}
+
+					return NULL
+				}
+			},
+		},
 		{
 			// Returns a string by concatenating each element to string, separated by given separator.
 			// If separator is nil, it uses empty string.
@@ -717,128 +1016,508 @@ func builtinArrayInstanceMethods() []*BuiltinMethodObject {
 			},
 		},
 		{
-			// Removes the last element in the array and returns it.
+			// Returns the largest element, elements are compared with `<=>`.
+			// Returns nil if the array is empty.
 			//
 			// ```ruby
-			// a = [1, 2, 3]
-			// a.pop # => 3
-			// a     # => [1, 2]
+			// [3, 1, 2].max       # => 3
+			// ["b", "c", "a"].max # => "c"
+			// [].max              # => nil
 			// ```
-			Name: "pop",
+			//
+			// @return [Object]
+			Name: "max",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
-
 					if len(args) != 0 {
 						return t.vm.initErrorObject(errors.ArgumentError, "Expect 0 argument. got=%d", len(args))
 					}
 
 					arr := receiver.(*ArrayObject)
-					return arr.pop()
+					return arr.extreme(t, 1)
 				}
 			},
 		},
 		{
-			// Appends the given object to the array and returns the array.
+			// Returns the smallest element, elements are compared with `<=>`.
+			// Returns nil if the array is empty.
 			//
 			// ```ruby
-			// a = [1, 2, 3]
-			// a.push(4) # => [1, 2, 3, 4]
+			// [3, 1, 2].min       # => 1
+			// ["b", "c", "a"].min # => "a"
+			// [].min              # => nil
 			// ```
-			Name: "push",
+			//
+			// @return [Object]
+			Name: "min",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect 0 argument. got=%d", len(args))
+					}
 
 					arr := receiver.(*ArrayObject)
-					return arr.push(args)
+					return arr.extreme(t, -1)
+				}
+			},
+		},
+		{
+			// Returns two arrays, the first one contains the elements that the block returns true for,
+			// and the second one contains the rest.
+			//
+			// ```ruby
+			// [1, 2, 3, 4].partition do |e|
+			//   e.even?
+			// end
+			// # => [[2, 4], [1, 3]]
+			// ```
+			//
+			// @return [Array]
+			Name: "partition",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect 0 argument. got=%d", len(args))
+					}
+
+					if blockFrame == nil {
+						return t.vm.initErrorObject(errors.InternalError, errors.CantYieldWithoutBlockFormat)
+					}
+
+					arr := receiver.(*ArrayObject)
+					selected := []Object{}
+					rejected := []Object{}
+
+					// If it's an empty array, pop the block's call frame
+					if len(arr.Elements) == 0 {
+						t.callFrameStack.pop()
+					}
+
+					for _, obj := range arr.Elements {
+						result := t.builtinMethodYield(blockFrame, obj)
+
+						if isTruthy(result.Target) {
+							selected = append(selected, obj)
+						} else {
+							rejected = append(rejected, obj)
+						}
+					}
+
+					return t.vm.initArrayObject([]Object{t.vm.initArrayObject(selected), t.vm.initArrayObject(rejected)})
+				}
+			},
+		},
+		{
+			// Removes the last element in the array and returns it.
+			//
+			// ```ruby
+			// a = [1, 2, 3]
+			// a.pop # => 3
+			// a     # => [1, 2]
+			// ```
+			Name: "pop",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect 0 argument. got=%d", len(args))
+					}
+
+					arr := receiver.(*ArrayObject)
+					return arr.pop()
+				}
+			},
+		},
+		{
+			// Appends the given object to the array and returns the array.
+			//
+			// ```ruby
+			// a = [1, 2, 3]
+			// a.push(4) # => [1, 2, 3, 4]
+			// ```
+			Name: "push",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+
+					arr := receiver.(*ArrayObject)
+					return arr.push(args)
+				}
+			},
+		},
+		{
+			// Loop through each elements and accumulate each results of given block in the first argument of the block
+			// If you do not give an argument, the first element of collection is used as an initial value
+			//
+			// ```ruby
+			// a = [1, 2, 7]
+			//
+			// a.reduce do |sum, n|
+			//   sum + n
+			// end
+			// # => 10
+			//
+			// a.reduce(10) do |sum, n|
+			//   sum + n
+			// end
+			// # => 20
+			// ```
+			Name: "reduce",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					arr := receiver.(*ArrayObject)
+					if blockFrame == nil {
+						return t.vm.initErrorObject(errors.InternalError, errors.CantYieldWithoutBlockFormat)
+					}
+
+					// If it's an empty array, pop the block's call frame
+					if len(arr.Elements) == 0 {
+						t.callFrameStack.pop()
+					}
+
+					var prev Object
+					var start int
+					if len(args) == 0 {
+						prev = arr.Elements[0]
+						start = 1
+					} else if len(args) == 1 {
+						prev = args[0]
+						start = 0
+					} else {
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect 0 or 1 argument. got=%d", len(args))
+					}
+
+					for i := start; i < len(arr.Elements); i++ {
+						result := t.builtinMethodYield(blockFrame, prev, arr.Elements[i])
+						prev = result.Target
+					}
+
+					return prev
+				}
+			},
+		},
+		{
+			// Returns a new array containing self‘s elements in reverse order.
+			//
+			// ```ruby
+			// a = [1, 2, 7]
+			//
+			// a.reverse # => [7, 2, 1]
+			// ```
+			Name: "reverse",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect 0 arguments. got=%d", len(args))
+					}
+
+					arr := receiver.(*ArrayObject)
+
+					return arr.reverse()
+				}
+			},
+		},
+		{
+			// Same as #each, but traverses self in reverse order.
+			//
+			// ```ruby
+			// a = ["a", "b", "c"]
+			//
+			// a.each do |e|
+			//   puts(e + e)
+			// end
+			// # => "cc"
+			// # => "bb"
+			// # => "aa"
+			// ```
+			Name: "reverse_each",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect 0 argument. got=%d", len(args))
+					}
+
+					if blockFrame == nil {
+						return t.vm.initErrorObject(errors.InternalError, errors.CantYieldWithoutBlockFormat)
+					}
+
+					arr := receiver.(*ArrayObject)
+
+					// If it's an empty array, pop the block's call frame
+					if len(arr.Elements) == 0 {
+						t.callFrameStack.pop()
+					}
+
+					reversedArr := arr.reverse()
+
+					for _, obj := range reversedArr.Elements {
+						t.builtinMethodYield(blockFrame, obj)
+					}
+
+					return reversedArr
+				}
+			},
+		},
+		{
+			// Returns a new array by putting the desired element as the first element.
+			// Use integer index as an argument to retrieve the element.
+			//
+			// ```ruby
+			// a = ["a", "b", "c", "d"]
+			//
+			// a.rotate    # => ["b", "c", "d", "a"]
+			// a.rotate(2) # => ["c", "d", "a", "b"]
+			// a.rotate(3) # => ["d", "a", "b", "c"]
+			// ```
+			Name: "rotate",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) > 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect 0..1 argument. got=%d", len(args))
+					}
+
+					arr := receiver.(*ArrayObject)
+					rotArr := t.vm.initArrayObject(arr.Elements)
+
+					rotate := 1
+
+					if len(args) != 0 {
+						arg, ok := args[0].(*IntegerObject)
+						if !ok {
+							return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.IntegerClass, args[0].Class().Name)
+						}
+						rotate = arg.value
+					}
+
+					for i := 0; i < rotate; i++ {
+						el := rotArr.shift()
+						rotArr.push([]Object{el})
+					}
+
+					return rotArr
+				}
+			},
+		},
+		{
+			// Returns a random element, or an array of n distinct random elements if n is given.
+			// Returns nil, or an empty array for an empty array.
+			// A Random generator can be given as the last argument for repeatable results.
+			//
+			// ```ruby
+			// [1, 2, 3].sample                   # => 2
+			// [1, 2, 3].sample(2)                # => [3, 1]
+			// [1, 2, 3].sample(2, Random.new(42)) # => always the same two elements
+			// ```
+			//
+			// @param n [Integer], random [Random]
+			// @return [Object]
+			Name: "sample",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					arr := receiver.(*ArrayObject)
+					g, args := randomGeneratorArg(args)
+
+					if len(args) == 0 {
+						if len(arr.Elements) == 0 {
+							return NULL
+						}
+
+						return arr.Elements[g.intn(len(arr.Elements))]
+					}
+
+					values, err := t.integerArgs(args, 1)
+
+					if err != nil {
+						return err
+					}
+
+					n := values[0]
+
+					if n < 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect sample size to be positive. got: %d", n)
+					}
+
+					elements := []Object{}
+
+					for _, i := range g.perm(len(arr.Elements)) {
+						if len(elements) == n {
+							break
+						}
+
+						elements = append(elements, arr.Elements[i])
+					}
+
+					return t.vm.initArrayObject(elements)
+				}
+			},
+		},
+		{
+			// Loop through each element with the given block.
+			// Return a new array with each element that returns true from yield.
+			//
+			// ```ruby
+			// a = [1, 2, 3, 4, 5]
+			//
+			// a.select do |e|
+			//   e + 1 > 3
+			// end
+			// # => [3, 4, 5]
+			// ```
+			Name: "select",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					arr := receiver.(*ArrayObject)
+					var elements []Object
+
+					if blockFrame == nil {
+						return t.vm.initErrorObject(errors.InternalError, errors.CantYieldWithoutBlockFormat)
+					}
+
+					// If it's an empty array, pop the block's call frame
+					if len(arr.Elements) == 0 {
+						t.callFrameStack.pop()
+					}
+
+					for _, obj := range arr.Elements {
+						result := t.builtinMethodYield(blockFrame, obj)
+						if result.Target.(*BooleanObject).value {
+							elements = append(elements, obj)
+						}
+					}
+
+					return t.vm.initArrayObject(elements)
+				}
+			},
+		},
+		{
+			// Removes the first element in the array and returns it.
+			//
+			// ```ruby
+			// a = [1, 2, 3]
+			// a.shift # => 1
+			// a       # => [2, 3]
+			// ```
+			Name: "shift",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect 0 argument. got=%d", len(args))
+					}
+
+					arr := receiver.(*ArrayObject)
+					return arr.shift()
 				}
 			},
 		},
 		{
-			// Loop through each elements and accumulate each results of given block in the first argument of the block
-			// If you do not give an argument, the first element of collection is used as an initial value
+			// Returns a new array with the elements shuffled.
+			// A Random generator can be given for repeatable results.
 			//
 			// ```ruby
-			// a = [1, 2, 7]
-			//
-			// a.reduce do |sum, n|
-			//   sum + n
-			// end
-			// # => 10
-			//
-			// a.reduce(10) do |sum, n|
-			//   sum + n
-			// end
-			// # => 20
+			// [1, 2, 3].shuffle                 # => [2, 3, 1]
+			// [1, 2, 3].shuffle(Random.new(42)) # => always the same order
 			// ```
-			Name: "reduce",
+			//
+			// @param random [Random]
+			// @return [Array]
+			Name: "shuffle",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
-					arr := receiver.(*ArrayObject)
-					if blockFrame == nil {
-						return t.vm.initErrorObject(errors.InternalError, errors.CantYieldWithoutBlockFormat)
-					}
+					g, args := randomGeneratorArg(args)
 
-					// If it's an empty array, pop the block's call frame
-					if len(arr.Elements) == 0 {
-						t.callFrameStack.pop()
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.RandomClass, args[0].Class().Name)
 					}
 
-					var prev Object
-					var start int
-					if len(args) == 0 {
-						prev = arr.Elements[0]
-						start = 1
-					} else if len(args) == 1 {
-						prev = args[0]
-						start = 0
-					} else {
-						return t.vm.initErrorObject(errors.ArgumentError, "Expect 0 or 1 argument. got=%d", len(args))
-					}
+					arr := receiver.(*ArrayObject)
+					elements := make([]Object, len(arr.Elements))
 
-					for i := start; i < len(arr.Elements); i++ {
-						result := t.builtinMethodYield(blockFrame, prev, arr.Elements[i])
-						prev = result.Target
+					for i, j := range g.perm(len(arr.Elements)) {
+						elements[i] = arr.Elements[j]
 					}
 
-					return prev
+					return t.vm.initArrayObject(elements)
 				}
 			},
 		},
 		{
-			// Returns a new array containing self‘s elements in reverse order.
+			// Returns a new array with the elements sorted by `<=>`.
+			// If a block is given, it's used to compare two elements and should return -1, 0 or 1 like `<=>`.
+			// Classes can define `<=>` to make their instances sortable.
 			//
 			// ```ruby
-			// a = [1, 2, 7]
+			// [3, 1, 2].sort       # => [1, 2, 3]
+			// ["b", "c", "a"].sort # => ["a", "b", "c"]
 			//
-			// a.reverse # => [7, 2, 1]
+			// [3, 1, 2].sort do |a, b|
+			//   b <=> a
+			// end
+			// # => [3, 2, 1]
+			//
+			// [1, "a"].sort # => ArgumentError: Comparison of String with Integer failed
 			// ```
-			Name: "reverse",
+			//
+			// @return [Array]
+			Name: "sort",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					if len(args) != 0 {
-						return t.vm.initErrorObject(errors.ArgumentError, "Expect 0 arguments. got=%d", len(args))
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect 0 argument. got=%d", len(args))
 					}
 
 					arr := receiver.(*ArrayObject)
+					elements := append([]Object{}, arr.Elements...)
 
-					return arr.reverse()
+					if blockFrame == nil {
+						if err := t.sortObjects(elements, elements); err != nil {
+							return err
+						}
+
+						return t.vm.initArrayObject(elements)
+					}
+
+					// The block won't be called if there's nothing to compare
+					if len(elements) < 2 {
+						t.callFrameStack.pop()
+					}
+
+					var err *Error
+
+					sort.SliceStable(elements, func(i, j int) bool {
+						if err != nil {
+							return false
+						}
+
+						result := t.builtinMethodYield(blockFrame, elements[i], elements[j]).Target
+						r, ok := result.(*IntegerObject)
+
+						if !ok {
+							err = t.vm.initErrorObject(errors.ArgumentError, "Comparison of %s with %s failed", elements[i].Class().Name, elements[j].Class().Name)
+							return false
+						}
+
+						return r.value < 0
+					})
+
+					if err != nil {
+						return err
+					}
+
+					return t.vm.initArrayObject(elements)
 				}
 			},
 		},
 		{
-			// Same as #each, but traverses self in reverse order.
+			// Returns a new array sorted by the results of the block, the results are compared with `<=>`.
 			//
 			// ```ruby
-			// a = ["a", "b", "c"]
-			//
-			// a.each do |e|
-			//   puts(e + e)
+			// ["ccc", "a", "bb"].sort_by do |s|
+			//   s.length
 			// end
-			// # => "cc"
-			// # => "bb"
-			// # => "aa"
+			// # => ["a", "bb", "ccc"]
 			// ```
-			Name: "reverse_each",
+			//
+			// @return [Array]
+			Name: "sort_by",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					if len(args) != 0 {
@@ -850,84 +1529,103 @@ func builtinArrayInstanceMethods() []*BuiltinMethodObject {
 					}
 
 					arr := receiver.(*ArrayObject)
+					elements := append([]Object{}, arr.Elements...)
+					keys := make([]Object, len(elements))
 
 					// If it's an empty array, pop the block's call frame
-					if len(arr.Elements) == 0 {
+					if len(elements) == 0 {
 						t.callFrameStack.pop()
 					}
 
-					reversedArr := arr.reverse()
+					for i, obj := range elements {
+						keys[i] = t.builtinMethodYield(blockFrame, obj).Target
+					}
 
-					for _, obj := range reversedArr.Elements {
-						t.builtinMethodYield(blockFrame, obj)
+					if err := t.sortObjects(keys, elements); err != nil {
+						return err
 					}
 
-					return reversedArr
+					return t.vm.initArrayObject(elements)
 				}
 			},
 		},
 		{
-			// Returns a new array by putting the desired element as the first element.
-			// Use integer index as an argument to retrieve the element.
+			// Returns the sum of the elements, which are added with `+`.
+			// The initial value is 0 unless it's given. If a block is given, the results of the block are added instead.
 			//
 			// ```ruby
-			// a = ["a", "b", "c", "d"]
+			// [1, 2, 3].sum        # => 6
+			// [1.5, 2].sum         # => 3.5
+			// ["a", "b"].sum("")   # => "ab"
 			//
-			// a.rotate    # => ["b", "c", "d", "a"]
-			// a.rotate(2) # => ["c", "d", "a", "b"]
-			// a.rotate(3) # => ["d", "a", "b", "c"]
+			// ["a", "bb"].sum do |s|
+			//   s.length
+			// end
+			// # => 3
 			// ```
-			Name: "rotate",
+			//
+			// @param initial value [Object]
+			// @return [Object]
+			Name: "sum",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					if len(args) > 1 {
-						return t.vm.initErrorObject(errors.ArgumentError, "Expect 0..1 argument. got=%d", len(args))
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect 0 or 1 argument. got=%d", len(args))
 					}
 
 					arr := receiver.(*ArrayObject)
-					rotArr := t.vm.initArrayObject(arr.Elements)
+					var sum Object = t.vm.initIntegerObject(0)
 
-					rotate := 1
+					if len(args) == 1 {
+						sum = args[0]
+					}
 
-					if len(args) != 0 {
-						arg, ok := args[0].(*IntegerObject)
-						if !ok {
-							return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.IntegerClass, args[0].Class().Name)
-						}
-						rotate = arg.value
+					// If it's an empty array, pop the block's call frame
+					if blockFrame != nil && len(arr.Elements) == 0 {
+						t.callFrameStack.pop()
 					}
 
-					for i := 0; i < rotate; i++ {
-						el := rotArr.shift()
-						rotArr.push([]Object{el})
+					for _, obj := range arr.Elements {
+						if blockFrame != nil {
+							obj = t.builtinMethodYield(blockFrame, obj).Target
+						}
+
+						sum = t.callMethodByName(sum, "+", obj)
+
+						if err, ok := sum.(*Error); ok {
+							return err
+						}
 					}
 
-					return rotArr
+					return sum
 				}
 			},
 		},
 		{
-			// Loop through each element with the given block.
-			// Return a new array with each element that returns true from yield.
+			// Returns the elements from the beginning until the block returns false for one.
 			//
 			// ```ruby
-			// a = [1, 2, 3, 4, 5]
-			//
-			// a.select do |e|
-			//   e + 1 > 3
+			// [1, 2, 3, 1].take_while do |e|
+			//   e < 3
 			// end
-			// # => [3, 4, 5]
+			// # => [1, 2]
 			// ```
-			Name: "select",
+			//
+			// @return [Array]
+			Name: "take_while",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
-					arr := receiver.(*ArrayObject)
-					var elements []Object
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect 0 argument. got=%d", len(args))
+					}
 
 					if blockFrame == nil {
 						return t.vm.initErrorObject(errors.InternalError, errors.CantYieldWithoutBlockFormat)
 					}
 
+					arr := receiver.(*ArrayObject)
+					elements := []Object{}
+
 					// If it's an empty array, pop the block's call frame
 					if len(arr.Elements) == 0 {
 						t.callFrameStack.pop()
@@ -935,9 +1633,12 @@ func builtinArrayInstanceMethods() []*BuiltinMethodObject {
 
 					for _, obj := range arr.Elements {
 						result := t.builtinMethodYield(blockFrame, obj)
-						if result.Target.(*BooleanObject).value {
-							elements = append(elements, obj)
+
+						if !isTruthy(result.Target) {
+							break
 						}
+
+						elements = append(elements, obj)
 					}
 
 					return t.vm.initArrayObject(elements)
@@ -945,14 +1646,20 @@ func builtinArrayInstanceMethods() []*BuiltinMethodObject {
 			},
 		},
 		{
-			// Removes the first element in the array and returns it.
+			// Returns a new array without duplicated elements, elements are compared with `hash` and `eql?`.
+			// If a block is given, elements are compared by the results of the block.
 			//
 			// ```ruby
-			// a = [1, 2, 3]
-			// a.shift # => 1
-			// a       # => [2, 3]
+			// [1, 2, 1, [3], [3]].uniq # => [1, 2, [3]]
+			//
+			// ["a", "b", "cc"].uniq do |s|
+			//   s.length
+			// end
+			// # => ["a", "cc"]
 			// ```
-			Name: "shift",
+			//
+			// @return [Array]
+			Name: "uniq",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					if len(args) != 0 {
@@ -960,7 +1667,35 @@ func builtinArrayInstanceMethods() []*BuiltinMethodObject {
 					}
 
 					arr := receiver.(*ArrayObject)
-					return arr.shift()
+					elements := []Object{}
+					seen := map[int][]Object{}
+
+					// If it's an empty array, pop the block's call frame
+					if blockFrame != nil && len(arr.Elements) == 0 {
+						t.callFrameStack.pop()
+					}
+
+				elementsLoop:
+					for _, obj := range arr.Elements {
+						key := obj
+
+						if blockFrame != nil {
+							key = t.builtinMethodYield(blockFrame, obj).Target
+						}
+
+						hash := t.hashOf(key)
+
+						for _, k := range seen[hash] {
+							if t.objectEql(k, key) {
+								continue elementsLoop
+							}
+						}
+
+						seen[hash] = append(seen[hash], key)
+						elements = append(elements, obj)
+					}
+
+					return t.vm.initArrayObject(elements)
 				}
 			},
 		},
@@ -1013,6 +1748,53 @@ func builtinArrayInstanceMethods() []*BuiltinMethodObject {
 						}
 					}
 
+					return t.vm.initArrayObject(elements)
+				}
+			},
+		},
+		{
+			// Merges the elements of the given arrays with the elements of self by their indexes.
+			// Missing elements are filled with nil.
+			//
+			// ```ruby
+			// [1, 2, 3].zip(["a", "b"])        # => [[1, "a"], [2, "b"], [3, nil]]
+			// [1, 2].zip([3, 4], [5, 6])       # => [[1, 3, 5], [2, 4, 6]]
+			// ```
+			//
+			// @param arrays [Array]
+			// @return [Array]
+			Name: "zip",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					arr := receiver.(*ArrayObject)
+					others := []*ArrayObject{}
+
+					for _, arg := range args {
+						other, ok := arg.(*ArrayObject)
+
+						if !ok {
+							return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.ArrayClass, arg.Class().Name)
+						}
+
+						others = append(others, other)
+					}
+
+					elements := make([]Object, len(arr.Elements))
+
+					for i, obj := range arr.Elements {
+						tuple := []Object{obj}
+
+						for _, other := range others {
+							if i < len(other.Elements) {
+								tuple = append(tuple, other.Elements[i])
+							} else {
+								tuple = append(tuple, NULL)
+							}
+						}
+
+						elements[i] = t.vm.initArrayObject(tuple)
+					}
+
 					return t.vm.initArrayObject(elements)
 				}
 			},
@@ -1035,7 +1817,7 @@ func (vm *VM) initArrayClass() *RClass {
 	ac := vm.initializeClass(classes.ArrayClass, false)
 	ac.setBuiltinMethods(builtinArrayInstanceMethods(), false)
 	ac.setBuiltinMethods(builtinArrayClassMethods(), true)
-	vm.libFiles = append(vm.libFiles, "array.gb")
+	ac.includeModule(vm.topLevelClass(classes.EnumerableModule))
 	return ac
 }
 
@@ -1052,10 +1834,12 @@ func (a *ArrayObject) toString() string {
 
 	elements := []string{}
 	for _, e := range a.Elements {
-		_, isString := e.(*StringObject)
-		if isString {
+		switch e := e.(type) {
+		case *StringObject:
 			elements = append(elements, "\""+e.toString()+"\"")
-		} else {
+		case *SymbolObject:
+			elements = append(elements, e.inspect())
+		default:
 			elements = append(elements, e.toString())
 		}
 	}
@@ -1125,6 +1909,57 @@ func (a *ArrayObject) dig(t *thread, keys []Object) Object {
 	return diggableCurrentValue.dig(t, nextKeys)
 }
 
+// extreme returns the largest element if sign is 1, or the smallest element if sign is -1
+func (a *ArrayObject) extreme(t *thread, sign int) Object {
+	if len(a.Elements) == 0 {
+		return NULL
+	}
+
+	result := a.Elements[0]
+
+	for _, obj := range a.Elements[1:] {
+		r, err := t.compareObjects(obj, result)
+
+		if err != nil {
+			return err
+		}
+
+		if r == sign {
+			result = obj
+		}
+	}
+
+	return result
+}
+
+// indexOf returns the index of the first element that is `==` to the given object, or -1 if there's none
+func (a *ArrayObject) indexOf(t *thread, obj Object) int {
+	for i, e := range a.Elements {
+		if isTruthy(t.callMethodByName(e, "==", obj)) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// slices splits the elements into slices of the given size
+func (a *ArrayObject) slices(size int) [][]Object {
+	slices := [][]Object{}
+
+	for i := 0; i < len(a.Elements); i += size {
+		end := i + size
+
+		if end > len(a.Elements) {
+			end = len(a.Elements)
+		}
+
+		slices = append(slices, a.Elements[i:end:end])
+	}
+
+	return slices
+}
+
 // Retrieves an object in an array using Integer index; common to `[]` and `at()`.
 func (a *ArrayObject) index(t *thread, args []Object) Object {
 	if len(args) != 1 {
@@ -1260,3 +2095,83 @@ func (a *ArrayObject) unshift(objs []Object) *ArrayObject {
 	a.Elements = append(objs, a.Elements...)
 	return a
 }
+
+// Other helper functions ----------------------------------------------
+
+// compareObjects compares two objects with the `<=>` protocol and returns -1, 0 or 1.
+// An ArgumentError is returned if they can't be compared.
+func (t *thread) compareObjects(a, b Object) (int, *Error) {
+	if x, ok := a.(*StringObject); ok {
+		if y, ok := b.(*StringObject); ok {
+			return strings.Compare(x.value, y.value), nil
+		}
+	}
+
+	if x, ok := toFloat(a); ok {
+		if y, ok := toFloat(b); ok {
+			switch {
+			case x < y:
+				return -1, nil
+			case x > y:
+				return 1, nil
+			}
+
+			return 0, nil
+		}
+	}
+
+	if r, ok := t.callMethodByName(a, "<=>", b).(*IntegerObject); ok {
+		switch {
+		case r.value < 0:
+			return -1, nil
+		case r.value > 0:
+			return 1, nil
+		}
+
+		return 0, nil
+	}
+
+	return 0, t.vm.initErrorObject(errors.ArgumentError, "Comparison of %s with %s failed", a.Class().Name, b.Class().Name)
+}
+
+// sortObjects sorts the keys with `<=>`, and the elements are sorted along with their keys
+func (t *thread) sortObjects(keys, elements []Object) *Error {
+	var err *Error
+	indexes := make([]int, len(keys))
+
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	sort.SliceStable(indexes, func(i, j int) bool {
+		if err != nil {
+			return false
+		}
+
+		r, e := t.compareObjects(keys[indexes[i]], keys[indexes[j]])
+
+		if e != nil {
+			err = e
+			return false
+		}
+
+		return r < 0
+	})
+
+	if err != nil {
+		return err
+	}
+
+	sortedKeys := make([]Object, len(keys))
+	sortedElements := make([]Object, len(elements))
+
+	for i, index := range indexes {
+		sortedKeys[i] = keys[index]
+		sortedElements[i] = elements[index]
+	}
+
+	copy(keys, sortedKeys)
+	copy(elements, sortedElements)
+
+	return nil
+}
@@ -92,7 +92,7 @@ func TestArrayDigMethod(t *testing.T) {
 			[1 , 2].dig(-2)
 		`, 1},
 		{`
-			[{a: 3} , 2].dig(0, :a)
+			[{a: 3} , 2].dig(0, "a")
 		`, 3},
 		{`
 			[[], 2].dig(0, 1)
@@ -1357,6 +1357,55 @@ func TestArrayRotateMethodFail(t *testing.T) {
 	}
 }
 
+func TestArraySampleAndShuffleMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`[].sample`, nil},
+		{`[1].sample`, 1},
+		{`[].sample(2).length`, 0},
+		{`[1, 2, 3].sample(5).length`, 3},
+		{`[1, 2, 3].sample(0).length`, 0},
+		{`[1, 2, 3].sample(3).sort.to_s`, "[1, 2, 3]"},
+		{`[1, 2, 3].include?([1, 2, 3].sample)`, true},
+		{`[1, 2, 3, 4].sample(2, Random.new(42)) == [1, 2, 3, 4].sample(2, Random.new(42))`, true},
+		{`[1, 2, 3, 4].sample(Random.new(42)) == [1, 2, 3, 4].sample(Random.new(42))`, true},
+		{`[].shuffle.length`, 0},
+		{`[1, 2, 3, 4].shuffle.sort.to_s`, "[1, 2, 3, 4]"},
+		{`[1, 2, 3, 4].shuffle(Random.new(42)) == [1, 2, 3, 4].shuffle(Random.new(42))`, true},
+		{`
+		a = [1, 2, 3]
+		a.shuffle
+		a.to_s
+		`, "[1, 2, 3]"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestArraySampleAndShuffleMethodsFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`[1, 2].sample(-1)`, "ArgumentError: Expect sample size to be positive. got: -1", 1},
+		{`[1, 2].sample("a")`, "TypeError: Expect argument to be Integer. got: String", 1},
+		{`[1, 2].shuffle(1)`, "TypeError: Expect argument to be Random. got: Integer", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestArraySelectMethod(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -1573,3 +1622,265 @@ func TestArrayIncludeP(t *testing.T) {
 		v.checkSP(t, i, 1)
 	}
 }
+
+func TestArrayEachSliceMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected [][]interface{}
+	}{
+		{`
+		slices = []
+		[1, 2, 3, 4, 5].each_slice(2) do |s|
+		  slices.push(s)
+		end
+		slices
+		`, [][]interface{}{{1, 2}, {3, 4}, {5}}},
+		{`
+		slices = []
+		[].each_slice(2) do |s|
+		  slices.push(s)
+		end
+		slices
+		`, [][]interface{}{}},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		testBidimensionalArrayObject(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestArrayEachWithIndexMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		s = ""
+		["a", "b", "c"].each_with_index do |e, i|
+		  s = s + e + i.to_s
+		end
+		s
+		`, "a0b1c2"},
+		{`
+		s = ""
+		[].each_with_index do |e, i|
+		  s = s + e + i.to_s
+		end
+		s
+		`, ""},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestArraySearchMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		[1, 2, 3, 4].find do |e|
+		  e > 2
+		end
+		`, 3},
+		{`
+		[1, 2].find do |e|
+		  e > 2
+		end
+		`, nil},
+		{`["a", "b", "c"].index("b")`, 1},
+		{`["a", "b", "c"].index("d")`, nil},
+		{`[[1], [2]].index([2])`, 1},
+		{`
+		[1, 2, 3].index do |e|
+		  e > 1
+		end
+		`, 1},
+		{`[1, "a", [2]].include?([2])`, true},
+		{`[1, "a", [2]].include?(2)`, false},
+		{`[3, 1, 2].max`, 3},
+		{`[3, 1, 2].min`, 1},
+		{`["b", "c", "a"].max`, "c"},
+		{`[1, 2.5, 2].max`, 2.5},
+		{`[].min`, nil},
+		{`[1, 2, 3].sum`, 6},
+		{`[1.5, 2].sum`, 3.5},
+		{`["a", "b"].sum("")`, "ab"},
+		{`[].sum`, 0},
+		{`
+		["a", "bb"].sum do |s|
+		  s.length
+		end
+		`, 3},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestArraySortMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []interface{}
+	}{
+		{`[3, 1, 2].sort`, []interface{}{1, 2, 3}},
+		{`["b", "c", "a"].sort`, []interface{}{"a", "b", "c"}},
+		{`[2, 1.5, 1].sort`, []interface{}{1, 1.5, 2}},
+		{`[].sort`, []interface{}{}},
+		{`
+		[3, 1, 2].sort do |a, b|
+		  b <=> a
+		end
+		`, []interface{}{3, 2, 1}},
+		{`
+		[1].sort do |a, b|
+		  b <=> a
+		end
+		`, []interface{}{1}},
+		{`
+		["ccc", "a", "bb"].sort_by do |s|
+		  s.length
+		end
+		`, []interface{}{"a", "bb", "ccc"}},
+		{`
+		class Version
+		  attr_reader :number
+
+		  def initialize(number)
+		    @number = number
+		  end
+
+		  def <=>(other)
+		    @number <=> other.number
+		  end
+		end
+
+		[Version.new(3), Version.new(1), Version.new(2)].sort.map do |v|
+		  v.number
+		end
+		`, []interface{}{1, 2, 3}},
+		{`
+		a = [3, 1, 2]
+		a.sort
+		a
+		`, []interface{}{3, 1, 2}},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		testArrayObject(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestArrayCollectionMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []interface{}
+	}{
+		{`[1, 2, 1, "a", "a", 2.0].uniq`, []interface{}{1, 2, "a", 2.0}},
+		{`
+		["a", "b", "cc"].uniq do |s|
+		  s.length
+		end
+		`, []interface{}{"a", "cc"}},
+		{`
+		[1, 2].flat_map do |e|
+		  [e, e * 10]
+		end
+		`, []interface{}{1, 10, 2, 20}},
+		{`
+		[1, 2].flat_map do |e|
+		  e
+		end
+		`, []interface{}{1, 2}},
+		{`
+		[1, 2, 3, 1].take_while do |e|
+		  e < 3
+		end
+		`, []interface{}{1, 2}},
+		{`
+		g = [1, 2, 3, 4].group_by do |e|
+		  e % 2
+		end
+		g[1]
+		`, []interface{}{1, 3}},
+		{`
+		g = ["a", "bb", "c"].group_by do |e|
+		  e.length
+		end
+		g.keys
+		`, []interface{}{1, 2}},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		testArrayObject(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+
+	tests2 := []struct {
+		input    string
+		expected [][]interface{}
+	}{
+		{`
+		[1, 2, 3, 4].partition do |e|
+		  e.even?
+		end
+		`, [][]interface{}{{2, 4}, {1, 3}}},
+		{`[1, 2, 3].zip(["a", "b"], [4, 5, 6])`, [][]interface{}{{1, "a", 4}, {2, "b", 5}, {3, nil, 6}}},
+		{`[[3], [4], [3]].uniq`, [][]interface{}{{3}, {4}}},
+	}
+
+	for i, tt := range tests2 {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		testBidimensionalArrayObject(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestArraySortAndSearchMethodsFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`[1, "a"].sort`, "ArgumentError: Comparison of String with Integer failed", 1},
+		{`[1, "a"].max`, "ArgumentError: Comparison of String with Integer failed", 1},
+		{`[1, 2].each_slice(0) do |s|
+		end`, "ArgumentError: Invalid slice size: 0", 1},
+		{`[1, 2].each_slice("a") do |s|
+		end`, "TypeError: Expect argument to be Integer. got: String", 1},
+		{`[1, 2].each_slice(1)`, "InternalError: Can't yield without a block", 1},
+		{`[1, 2].find`, "InternalError: Can't yield without a block", 1},
+		{`[1, 2].sort_by`, "InternalError: Can't yield without a block", 1},
+		{`[1, 2].zip(1)`, "TypeError: Expect argument to be Array. got: Integer", 1},
+		{`[1, "a"].sum`, "TypeError: Expect argument to be Numeric. got: String", 1},
+		{`[1, 2].include?`, "ArgumentError: Expect 1 argument. got=0", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
@@ -0,0 +1,165 @@
+package vm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/goby-lang/goby/compiler"
+	"github.com/goby-lang/goby/compiler/parser"
+)
+
+func BenchmarkMethodCall(b *testing.B) {
+	benchmarkEval(b, `
+	class Foo
+	  def bar(x)
+	    x + 1
+	  end
+	end
+
+	f = Foo.new
+	i = 0
+	while i < 1000 do
+	  i = f.bar(i)
+	end
+	`)
+}
+
+func BenchmarkBlockCall(b *testing.B) {
+	benchmarkEval(b, `
+	sum = 0
+	(1..1000).each do |i|
+	  sum = sum + i
+	end
+	`)
+}
+
+func BenchmarkMethodCallWithBlock(b *testing.B) {
+	benchmarkEval(b, `
+	def twice
+	  yield
+	  yield
+	end
+
+	i = 0
+	while i < 1000 do
+	  twice do
+	    i += 1
+	  end
+	end
+	`)
+}
+
+func BenchmarkMethodDefinition(b *testing.B) {
+	benchmarkEval(b, `
+	class Foo
+	  def a; 1; end
+	  def b; 2; end
+	  def c; 3; end
+	end
+
+	Foo.new.a + Foo.new.b + Foo.new.c
+	`)
+}
+
+func BenchmarkPolymorphicCall(b *testing.B) {
+	benchmarkEval(b, `
+	sum = 0
+
+	[1, 2.5, "s", :sym, [1], { a: 1 }].each do |o|
+	  i = 0
+	  while i < 100 do
+	    sum += o.to_s.length
+	    i += 1
+	  end
+	end
+	`)
+}
+
+func BenchmarkInstructionTranslation(b *testing.B) {
+	iss, err := compiler.CompileToInstructions(`
+	class Foo
+	  def bar(x, y = 2)
+	    [x, y, 1.5, "str", :sym, true].map do |e|
+	      e.to_s
+	    end
+	  end
+	end
+
+	Foo.new.bar(1).each do |s|
+	  if s.length > 10
+	    puts(s)
+	  end
+	end
+	`, parser.TestMode)
+
+	if err != nil {
+		b.Fatal(err.Error())
+	}
+
+	v := initTestVM()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		it := newInstructionTranslator(getFilename())
+		it.vm = v
+		it.transferInstructionSets(iss)
+	}
+}
+
+// BenchmarkInstructionDispatch runs straight-line code of cheap instructions without method calls,
+// so most of the time is spent on dispatching the instructions
+func BenchmarkInstructionDispatch(b *testing.B) {
+	iss, err := compiler.CompileToInstructions(strings.Repeat(`
+	a = 1
+	b = a
+	c = nil
+	if b
+	  c = a
+	else
+	  c = b
+	end
+	`, 100), parser.TestMode)
+
+	if err != nil {
+		b.Fatal(err.Error())
+	}
+
+	v := initTestVM()
+	it := newInstructionTranslator(getFilename())
+	it.vm = v
+	it.transferInstructionSets(iss)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		cf := newCallFrame(it.program)
+		cf.self = v.mainObj
+		v.mainThread.callFrameStack.push(cf)
+		v.startFromTopFrame()
+		v.mainThread.callFrameStack.pop()
+		v.mainThread.sp = 0
+	}
+}
+
+// benchmarkEval compiles the input once, and evaluates it in the same VM in every iteration
+func benchmarkEval(b *testing.B, input string) {
+	iss, err := compiler.CompileToInstructions(input, parser.TestMode)
+
+	if err != nil {
+		b.Fatal(err.Error())
+	}
+
+	v := initTestVM()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		v.ExecInstructions(iss, getFilename())
+
+		if p := v.mainThread.stack.top(); p != nil {
+			if err, ok := p.Target.(*Error); ok {
+				b.Fatal(err.toString())
+			}
+		}
+
+		v.mainThread.sp = 0
+	}
+}
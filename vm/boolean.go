@@ -128,6 +128,18 @@ func toBooleanObject(value bool) *BooleanObject {
 	return FALSE
 }
 
+// isTruthy returns false only for `false` and `nil`, just like how conditions are evaluated
+func isTruthy(obj Object) bool {
+	switch o := obj.(type) {
+	case *BooleanObject:
+		return o.value
+	case *NullObject:
+		return false
+	}
+
+	return true
+}
+
 // Value returns the object
 func (b *BooleanObject) Value() interface{} {
 	return b.value
@@ -1,6 +1,11 @@
 package vm
 
-import "sync"
+import (
+	"fmt"
+	"sync"
+
+	"github.com/goby-lang/goby/compiler/bytecode"
+)
 
 type callFrameStack struct {
 	callFrames []*callFrame
@@ -19,9 +24,51 @@ type callFrame struct {
 	lPr        int
 	isBlock    bool
 	blockFrame *callFrame
+	// error handlers pushed by `begin` expressions, the last one is the innermost handler
+	handlers []*errorHandler
+	// errors rescued in this frame, the last one is the innermost, they're raised again by `raise` without arguments
+	rescuedErrors []*rescuedError
+	// visibility of the methods defined in this frame, it's changed by `private`, `protected` and `public`
+	visibility int
 	sync.RWMutex
 }
 
+// errorHandler stores where to jump and which stack position to restore when an error is raised
+type errorHandler struct {
+	pc int
+	sp int
+}
+
+// rescuedError is an error handled by the rescue clauses between pc and end
+type rescuedError struct {
+	err *Error
+	pc  int
+	end int
+}
+
+// rescuedErrorAt returns the error handled by the rescue clauses at the given pc,
+// the errors whose clauses are already left are removed.
+func (cf *callFrame) rescuedErrorAt(pc int) *Error {
+	for n := len(cf.rescuedErrors); n > 0; n-- {
+		if r := cf.rescuedErrors[n-1]; r.pc <= pc && pc < r.end {
+			return r.err
+		}
+
+		cf.rescuedErrors = cf.rescuedErrors[:n-1]
+	}
+
+	return nil
+}
+
+// addRescuedError adds the error rescued by the clauses, which replaces the error rescued by the same clauses before, like in a loop
+func (cf *callFrame) addRescuedError(r *rescuedError) {
+	if cf.rescuedErrorAt(r.pc) != nil && cf.rescuedErrors[len(cf.rescuedErrors)-1].pc == r.pc {
+		cf.rescuedErrors = cf.rescuedErrors[:len(cf.rescuedErrors)-1]
+	}
+
+	cf.rescuedErrors = append(cf.rescuedErrors, r)
+}
+
 // We use lock on every local variable retrieval and insertion.
 // The main scenario is when multiple threads want to access local variables outside it's block
 // Since they share same block frame, they will all access to that frame's locals.
@@ -98,6 +145,30 @@ func (cf *callFrame) lookupConstant(constName string) *Pointer {
 	return c
 }
 
+// label returns the frame's name used in error backtraces
+func (cf *callFrame) label() string {
+	switch cf.instructionSet.isType {
+	case bytecode.Program:
+		return "<main>"
+	case bytecode.ClassDef:
+		return fmt.Sprintf("<class:%s>", cf.instructionSet.name)
+	case bytecode.Block:
+		if cf.ep != nil {
+			return "block in " + cf.ep.label()
+		}
+
+		return "block"
+	default:
+		return cf.instructionSet.name
+	}
+}
+
+// sourceLine returns the source line of the instruction the frame is executing
+func (cf *callFrame) sourceLine() int {
+	// Add 1 to source line because it's zero indexed
+	return cf.instructionSet.instructions[cf.pc-1].sourceLine + 1
+}
+
 func (cfs *callFrameStack) push(cf *callFrame) {
 	if cf == nil {
 		panic("Callframe can't be nil!")
@@ -2,26 +2,140 @@ package vm
 
 import (
 	"fmt"
-	"sync"
+	"reflect"
+	"runtime"
+	"time"
 
 	"github.com/goby-lang/goby/vm/classes"
+	"github.com/goby-lang/goby/vm/errors"
 )
 
-// ChannelObject represents a goby channel, which carries a golang channel
+// ChannelObject represents a goby channel, which carries a golang channel of objects.
+// A channel is unbuffered by default, and a buffered channel can be created with its capacity.
+//
+// ```ruby
+// c = Channel.new(2)
+// c.deliver(1)
+// c.deliver(2)
+// c.close
+//
+// c.each do |i|
+//   puts(i)
+// end
+// ```
+//
 type ChannelObject struct {
 	*baseObj
-	Chan chan int
+	Chan chan Object
+}
+
+// selectorClassName is the name of the class of the object yielded by `Channel.select`, which is defined under Channel
+const selectorClassName = "Selector"
+
+// SelectorObject collects the cases of a `Channel.select` call
+type SelectorObject struct {
+	*baseObj
+	cases []*selectCase
+}
+
+// selectCase is a receive, deliver or default case of `Channel.select`, the block is called when the case is chosen
+type selectCase struct {
+	dir        reflect.SelectDir
+	channel    *ChannelObject
+	value      Object
+	blockFrame *callFrame
 }
 
 // Class methods --------------------------------------------------------
 func builtinChannelClassMethods() []*BuiltinMethodObject {
 	return []*BuiltinMethodObject{
 		{
+			// Creates a channel with the given capacity, a channel without capacity is unbuffered.
+			//
+			// ```ruby
+			// c = Channel.new     # unbuffered
+			// c = Channel.new(10) # buffered, delivering doesn't block until there're 10 objects in the channel
+			// ```
+			//
+			// @param capacity [Integer]
+			// @return [Channel]
 			Name: "new",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
-					c := &ChannelObject{baseObj: &baseObj{class: t.vm.topLevelClass(classes.ChannelClass)}, Chan: make(chan int)}
-					return c
+					capacity := 0
+
+					switch len(args) {
+					case 0:
+					case 1:
+						i, ok := args[0].(*IntegerObject)
+
+						if !ok {
+							return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.IntegerClass, args[0].Class().Name)
+						}
+
+						if i.value < 0 {
+							return t.vm.initErrorObject(errors.ArgumentError, "Channel capacity can't be negative. got: %d", i.value)
+						}
+
+						capacity = i.value
+					default:
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect 0 or 1 argument. got: %d", len(args))
+					}
+
+					return t.vm.initChannelObject(capacity)
+				}
+			},
+		},
+		{
+			// Waits until one of the cases can proceed, then calls the case's block and returns its result.
+			// The block of `select` is called with a selector to set up the cases:
+			//
+			// - `receive(channel) { |value| }` is chosen when an object is received from the channel,
+			//   the object is nil if the channel is closed
+			// - `deliver(channel, value) { }` is chosen when the object is delivered to the channel
+			// - `default { }` is chosen when no other case can proceed, which makes `select` non-blocking
+			//
+			// If many cases can proceed, one of them is chosen randomly.
+			// A case without block makes `select` return the received object, or nil for other cases.
+			//
+			// ```ruby
+			// c1 = Channel.new(1)
+			// c2 = Channel.new(1)
+			// c2.deliver(10)
+			//
+			// Channel.select do |s|
+			//   s.receive(c1) do |v|
+			//     "c1: " + v.to_s
+			//   end
+			//   s.receive(c2) do |v|
+			//     "c2: " + v.to_s
+			//   end
+			//   s.default do
+			//     "nothing"
+			//   end
+			// end # => "c2: 10"
+			// ```
+			//
+			// @return [Object] The result of the chosen case's block
+			Name: "select",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if blockFrame == nil {
+						return t.vm.initErrorObject(errors.InternalError, errors.CantYieldWithoutBlockFormat)
+					}
+
+					s := &SelectorObject{baseObj: &baseObj{class: t.vm.topLevelClass(classes.ChannelClass).getClassConstant(selectorClassName)}}
+					result := t.builtinMethodYield(blockFrame, s)
+
+					if err, ok := result.Target.(*Error); ok && err.raised {
+						return err
+					}
+
+					if len(s.cases) == 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect at least 1 case for select")
+					}
+
+					return s.selectCase(t)
 				}
 			},
 		},
@@ -32,40 +146,243 @@ func builtinChannelClassMethods() []*BuiltinMethodObject {
 func builtinChannelInstanceMethods() []*BuiltinMethodObject {
 	return []*BuiltinMethodObject{
 		{
+			// Closes the channel. Objects in a closed buffered channel can still be received,
+			// and receiving from an empty closed channel returns nil immediately.
+			//
+			// ```ruby
+			// c = Channel.new
+			// c.close
+			// c.receive # => nil
+			// ```
+			//
+			// @return [Null]
 			Name: "close",
 			Fn: func(receiver Object) builtinMethodBody {
-				return func(t *thread, args []Object, blockFrame *callFrame) Object {
-					c := receiver.(*ChannelObject)
+				return func(t *thread, args []Object, blockFrame *callFrame) (result Object) {
+					defer t.recoverClosedChannel(&result, "Channel is already closed")
 
-					close(c.Chan)
+					close(receiver.(*ChannelObject).Chan)
 
 					return NULL
 				}
 			},
 		},
 		{
+			// Delivers the object to the channel, it blocks until the object is received or there's room in the buffer.
+			//
+			// ```ruby
+			// c = Channel.new
+			//
+			// thread do
+			//   c.deliver("foo")
+			// end
+			//
+			// c.receive # => "foo"
+			// ```
+			//
+			// @param object [Object]
+			// @return [Object] The delivered object
 			Name: "deliver",
 			Fn: func(receiver Object) builtinMethodBody {
-				return func(t *thread, args []Object, blockFrame *callFrame) Object {
-					id := t.vm.channelObjectMap.storeObj(args[0])
+				return func(t *thread, args []Object, blockFrame *callFrame) (result Object) {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
 
-					c := receiver.(*ChannelObject)
+					defer t.recoverClosedChannel(&result, "Can't deliver to a closed channel")
 
-					c.Chan <- id
+					receiver.(*ChannelObject).Chan <- args[0]
 
 					return args[0]
 				}
 			},
 		},
 		{
+			// Yields the objects received from the channel until it's closed.
+			//
+			// ```ruby
+			// c = Channel.new(3)
+			// c.deliver(1)
+			// c.deliver(2)
+			// c.close
+			//
+			// sum = 0
+			// c.each do |i|
+			//   sum += i
+			// end
+			// sum # => 3
+			// ```
+			//
+			// @return [Channel]
+			Name: "each",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if blockFrame == nil {
+						return t.vm.initErrorObject(errors.InternalError, errors.CantYieldWithoutBlockFormat)
+					}
+
+					yielded := false
+
+					for obj := range receiver.(*ChannelObject).Chan {
+						yielded = true
+						t.builtinMethodYield(blockFrame, obj)
+					}
+
+					// If nothing is received, pop the block's call frame
+					if !yielded {
+						t.callFrameStack.pop()
+					}
+
+					return receiver
+				}
+			},
+		},
+		{
+			// Receives an object from the channel, it blocks until there's an object or the channel is closed.
+			// It returns nil if the channel is closed, or no object is received in the given seconds of timeout.
+			//
+			// ```ruby
+			// c = Channel.new
+			//
+			// thread do
+			//   c.deliver(1)
+			// end
+			//
+			// c.receive      # => 1
+			// c.receive(0.1) # => nil
+			// ```
+			//
+			// @param timeout [Numeric] Seconds to wait
+			// @return [Object]
 			Name: "receive",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					c := receiver.(*ChannelObject)
 
-					num := <-c.Chan
+					switch len(args) {
+					case 0:
+						return receivedObject(<-c.Chan)
+					case 1:
+						timeout, ok := args[0].(Numeric)
+
+						if !ok {
+							return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, "Numeric", args[0].Class().Name)
+						}
+
+						timer := time.NewTimer(time.Duration(timeout.floatValue() * float64(time.Second)))
+						defer timer.Stop()
+
+						select {
+						case obj := <-c.Chan:
+							return receivedObject(obj)
+						case <-timer.C:
+							return NULL
+						}
+					default:
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect 0 or 1 argument. got: %d", len(args))
+					}
+				}
+			},
+		},
+		{
+			// Delivers the object only if it can be delivered without blocking, and returns true if it's delivered.
+			//
+			// ```ruby
+			// c = Channel.new(1)
+			// c.try_deliver(1) # => true
+			// c.try_deliver(2) # => false
+			// ```
+			//
+			// @param object [Object]
+			// @return [Boolean]
+			Name: "try_deliver",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) (result Object) {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					defer t.recoverClosedChannel(&result, "Can't deliver to a closed channel")
+
+					select {
+					case receiver.(*ChannelObject).Chan <- args[0]:
+						return TRUE
+					default:
+						return FALSE
+					}
+				}
+			},
+		},
+		{
+			// Receives an object only if there's one in the channel, otherwise it returns nil without blocking.
+			//
+			// ```ruby
+			// c = Channel.new(1)
+			// c.try_receive # => nil
+			// c.deliver(1)
+			// c.try_receive # => 1
+			// ```
+			//
+			// @return [Object]
+			Name: "try_receive",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					select {
+					case obj := <-receiver.(*ChannelObject).Chan:
+						return receivedObject(obj)
+					default:
+						return NULL
+					}
+				}
+			},
+		},
+	}
+}
+
+// Instance methods -----------------------------------------------------
+func builtinSelectorInstanceMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			// Adds a case which is chosen when no other case can proceed.
+			//
+			// @return [Null]
+			Name: "default",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return receiver.(*SelectorObject).addCase(t, reflect.SelectDefault, nil, nil, blockFrame)
+				}
+			},
+		},
+		{
+			// Adds a case which is chosen when the object is delivered to the channel.
+			//
+			// @param channel [Channel]
+			// @param object [Object]
+			// @return [Null]
+			Name: "deliver",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 2 {
+						return t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, 2, len(args))
+					}
+
+					return receiver.(*SelectorObject).addCase(t, reflect.SelectSend, args[0], args[1], blockFrame)
+				}
+			},
+		},
+		{
+			// Adds a case which is chosen when an object is received from the channel, the object is passed to the block.
+			//
+			// @param channel [Channel]
+			// @return [Null]
+			Name: "receive",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
 
-					return t.vm.channelObjectMap.retrieveObj(num)
+					return receiver.(*SelectorObject).addCase(t, reflect.SelectRecv, args[0], nil, blockFrame)
 				}
 			},
 		},
@@ -76,10 +393,19 @@ func builtinChannelInstanceMethods() []*BuiltinMethodObject {
 
 // Functions for initialization -----------------------------------------
 
+func (vm *VM) initChannelObject(capacity int) *ChannelObject {
+	return &ChannelObject{baseObj: &baseObj{class: vm.topLevelClass(classes.ChannelClass)}, Chan: make(chan Object, capacity)}
+}
+
 func (vm *VM) initChannelClass() *RClass {
 	class := vm.initializeClass(classes.ChannelClass, false)
 	class.setBuiltinMethods(builtinChannelClassMethods(), true)
 	class.setBuiltinMethods(builtinChannelInstanceMethods(), false)
+
+	selector := vm.initializeClass(selectorClassName, false)
+	selector.setBuiltinMethods(builtinSelectorInstanceMethods(), false)
+	class.setClassConstant(selector)
+
 	return class
 }
 
@@ -100,30 +426,135 @@ func (co *ChannelObject) toJSON() string {
 	return co.toString()
 }
 
-// copy returns the duplicate of the Array object
+// copy returns a new channel with the same capacity
 func (co *ChannelObject) copy() Object {
-	newC := &ChannelObject{baseObj: &baseObj{class: co.class}, Chan: make(chan int)}
+	newC := &ChannelObject{baseObj: &baseObj{class: co.class}, Chan: make(chan Object, cap(co.Chan))}
 	return newC
 }
 
-// objectMap ==========================================================
+// Value returns the cases
+func (s *SelectorObject) Value() interface{} {
+	return s.cases
+}
 
-type objectMap struct {
-	store *sync.Map
+// toString returns the object's name as the string format
+func (s *SelectorObject) toString() string {
+	return fmt.Sprintf("<Channel::Selector: %p>", s)
 }
 
-// Polymorphic helper functions -----------------------------------------
+// toJSON just delegates to toString
+func (s *SelectorObject) toJSON() string {
+	return s.toString()
+}
+
+// addCase adds a select case. The case's block runs after the selector's block finishes,
+// so its frame is popped from the stack like the block of `thread`.
+func (s *SelectorObject) addCase(t *thread, dir reflect.SelectDir, channel, value Object, blockFrame *callFrame) Object {
+	c := &selectCase{dir: dir, value: value, blockFrame: blockFrame}
+
+	if blockFrame != nil {
+		t.callFrameStack.pop()
+	}
+
+	if dir != reflect.SelectDefault {
+		co, ok := channel.(*ChannelObject)
+
+		if !ok {
+			return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.ChannelClass, channel.Class().Name)
+		}
+
+		c.channel = co
+	}
+
+	for _, sc := range s.cases {
+		if dir == reflect.SelectDefault && sc.dir == reflect.SelectDefault {
+			return t.vm.initErrorObject(errors.ArgumentError, "Select can only have 1 default case")
+		}
+	}
+
+	s.cases = append(s.cases, c)
 
-// storeObj stores objects into the container map
-// and update containerCount at the same time
-func (m *objectMap) storeObj(obj Object) int {
-	m.store.Store(obj.id(), obj)
+	return NULL
+}
+
+// selectCase waits for one of the cases, and calls its block
+func (s *SelectorObject) selectCase(t *thread) Object {
+	chosen, obj, err := s.wait(t)
+
+	if err != nil {
+		return err
+	}
+
+	c := s.cases[chosen]
+
+	if c.blockFrame == nil {
+		return obj
+	}
+
+	if c.dir == reflect.SelectRecv {
+		return t.builtinMethodYield(c.blockFrame, obj).Target
+	}
+
+	return t.builtinMethodYield(c.blockFrame).Target
+}
+
+// wait returns the index of the chosen case and the received object, which is nil for other cases
+func (s *SelectorObject) wait(t *thread) (chosen int, obj Object, err Object) {
+	cases := []reflect.SelectCase{}
+
+	for _, c := range s.cases {
+		sc := reflect.SelectCase{Dir: c.dir}
+
+		if c.channel != nil {
+			sc.Chan = reflect.ValueOf(c.channel.Chan)
+		}
+
+		if c.dir == reflect.SelectSend {
+			sc.Send = reflect.ValueOf(&c.value).Elem()
+		}
+
+		cases = append(cases, sc)
+	}
+
+	defer t.recoverClosedChannel(&err, "Can't deliver to a closed channel")
+
+	chosen, v, ok := reflect.Select(cases)
+	obj = NULL
+
+	if ok {
+		obj = v.Interface().(Object)
+	}
+
+	return
+}
+
+// closedChannelPanics are the messages of the runtime's panics when closing or delivering to a closed channel
+var closedChannelPanics = map[string]bool{
+	"close of closed channel": true,
+	"send on closed channel":  true,
+}
+
+// recoverClosedChannel turns the panic of operating a closed channel into an error.
+// Other panics, like the errors unwinding to rescue clauses or other runtime errors, are not recovered.
+func (t *thread) recoverClosedChannel(result *Object, message string) {
+	r := recover()
+
+	if r == nil {
+		return
+	}
 
-	return obj.id()
+	if e, ok := r.(runtime.Error); !ok || !closedChannelPanics[e.Error()] {
+		panic(r)
+	}
+
+	*result = t.vm.initErrorObject(errors.ChannelCloseError, "%s", message)
 }
 
-// retrieveObj returns the objects with the number specified
-func (m *objectMap) retrieveObj(num int) Object {
-	obj, _ := m.store.Load(num)
-	return obj.(Object)
+// receivedObject returns the received object, or nil if the channel is closed
+func receivedObject(obj Object) Object {
+	if obj == nil {
+		return NULL
+	}
+
+	return obj
 }
@@ -20,6 +20,261 @@ func TestChannelClassSuperclass(t *testing.T) {
 	}
 }
 
+func TestChannelMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		// Buffered channels
+		{`
+		c = Channel.new(2)
+		c.deliver(1)
+		c.deliver(2)
+		c.receive + c.receive
+		`, 3},
+		{`
+		c = Channel.new(1)
+		[c.try_deliver(1), c.try_deliver(2), c.try_receive, c.try_receive].to_s
+		`, "[true, false, 1, nil]"},
+		{`
+		c = Channel.new
+		c.try_deliver(1)
+		`, false},
+		// Receiving with timeout
+		{`
+		c = Channel.new
+		c.receive(0.01)
+		`, nil},
+		{`
+		c = Channel.new
+		thread do
+		  c.deliver(10)
+		end
+		c.receive(1)
+		`, 10},
+		// Closed channels
+		{`
+		c = Channel.new(3)
+		c.deliver(1)
+		c.deliver(2)
+		c.close
+		[c.receive, c.receive, c.receive, c.try_receive].to_s
+		`, "[1, 2, nil, nil]"},
+		{`
+		c = Channel.new
+		thread do
+		  3.times do |i|
+		    c.deliver(i + 1)
+		  end
+		  c.close
+		end
+
+		sum = 0
+		c.each do |i|
+		  sum += i
+		end
+		sum
+		`, 6},
+		{`
+		c = Channel.new
+		c.close
+		sum = 0
+		c.each do |i|
+		  sum += i
+		end
+		sum
+		`, 0},
+		// Nil and false are delivered as objects
+		{`
+		c = Channel.new(2)
+		c.deliver(nil)
+		c.deliver(false)
+		[c.receive, c.receive].to_s
+		`, "[nil, false]"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestChannelSelect(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		c1 = Channel.new(1)
+		c2 = Channel.new(1)
+		c2.deliver(10)
+
+		Channel.select do |s|
+		  s.receive(c1) do |v|
+		    "c1: " + v.to_s
+		  end
+		  s.receive(c2) do |v|
+		    "c2: " + v.to_s
+		  end
+		end
+		`, "c2: 10"},
+		{`
+		c = Channel.new
+
+		Channel.select do |s|
+		  s.receive(c) do |v|
+		    v
+		  end
+		  s.default do
+		    "nothing"
+		  end
+		end
+		`, "nothing"},
+		{`
+		c = Channel.new(1)
+		r = Channel.select do |s|
+		  s.deliver(c, 5) do
+		    "delivered"
+		  end
+		  s.default do
+		    "full"
+		  end
+		end
+		[r, c.receive].to_s
+		`, `["delivered", 5]`},
+		// Cases without blocks
+		{`
+		c = Channel.new(1)
+		c.deliver(3)
+		Channel.select do |s|
+		  s.receive(c)
+		end
+		`, 3},
+		{`
+		c = Channel.new
+		Channel.select do |s|
+		  s.receive(c)
+		  s.default
+		end
+		`, nil},
+		// Waiting for other threads
+		{`
+		c1 = Channel.new
+		c2 = Channel.new
+		thread do
+		  c2.deliver("foo")
+		end
+
+		Channel.select do |s|
+		  s.receive(c1) do |v|
+		    "c1"
+		  end
+		  s.receive(c2) do |v|
+		    v
+		  end
+		end
+		`, "foo"},
+		// Local variables can be used in the cases' blocks
+		{`
+		c = Channel.new(1)
+		c.deliver(2)
+		x = 10
+		results = []
+		Channel.select do |s|
+		  y = 100
+		  s.receive(c) do |v|
+		    results.push(v + x + y)
+		  end
+		end
+		results.to_s
+		`, "[112]"},
+		// Select in a loop
+		{`
+		c = Channel.new(3)
+		c.deliver(1)
+		c.deliver(2)
+		c.deliver(3)
+		sum = 0
+		done = false
+		i = 0
+		while !done do
+		  Channel.select do |s|
+		    s.receive(c) do |v|
+		      sum += v
+		    end
+		    s.default do
+		      done = true
+		    end
+		  end
+		  i += 1
+		end
+		[sum, i].to_s
+		`, "[6, 4]"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestChannelMethodsFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`Channel.new("a")`, "TypeError: Expect argument to be Integer. got: String", 1},
+		{`Channel.new(-1)`, "ArgumentError: Channel capacity can't be negative. got: -1", 1},
+		{`Channel.new(1, 2)`, "ArgumentError: Expect 0 or 1 argument. got: 2", 1},
+		{`c = Channel.new(1)
+		c.close
+		c.deliver(1)`, "ChannelCloseError: Can't deliver to a closed channel", 3},
+		{`c = Channel.new(1)
+		c.close
+		c.try_deliver(1)`, "ChannelCloseError: Can't deliver to a closed channel", 3},
+		{`c = Channel.new
+		c.close
+		c.close`, "ChannelCloseError: Channel is already closed", 3},
+		{`Channel.new.receive("a")`, "TypeError: Expect argument to be Numeric. got: String", 1},
+		{`Channel.select do |s|
+		end`, "ArgumentError: Expect at least 1 case for select", 1},
+		{`c = Channel.new
+		c.close
+		Channel.select do |s|
+		  s.deliver(c, 1)
+		end`, "ChannelCloseError: Can't deliver to a closed channel", 3},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestRecoverClosedChannelOnlyRecoversClosedChannel(t *testing.T) {
+	v := initTestVM()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expect runtime errors other than closed channel's to be panicked again")
+		}
+	}()
+
+	func() {
+		var result Object
+		defer v.mainThread.recoverClosedChannel(&result, "Can't deliver to a closed channel")
+
+		var m map[string]int
+		m["goby"] = 1
+	}()
+}
+
 func TestObjectMutationInThread(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -159,3 +414,190 @@ func TestObjectDeliveryBetweenThread(t *testing.T) {
 		v.checkSP(t, i, 1)
 	}
 }
+
+func TestThreadObject(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		t = thread do
+		  1 + 1
+		end
+
+		t.value
+		`, 2},
+		{`
+		t = thread(1, 2) do |a, b|
+		  a + b
+		end
+
+		t.join.value
+		`, 3},
+		{`
+		t = thread do
+		end
+
+		t.value
+		`, nil},
+		{`
+		t = thread do
+		  [1, 2, 3].map do |i|
+		    i * 2
+		  end
+		end
+
+		t.value.to_s
+		`, "[2, 4, 6]"},
+		{`
+		c = Channel.new
+		t = thread do
+		  c.receive
+		end
+
+		alive = t.alive?
+		c.deliver(10)
+		t.join
+		[alive, t.alive?, t.value].to_s
+		`, "[true, false, 10]"},
+		{`
+		threads = []
+		10.times do |i|
+		  threads.push(thread(i) do |n|
+		    n * 10
+		  end)
+		end
+
+		sum = 0
+		threads.each do |t|
+		  sum += t.value
+		end
+		sum
+		`, 450},
+		{`
+		t = thread do
+		end
+		t.class.name
+		`, "Thread"},
+		// Errors are raised again when the thread is joined
+		{`
+		t = thread do
+		  raise(ArgumentError, "foo")
+		end
+
+		begin
+		  t.join
+		rescue ArgumentError => e
+		  e.message
+		end
+		`, "foo"},
+		{`
+		def foo
+		  nil.bar
+		end
+
+		t = thread do
+		  foo
+		end
+
+		messages = []
+		2.times do
+		  begin
+		    t.value
+		  rescue => e
+		    messages.push(e.class.name)
+		  end
+		end
+		messages.to_s
+		`, `["UndefinedMethodError", "UndefinedMethodError"]`},
+		// Many threads can join the same failed thread at the same time
+		{`
+		t = thread do
+		  raise(ArgumentError, "foo")
+		end
+
+		c = Channel.new
+		5.times do
+		  thread do
+		    begin
+		      t.join
+		    rescue ArgumentError => e
+		      c.deliver(e.message)
+		    end
+		  end
+		end
+
+		messages = []
+		5.times do
+		  messages.push(c.receive)
+		end
+		messages.join(",")
+		`, "foo,foo,foo,foo,foo"},
+		// Errors raised in blocks called by built-in methods stop the whole thread
+		{`
+		t = thread do
+		  [1].each do |x|
+		    raise(ArgumentError, "x")
+		  end
+		  5
+		end
+
+		begin
+		  t.join
+		rescue ArgumentError => e
+		  e.message
+		end
+		`, "x"},
+		{`
+		t = thread do
+		  [1, 2].map do |x|
+		    x.foo
+		  end
+		  5
+		end
+
+		begin
+		  t.value
+		rescue UndefinedMethodError => e
+		  e.class.name
+		end
+		`, "UndefinedMethodError"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestThreadObjectFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`Thread.new`, "UnsupportedMethodError: Unsupported Method #new for Thread", 1},
+		{`t = thread do
+		  raise(ArgumentError, "foo")
+		end
+		t.join`, "ArgumentError: foo", 2},
+		{`t = thread do
+		  nil.bar
+		end
+		t.value`, "UndefinedMethodError: Undefined Method 'bar' for nil", 2},
+		{`t = thread do
+		  [1].each do |x|
+		    raise(ArgumentError, "x")
+		  end
+		  5
+		end
+		t.value`, "ArgumentError: x", 3},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
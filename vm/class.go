@@ -2,11 +2,10 @@ package vm
 
 import (
 	"fmt"
-	"io/ioutil"
 	"path"
-	"reflect"
 	"time"
 
+	"github.com/goby-lang/goby/compiler/bytecode"
 	"github.com/goby-lang/goby/vm/classes"
 	"github.com/goby-lang/goby/vm/errors"
 )
@@ -27,6 +26,9 @@ type RClass struct {
 	isModule    bool
 	constants   map[string]*Pointer
 	scope       *RClass
+	// includedModule points to the module if this class is a proxy created by `include` or `extend`.
+	// Proxies let a module be included by many classes without touching the module's own superclass.
+	includedModule *RClass
 	*baseObj
 }
 
@@ -36,7 +38,8 @@ func builtinClassCommonClassMethods() []*BuiltinMethodObject {
 		{
 			// Creates instance variables and corresponding methods that return the value of
 			// each instance variable and assign an argument to each instance variable.
-			// Only string literal can be used for now.
+			// Names can be given as string literals or symbols like `attr_reader :bar`.
+			// Like `def`, the methods are private or protected after calling `private` or `protected` in the class body.
 			//
 			// ```ruby
 			// class Foo
@@ -68,7 +71,7 @@ func builtinClassCommonClassMethods() []*BuiltinMethodObject {
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					r := receiver.(*RClass)
-					r.setAttrAccessor(args)
+					r.setAttrAccessor(args, t.callFrameStack.top().visibility)
 
 					return r
 				}
@@ -78,7 +81,7 @@ func builtinClassCommonClassMethods() []*BuiltinMethodObject {
 			// Creates instance variables and corresponding methods that return the value of each
 			// instance variable.
 			//
-			// Only string literal can be used for now.
+			// Names can be given as string literals or symbols like `attr_reader :bar`.
 			//
 			// ```ruby
 			// class Foo
@@ -104,7 +107,7 @@ func builtinClassCommonClassMethods() []*BuiltinMethodObject {
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					r := receiver.(*RClass)
-					r.setAttrReader(args)
+					r.setAttrReader(args, t.callFrameStack.top().visibility)
 
 					return r
 				}
@@ -114,7 +117,7 @@ func builtinClassCommonClassMethods() []*BuiltinMethodObject {
 			// Creates instance variables and corresponding methods that assign an argument to each
 			// instance variable. No return value.
 			//
-			// Only string literal can be used for now.
+			// Names can be given as string literals or symbols like `attr_reader :bar`.
 			//
 			// ```ruby
 			// class Foo
@@ -140,12 +143,71 @@ func builtinClassCommonClassMethods() []*BuiltinMethodObject {
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
 					r := receiver.(*RClass)
-					r.setAttrWriter(args)
+					r.setAttrWriter(args, t.callFrameStack.top().visibility)
 
 					return r
 				}
 			},
 		},
+		{
+			// Defines an instance method with the given name, the block or the Proc becomes the method's body.
+			// The body is evaluated with the instance as `self`, and it can access the local variables
+			// of the context where it's defined.
+			//
+			// ```ruby
+			// class Foo
+			//   ["bar", "baz"].each do |name|
+			//     define_method(name) do |x|
+			//       name + x.to_s
+			//     end
+			//   end
+			// end
+			//
+			// Foo.new.bar(1) # => "bar1"
+			// Foo.define_method(:double, ->(x) { x * 2 })
+			// Foo.new.double(3) # => 6
+			// ```
+			//
+			// @param name [String/Symbol], body [Proc]
+			// @return [Symbol]
+			Name: "define_method",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) < 1 || len(args) > 2 {
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect 1 or 2 arguments. got: %d", len(args))
+					}
+
+					name, ok := toName(args[0])
+					if !ok {
+						return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.StringClass, args[0].Class().Name)
+					}
+
+					if len(args) == 2 {
+						p, ok := args[1].(*ProcObject)
+						if !ok {
+							return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.ProcClass, args[1].Class().Name)
+						}
+
+						if blockFrame != nil {
+							t.callFrameStack.pop()
+						}
+
+						blockFrame = p.blockFrame
+					} else if blockFrame == nil {
+						return t.vm.initErrorObject(errors.ArgumentError, "Can't define method without a block")
+					} else {
+						// The block is called later, so we need to pop its frame from the stack manually
+						t.callFrameStack.pop()
+					}
+
+					method := generateDefinedMethod(name, blockFrame)
+					method.visibility = t.callFrameStack.top().visibility
+					receiver.(*RClass).setMethod(name, method)
+
+					return t.vm.initSymbolObject(name)
+				}
+			},
+		},
 		{
 			// Includes a module for mixin, which inherits only methods and constants from the module.
 			// The included module is inserted into the path of the inheritance tree, between the class
@@ -218,12 +280,7 @@ func builtinClassCommonClassMethods() []*BuiltinMethodObject {
 						class = r.SingletonClass()
 					}
 
-					if class.alreadyInherit(module) {
-						return class
-					}
-
-					module.superClass = class.superClass
-					class.superClass = module
+					class.includeModule(module)
 
 					return class
 				}
@@ -242,12 +299,7 @@ func builtinClassCommonClassMethods() []*BuiltinMethodObject {
 
 					class = receiver.SingletonClass()
 
-					if class.alreadyInherit(module) {
-						return class
-					}
-
-					module.superClass = class.superClass
-					class.superClass = module
+					class.includeModule(module)
 
 					return class
 				}
@@ -321,6 +373,104 @@ func builtinClassCommonClassMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Makes the methods private, so they can only be called with self as the receiver, like `foo` or `self.foo`.
+			// Without arguments, the methods defined after it in the class body become private.
+			// `send` can still call private methods.
+			//
+			// ```ruby
+			// class Foo
+			//   def bar
+			//     secret
+			//   end
+			//
+			//   private
+			//
+			//   def secret
+			//     42
+			//   end
+			// end
+			//
+			// Foo.new.bar          # => 42
+			// Foo.new.secret       # => UndefinedMethodError: Private method 'secret' called for <Instance of: Foo>
+			// Foo.new.send(:secret) # => 42
+			//
+			// class Foo
+			//   private :bar
+			// end
+			// ```
+			//
+			// @param *names [String/Symbol]
+			// @return [Object]
+			Name: "private",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.setMethodVisibility(receiver.(*RClass), args, privateMethod)
+				}
+			},
+		},
+		{
+			// Makes the methods protected, so they can be called with self as the receiver,
+			// or in the methods of the objects whose classes inherit the class defining them.
+			// Without arguments, the methods defined after it in the class body become protected.
+			//
+			// ```ruby
+			// class Account
+			//   def initialize(balance)
+			//     @balance = balance
+			//   end
+			//
+			//   def richer_than?(other)
+			//     balance > other.balance
+			//   end
+			//
+			//   protected
+			//
+			//   def balance
+			//     @balance
+			//   end
+			// end
+			//
+			// Account.new(10).richer_than?(Account.new(5)) # => true
+			// Account.new(10).balance # => UndefinedMethodError: Protected method 'balance' called for <Instance of: Account>
+			// ```
+			//
+			// @param *names [String/Symbol]
+			// @return [Object]
+			Name: "protected",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.setMethodVisibility(receiver.(*RClass), args, protectedMethod)
+				}
+			},
+		},
+		{
+			// Makes the methods public, which is the default visibility.
+			// Without arguments, the methods defined after it in the class body become public.
+			//
+			// ```ruby
+			// class Foo
+			//   private
+			//
+			//   def bar
+			//     10
+			//   end
+			//
+			//   public :bar
+			// end
+			//
+			// Foo.new.bar # => 10
+			// ```
+			//
+			// @param *names [String/Symbol]
+			// @return [Object]
+			Name: "public",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.setMethodVisibility(receiver.(*RClass), args, publicMethod)
+				}
+			},
+		},
 		{
 			// Returns the superclass object of the receiver.
 			//
@@ -433,7 +583,7 @@ func builtinClassCommonInstanceMethods() []*BuiltinMethodObject {
 					className := receiver.Class().Name
 					compareClassName := args[0].Class().Name
 
-					if className == compareClassName && reflect.DeepEqual(receiver, args[0]) {
+					if className == compareClassName && t.valueEqual(receiver, args[0]) {
 						return TRUE
 					}
 					return FALSE
@@ -466,13 +616,60 @@ func builtinClassCommonInstanceMethods() []*BuiltinMethodObject {
 					className := receiver.Class().Name
 					compareClassName := args[0].Class().Name
 
-					if className == compareClassName && reflect.DeepEqual(receiver, args[0]) {
+					if className == compareClassName && t.valueEqual(receiver, args[0]) {
 						return FALSE
 					}
 					return TRUE
 				}
 			},
 		},
+		{
+			// Returns true if the receiver and the argument are the same hash key.
+			// Built-in objects like Integer, String, Array and Hash are compared by their values,
+			// other objects are only equal to themselves unless the class defines its own `eql?`.
+			//
+			// ```ruby
+			// 1.eql?(1)              # => true
+			// 1.eql?(1.0)            # => false
+			// [1, "a"].eql?([1, "a"]) # => true
+			// Object.new.eql?(Object.new) # => false
+			// ```
+			//
+			// @param object [Object]
+			// @return [Boolean]
+			Name: "eql?",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect 1 argument. got: %d", len(args))
+					}
+
+					return toBooleanObject(t.builtinEql(receiver, args[0]))
+				}
+			},
+		},
+		{
+			// Returns an Integer hash value of the object, objects that are `eql?` have the same hash value.
+			// A class can define `hash` along with `eql?` to make its instances usable as hash keys.
+			//
+			// ```ruby
+			// "foo".hash == "foo".hash       # => true
+			// [1, 2].hash == [1, 2].hash     # => true
+			// Object.new.hash == Object.new.hash # => false
+			// ```
+			//
+			// @return [Integer]
+			Name: "hash",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect 0 argument. got: %d", len(args))
+					}
+
+					return t.vm.initIntegerObject(t.builtinHashOf(receiver))
+				}
+			},
+		},
 		{
 			// Loads the given Goby library name without extension (mainly for modules), returning `true`
 			// if successful and `false` if the feature is already loaded.
@@ -513,6 +710,8 @@ func builtinClassCommonInstanceMethods() []*BuiltinMethodObject {
 			// Loads the Goby library (mainly for modules) from the given local path plus name
 			// without extension from the current directory, returning `true` if successful,
 			// and `false` if the feature is already loaded.
+			// The compiled bytecode is cached in $GOBY_CACHE_DIR (~/.cache/goby by default), which is reused until the library is modified.
+			// A `.gbc` file compiled by `goby -c` next to the library is loaded as well.
 			//
 			// ```ruby
 			// require_relative("../test_fixtures/require_test/foo")
@@ -529,13 +728,13 @@ func builtinClassCommonInstanceMethods() []*BuiltinMethodObject {
 
 					filepath = path.Join(callerDir, filepath)
 
-					file, err := ioutil.ReadFile(filepath + ".gb")
+					instructionSets, err := t.vm.loadRequiredFile(filepath)
 
 					if err != nil {
 						return t.vm.initErrorObject(errors.InternalError, err.Error())
 					}
 
-					t.vm.execRequiredFile(filepath, file)
+					t.vm.ExecInstructions(instructionSets, filepath)
 
 					return TRUE
 				}
@@ -571,6 +770,135 @@ func builtinClassCommonInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Raises an error. Without arguments it raises the error being rescued again, or an `InternalError`
+			// outside of rescue clauses; a String raises an `InternalError` with the given message. An error class can be given with an optional message, which are passed to
+			// the class's `new`, and an error object can be raised directly.
+			//
+			// ```ruby
+			// class MyError < StandardError; end
+			//
+			// begin
+			//   raise(MyError, "wrong argument")
+			// rescue MyError => e
+			//   puts(e.message) # => wrong argument
+			//   raise           # raise it again
+			// end
+			//
+			// raise("something went wrong") # => InternalError: something went wrong
+			// raise(ArgumentError.new("bad argument"))
+			// ```
+			//
+			// @param error class [Class] or message [String] or error object (optional)
+			// @param message [String] (optional)
+			// @return [Error]
+			Name: "raise",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					switch len(args) {
+					case 0:
+						if err := t.rescuedError(); err != nil {
+							err.raised = true
+							return err
+						}
+
+						return t.vm.initErrorObject(errors.InternalError, "unhandled exception")
+					case 1:
+						switch arg := args[0].(type) {
+						case *StringObject:
+							return t.vm.initErrorObject(errors.InternalError, "%s", arg.value)
+						case *RClass:
+							if t.vm.isErrorClass(arg) {
+								return t.raiseNewError(arg, []Object{})
+							}
+						case *Error:
+							arg.raised = true
+							return arg
+						}
+					case 2:
+						class, ok := args[0].(*RClass)
+
+						if !ok || !t.vm.isErrorClass(class) {
+							return t.vm.initErrorObject(errors.TypeError, "exception class expected. got: %s", args[0].toString())
+						}
+
+						return t.raiseNewError(class, args[1:])
+					default:
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect at most 2 arguments. got: %d", len(args))
+					}
+
+					return t.vm.initErrorObject(errors.TypeError, "exception class/object expected. got: %s", args[0].toString())
+				}
+			},
+		},
+		{
+			// Registers the block to be called when the program exits, including by `exit` and uncaught errors.
+			// The blocks are called in the reverse order of their registration.
+			//
+			// ```ruby
+			// at_exit do
+			//   puts("world")
+			// end
+			//
+			// at_exit do
+			//   puts("hello")
+			// end
+			// # => hello
+			// # => world
+			// ```
+			//
+			// @return [Null]
+			Name: "at_exit",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if blockFrame == nil {
+						return t.vm.initErrorObject(errors.InternalError, errors.CantYieldWithoutBlockFormat)
+					}
+
+					t.vm.Lock()
+					t.vm.atExitHooks = append(t.vm.atExitHooks, blockFrame)
+					t.vm.Unlock()
+
+					// The block is called when the program exits
+					t.callFrameStack.pop()
+
+					return NULL
+				}
+			},
+		},
+		{
+			// Terminates the program with the exit status, which is 0 by default.
+			// The blocks registered by `at_exit` are called before the program exits.
+			//
+			// ```ruby
+			// if ARGV.length == 0
+			//   STDERR.puts("Usage: deploy.gb ENV")
+			//   exit(1)
+			// end
+			// ```
+			//
+			// @param status [Integer]
+			// @return [Null]
+			Name: "exit",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					status := 0
+
+					if len(args) > 0 {
+						values, err := t.integerArgs(args, 1)
+
+						if err != nil {
+							return err
+						}
+
+						status = values[0]
+					}
+
+					t.vm.exit(status)
+					return NULL
+				}
+			},
+		},
 		{
 			// Returns the class of the object. Receiver cannot be omitted.
 			//
@@ -593,7 +921,7 @@ func builtinClassCommonInstanceMethods() []*BuiltinMethodObject {
 					case Object:
 						return r.Class()
 					default:
-						return &Error{Message: "Can't call class on %T" + string(r.Class().ReturnName())}
+						return &Error{Message: "Can't call class on %T" + string(r.Class().ReturnName()), raised: true}
 					}
 				}
 			},
@@ -617,17 +945,19 @@ func builtinClassCommonInstanceMethods() []*BuiltinMethodObject {
 			},
 		},
 		{
-			// Suspends the current thread for duration (sec).
+			// Suspends the current thread for duration (sec). A Float or a Duration can also be specified.
 			//
-			// **Note:** currently, parameter cannot be omitted, and only Integer can be specified.
+			// **Note:** currently, parameter cannot be omitted.
 			//
 			// ```ruby
 			// a = sleep(2)
 			// puts(a)     # => 2
+			// sleep(0.5)
+			// sleep(Duration.milliseconds(100))
 			// ```
 			//
-			// @param sec [Integer] time to wait in sec
-			// @return [Integer] actual time slept in sec
+			// @param sec [Numeric, Duration] time to wait in sec
+			// @return [Object] the given parameter
 			Name: "sleep",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
@@ -635,10 +965,14 @@ func builtinClassCommonInstanceMethods() []*BuiltinMethodObject {
 						return t.vm.initErrorObject(errors.ArgumentError, "Expect 1 argument. got: %d", len(args))
 					}
 
-					int := args[0].(*IntegerObject)
-					seconds := int.value
-					time.Sleep(time.Duration(seconds) * time.Second)
-					return int
+					d, ok := toDuration(args[0], time.Second)
+
+					if !ok {
+						return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, "Numeric or Duration", args[0].Class().Name)
+					}
+
+					time.Sleep(d)
+					return args[0]
 				}
 			},
 		},
@@ -687,6 +1021,34 @@ func builtinClassCommonInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Creates a lambda with the given block, which is a Proc that checks the number of arguments strictly.
+			// The `->(x) { }` literal is a shorthand of it.
+			//
+			// ```ruby
+			// double = lambda do |x|
+			//   x * 2
+			// end
+			//
+			// double.call(3) # => 6
+			// ```
+			//
+			// @param block literal
+			// @return [Proc]
+			Name: "lambda",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if blockFrame == nil {
+						return t.vm.initErrorObject(errors.ArgumentError, "Can't create lambda without a block")
+					}
+
+					// The block is called later, so we need to pop its frame from the stack manually
+					t.callFrameStack.pop()
+
+					return t.vm.initProcObject(blockFrame, true)
+				}
+			},
+		},
 		{
 			Name: "send",
 			Fn: func(receiver Object) builtinMethodBody {
@@ -695,19 +1057,126 @@ func builtinClassCommonInstanceMethods() []*BuiltinMethodObject {
 						return t.vm.initErrorObject(errors.ArgumentError, "no method name given")
 					}
 
-					name, ok := args[0].(*StringObject)
+					name, ok := toName(args[0])
 
 					if !ok {
 						return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.StringClass, args[0].Class().Name)
 					}
 
-					t.sendMethod(name.value, len(args), blockFrame)
+					t.sendMethod(name, len(args), blockFrame)
 
 					return t.stack.top().Target
 				}
 			},
 		},
 		{
+			// Calls the method with the given name and arguments like `send`,
+			// but only public methods can be called.
+			//
+			// ```ruby
+			// class Foo
+			//   private
+			//
+			//   def bar
+			//     10
+			//   end
+			// end
+			//
+			// 1.public_send(:+, 2)     # => 3
+			// Foo.new.public_send(:bar) # => UndefinedMethodError: Private method 'bar' called for <Instance of: Foo>
+			// ```
+			//
+			// @param name [String/Symbol], args [Object]
+			// @return [Object]
+			Name: "public_send",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) < 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, "no method name given")
+					}
+
+					name, ok := toName(args[0])
+
+					if !ok {
+						return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.StringClass, args[0].Class().Name)
+					}
+
+					if method := receiver.findMethod(name); method != nil && methodVisibility(method) != publicMethod {
+						return t.visibilityError(receiver, method, name)
+					}
+
+					t.sendMethod(name, len(args), blockFrame)
+
+					return t.stack.top().Target
+				}
+			},
+		},
+		{
+			// Calls the method that the current method overrides, which is looked up from the superclass of the class
+			// defining the current method. The arguments have to be given explicitly, and the current method's block
+			// is passed if no block is given.
+			//
+			// ```ruby
+			// class Animal
+			//   def initialize(name)
+			//     @name = name
+			//   end
+			// end
+			//
+			// class Dog < Animal
+			//   def initialize(name)
+			//     super(name + " the dog")
+			//   end
+			// end
+			// ```
+			//
+			// @param args [Object]
+			// @return [Object]
+			Name: "super",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					cf := t.callFrameStack.top()
+
+					// In a block, the method is the frame where the block is defined
+					for cf.instructionSet.isType == bytecode.Block && cf.ep != nil {
+						cf = cf.ep
+					}
+
+					if cf.instructionSet.isType != bytecode.MethodDef {
+						return t.vm.initErrorObject(errors.InternalError, "super called outside of method")
+					}
+
+					name := cf.instructionSet.name
+
+					if blockFrame == nil {
+						blockFrame = cf.blockFrame
+					}
+
+					switch m := superMethod(cf.self, cf.instructionSet).(type) {
+					case *MethodObject:
+						return t.callMethod(cf.self, m, blockFrame, args...)
+					case *BuiltinMethodObject:
+						return m.Fn(cf.self)(t, args, blockFrame)
+					default:
+						return t.vm.initErrorObject(errors.UndefinedMethodError, "Undefined super method '%s' for %s", name, cf.self.toString())
+					}
+				}
+			},
+		},
+		{
+			// Runs the block in a new thread with given arguments, and returns the Thread object.
+			// The Thread can be joined to wait for it, get its result or raise the error that stopped it.
+			//
+			// ```ruby
+			// t = thread(1, 2) do |a, b|
+			//   a + b
+			// end
+			//
+			// t.value # => 3
+			// ```
+			//
+			// @param args [Object] Arguments passed to the block
+			// @return [Thread]
 			Name: "thread",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
@@ -715,17 +1184,13 @@ func builtinClassCommonInstanceMethods() []*BuiltinMethodObject {
 						return t.vm.initErrorObject(errors.InternalError, errors.CantYieldWithoutBlockFormat)
 					}
 
-					newT := t.vm.newThread()
-
-					go func() {
-						newT.builtinMethodYield(blockFrame, args...)
-					}()
+					to := t.vm.initThreadObject(blockFrame, args)
 
 					// We need to pop this frame from main thread manually,
 					// because the block's 'leave' instruction is running on other process
 					t.callFrameStack.pop()
 
-					return NULL
+					return to
 				}
 			},
 		},
@@ -803,13 +1268,13 @@ func builtinClassCommonInstanceMethods() []*BuiltinMethodObject {
 			Name: "instance_variable_get",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
-					arg, isStr := args[0].(*StringObject)
+					arg, isStr := toName(args[0])
 
 					if !isStr {
 						return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.StringClass, args[0].Class().Name)
 					}
 
-					obj, ok := receiver.instanceVariableGet(arg.value)
+					obj, ok := receiver.instanceVariableGet(arg)
 
 					if !ok {
 						return NULL
@@ -827,19 +1292,52 @@ func builtinClassCommonInstanceMethods() []*BuiltinMethodObject {
 						return t.vm.initErrorObject(errors.ArgumentError, "Expect 2 arguments. got: %d", len(args))
 					}
 
-					argName, isStr := args[0].(*StringObject)
+					argName, isStr := toName(args[0])
 					obj := args[1]
 
 					if !isStr {
 						return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.StringClass, args[0].Class().Name)
 					}
 
-					receiver.instanceVariableSet(argName.value, obj)
+					receiver.instanceVariableSet(argName, obj)
 
 					return obj
 				}
 			},
 		},
+		{
+			// Returns the names of the object's instance variables as Symbols.
+			//
+			// ```ruby
+			// class Foo
+			//   def initialize
+			//     @bar = 1
+			//     @baz = 2
+			//   end
+			// end
+			//
+			// Foo.new.instance_variables # => [:@bar, :@baz]
+			// ```
+			//
+			// @return [Array]
+			Name: "instance_variables",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					names := []Object{}
+					ivars := receiver.instanceVariables()
+
+					if ivars == nil {
+						return t.vm.initArrayObject(names)
+					}
+
+					for _, name := range ivars.names() {
+						names = append(names, t.vm.initSymbolObject(name))
+					}
+
+					return t.vm.initArrayObject(names)
+				}
+			},
+		},
 		{
 			Name: "methods",
 			Fn: func(receiver Object) builtinMethodBody {
@@ -854,7 +1352,11 @@ func builtinClassCommonInstanceMethods() []*BuiltinMethodObject {
 						for _, name := range klass.Methods.names() {
 							if set[name] == nil {
 								set[name] = true
-								methods = append(methods, t.vm.initStringObject(name))
+								method, _ := klass.Methods.get(name)
+
+								if methodVisibility(method) != privateMethod {
+									methods = append(methods, t.vm.initStringObject(name))
+								}
 							}
 						}
 					}
@@ -862,6 +1364,82 @@ func builtinClassCommonInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Returns true if the object has the public method, or its `respond_to_missing?` returns true for the method.
+			// Private and protected methods are included only if include_all is true.
+			//
+			// ```ruby
+			// 1.respond_to?(:+)   # => true
+			// 1.respond_to?(:foo) # => false
+			//
+			// class Proxy
+			//   def method_missing(name, *args)
+			//     name.to_s
+			//   end
+			//
+			//   def respond_to_missing?(name, include_all)
+			//     name == :get_name
+			//   end
+			// end
+			//
+			// Proxy.new.respond_to?(:get_name) # => true
+			// ```
+			//
+			// @param name [String/Symbol], include_all [Boolean]
+			// @return [Boolean]
+			Name: "respond_to?",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) < 1 || len(args) > 2 {
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect 1 or 2 arguments. got: %d", len(args))
+					}
+
+					name, ok := toName(args[0])
+					if !ok {
+						return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.StringClass, args[0].Class().Name)
+					}
+
+					var includeAll Object = FALSE
+
+					if len(args) == 2 {
+						includeAll = args[1]
+					}
+
+					if method := receiver.findMethod(name); method != nil {
+						if methodVisibility(method) == publicMethod || isTruthy(includeAll) {
+							return TRUE
+						}
+
+						return FALSE
+					}
+
+					result := t.callMethodByName(receiver, "respond_to_missing?", t.vm.initSymbolObject(name), includeAll)
+
+					if err, ok := result.(*Error); ok {
+						return err
+					}
+
+					return toBooleanObject(isTruthy(result))
+				}
+			},
+		},
+		{
+			// Returns false by default. Override it along with `method_missing`,
+			// so `respond_to?` returns true for the methods handled by `method_missing`.
+			//
+			// ```ruby
+			// 1.respond_to_missing?(:foo, false) # => false
+			// ```
+			//
+			// @param name [Symbol], include_all [Boolean]
+			// @return [Boolean]
+			Name: "respond_to_missing?",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return FALSE
+				}
+			},
+		},
 	}
 }
 
@@ -978,6 +1556,7 @@ func (c *RClass) Value() interface{} {
 }
 
 func (c *RClass) inherits(sc *RClass) {
+	bumpMethodState()
 	c.superClass = sc
 	c.pseudoSuperClass = sc
 	c.singletonClass.superClass = sc.singletonClass
@@ -986,16 +1565,22 @@ func (c *RClass) inherits(sc *RClass) {
 
 func (c *RClass) setBuiltinMethods(methodList []*BuiltinMethodObject, classMethods bool) {
 	for _, m := range methodList {
-		c.Methods.set(m.Name, m)
+		c.setMethod(m.Name, m)
 	}
 
 	if classMethods {
 		for _, m := range methodList {
-			c.singletonClass.Methods.set(m.Name, m)
+			c.singletonClass.setMethod(m.Name, m)
 		}
 	}
 }
 
+// setMethod defines the method in the class, and invalidates the inline method caches
+func (c *RClass) setMethod(name string, method Object) {
+	c.Methods.set(name, method)
+	bumpMethodState()
+}
+
 func (c *RClass) findMethod(methodName string) (method Object) {
 	if c.isSingleton {
 		method = c.superClass.lookupMethod(methodName)
@@ -1058,7 +1643,7 @@ func (c *RClass) getClassConstant(constName string) (class *RClass) {
 }
 
 func (c *RClass) alreadyInherit(constant *RClass) bool {
-	if c.superClass == constant {
+	if c.superClass == constant || c.superClass.includedModule == constant {
 		return true
 	}
 
@@ -1079,42 +1664,47 @@ func (c *RClass) initializeInstance() *RObject {
 	return instance
 }
 
-func (c *RClass) setAttrWriter(args interface{}) {
+func (c *RClass) setAttrWriter(args interface{}, visibility int) {
+	for _, attrName := range attrNames(args) {
+		method := generateAttrWriteMethod(attrName)
+		method.visibility = visibility
+		c.setMethod(attrName+"=", method)
+	}
+}
 
-	switch args := args.(type) {
-	case []Object:
-		for _, attr := range args {
-			attrName := attr.(*StringObject).value
-			c.Methods.set(attrName+"=", generateAttrWriteMethod(attrName))
-		}
-	case []string:
-		for _, attrName := range args {
-			c.Methods.set(attrName+"=", generateAttrWriteMethod(attrName))
-		}
+func (c *RClass) setAttrReader(args interface{}, visibility int) {
+	for _, attrName := range attrNames(args) {
+		method := generateAttrReadMethod(attrName)
+		method.visibility = visibility
+		c.setMethod(attrName, method)
 	}
+}
 
+func (c *RClass) setAttrAccessor(args interface{}, visibility int) {
+	c.setAttrReader(args, visibility)
+	c.setAttrWriter(args, visibility)
 }
 
-func (c *RClass) setAttrReader(args interface{}) {
+// attrNames returns the attribute names given to `attr_*` methods, the arguments which aren't names are skipped
+func attrNames(args interface{}) []string {
 	switch args := args.(type) {
 	case []Object:
+		names := []string{}
+
 		for _, attr := range args {
-			attrName := attr.(*StringObject).value
-			c.Methods.set(attrName, generateAttrReadMethod(attrName))
+			if attrName, ok := toName(attr); ok {
+				names = append(names, attrName)
+			}
 		}
+
+		return names
 	case []string:
-		for _, attrName := range args {
-			c.Methods.set(attrName, generateAttrReadMethod(attrName))
-		}
+		return args
 	case string:
-		c.Methods.set(args, generateAttrReadMethod(args))
+		return []string{args}
 	}
 
-}
-
-func (c *RClass) setAttrAccessor(args interface{}) {
-	c.setAttrReader(args)
-	c.setAttrWriter(args)
+	return nil
 }
 
 func (c *RClass) ancestors() []*RClass {
@@ -1124,14 +1714,139 @@ func (c *RClass) ancestors() []*RClass {
 			break
 		}
 		c = c.superClass
+
+		if c.includedModule != nil {
+			klasses = append(klasses, c.includedModule)
+			continue
+		}
+
 		klasses = append(klasses, c)
 	}
 
 	return klasses
 }
 
+// superMethod returns the method overridden by the method with the instruction set, it's looked up from the superclass
+// of the class that defines the method in the receiver's method lookup chain. It returns nil if there isn't one.
+func superMethod(receiver Object, is *instructionSet) Object {
+	c := receiver.SingletonClass()
+
+	if c == nil {
+		c = receiver.Class()
+	}
+
+	for ; c != nil; c = c.superClass {
+		if m, ok := c.Methods.get(is.name); ok {
+			if m, ok := m.(*MethodObject); ok && m.instructionSet == is {
+				if c.superClass == nil || c.superClass == c {
+					return nil
+				}
+
+				return c.superClass.lookupMethod(is.name)
+			}
+		}
+
+		if c.superClass == c {
+			break
+		}
+	}
+
+	return nil
+}
+
+// includeModule inserts the module, and the modules it includes, into the class's method lookup chain.
+func (c *RClass) includeModule(module *RClass) {
+	modules := []*RClass{module}
+
+	for m := module.superClass; m != nil && m.includedModule != nil; m = m.superClass {
+		modules = append(modules, m.includedModule)
+	}
+
+	for i := len(modules) - 1; i >= 0; i-- {
+		if c.alreadyInherit(modules[i]) {
+			continue
+		}
+
+		c.superClass = modules[i].newIncludeProxy(c.superClass)
+	}
+
+	bumpMethodState()
+}
+
+// newIncludeProxy returns a class which shares the module's methods and constants but has its own superclass
+func (c *RClass) newIncludeProxy(superClass *RClass) *RClass {
+	return &RClass{
+		Name:             c.Name,
+		Methods:          c.Methods,
+		pseudoSuperClass: c.pseudoSuperClass,
+		superClass:       superClass,
+		isModule:         true,
+		constants:        c.constants,
+		scope:            c.scope,
+		includedModule:   c,
+		baseObj:          c.baseObj,
+	}
+}
+
 // Other helper functions -----------------------------------------------
 
+// setMethodVisibility sets the visibility of the named methods. Inherited methods are copied into the class,
+// so the superclass isn't affected. Without names, it sets the visibility of the methods defined later in the current frame.
+func (t *thread) setMethodVisibility(class *RClass, args []Object, visibility int) Object {
+	if len(args) == 0 {
+		t.callFrameStack.top().visibility = visibility
+		return NULL
+	}
+
+	for _, arg := range args {
+		name, ok := toName(arg)
+		if !ok {
+			return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.StringClass, arg.Class().Name)
+		}
+
+		method := class.lookupMethod(name)
+		if method == nil {
+			return t.vm.initErrorObject(errors.NameError, "Undefined method '%s' for class '%s'", name, class.Name)
+		}
+
+		class.setMethod(name, withVisibility(method, visibility))
+	}
+
+	if len(args) == 1 {
+		return args[0]
+	}
+
+	return t.vm.initArrayObject(args)
+}
+
+// generateDefinedMethod returns a method which calls the block with the receiver as `self`, it's used by `define_method`
+func generateDefinedMethod(name string, blockFrame *callFrame) *BuiltinMethodObject {
+	return &BuiltinMethodObject{
+		Name: name,
+		Fn: func(receiver Object) builtinMethodBody {
+			return func(t *thread, args []Object, _ *callFrame) Object {
+				params := blockFrame.instructionSet.paramTypes
+				argc := 0
+
+				if params != nil {
+					argc = len(params.Types())
+				}
+
+				if len(args) != argc {
+					return t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, argc, len(args))
+				}
+
+				c := newCallFrame(blockFrame.instructionSet)
+				c.isBlock = true
+				c.ep = blockFrame.ep
+				c.self = receiver
+
+				return t.builtinMethodYield(c, args...).Target
+			}
+		},
+	}
+}
+
 func generateAttrWriteMethod(attrName string) *BuiltinMethodObject {
 	return &BuiltinMethodObject{
 		Name: attrName + "=",
@@ -1,6 +1,15 @@
 package vm
 
-import "testing"
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/goby-lang/goby/compiler"
+)
 
 func TestClassClassSuperclass(t *testing.T) {
 	tests := []struct {
@@ -328,6 +337,620 @@ func TestSendMethod(t *testing.T) {
 	}
 }
 
+func TestSuperMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		class Foo
+		  def initialize(x)
+		    @x = x
+		  end
+
+		  def x
+		    @x
+		  end
+		end
+
+		class Bar < Foo
+		  def initialize(x)
+		    super(x * 2)
+		  end
+		end
+
+		Bar.new(5).x
+		`, 10},
+		{`
+		class Foo
+		  def bar(x)
+		    "Foo" + x
+		  end
+		end
+
+		class Baz < Foo; end
+
+		class Qux < Baz
+		  def bar(x)
+		    super(x) + "Qux"
+		  end
+		end
+
+		Qux.new.bar("-")
+		`, "Foo-Qux"},
+		{`
+		module Greet
+		  def hi
+		    "hi"
+		  end
+		end
+
+		class Foo
+		  include Greet
+
+		  def hi
+		    [1].map do |i|
+		      super + i.to_s
+		    end.first
+		  end
+		end
+
+		Foo.new.hi
+		`, "hi1"},
+		{`
+		class Foo
+		  def each_twice
+		    yield(1)
+		    yield(2)
+		  end
+		end
+
+		class Bar < Foo
+		  def each_twice
+		    super
+		  end
+		end
+
+		sum = 0
+		Bar.new.each_twice do |i|
+		  sum += i
+		end
+		sum
+		`, 3},
+		{`
+		class Foo
+		  def self.create
+		    "Foo"
+		  end
+		end
+
+		class Bar < Foo
+		  def self.create
+		    super + "Bar"
+		  end
+		end
+
+		Bar.create
+		`, "FooBar"},
+		{`
+		class Foo
+		  def to_s
+		    "<" + super + ">"
+		  end
+		end
+
+		Foo.new.to_s
+		`, "<<Instance of: Foo>>"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestSuperMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`super`, "InternalError: super called outside of method", 1},
+		{`class Foo
+		  def bar
+		    super
+		  end
+		end
+		begin
+		  Foo.new.bar
+		rescue UndefinedMethodError => e
+		  raise(e)
+		end`, "UndefinedMethodError: Undefined super method 'bar' for <Instance of: Foo>", 3},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestMethodMissing(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		class Builder
+		  def method_missing(name, *args)
+		    name.to_s + args.to_s
+		  end
+		end
+
+		Builder.new.hello(1, 2)
+		`, "hello[1, 2]"},
+		{`
+		class Builder
+		  def method_missing(name)
+		    name.to_s
+		  end
+		end
+
+		Builder.new.send(:hello)
+		`, "hello"},
+		{`
+		class Builder
+		  def method_missing(name, *args)
+		    yield(name)
+		  end
+		end
+
+		Builder.new.hello do |name|
+		  name.to_s + "!"
+		end
+		`, "hello!"},
+		{`
+		class Proxy
+		  def initialize(target)
+		    @target = target
+		  end
+
+		  def method_missing(name, *args)
+		    @target.send(name, *args)
+		  end
+		end
+
+		Proxy.new([3, 1, 2]).sort.first
+		`, 1},
+		{`
+		class Foo
+		  def bar
+		    10
+		  end
+
+		  def method_missing(name)
+		    0
+		  end
+		end
+
+		Foo.new.bar
+		`, 10},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestRespondToMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`1.respond_to?(:+)`, true},
+		{`1.respond_to?("foo")`, false},
+		{`
+		class Foo
+		  def bar; end
+		end
+
+		Foo.new.respond_to?(:bar)
+		`, true},
+		{`
+		class Foo
+		  def respond_to_missing?(name, include_all)
+		    name == :find_user
+		  end
+		end
+
+		Foo.new.respond_to?(:find_user)
+		`, true},
+		{`
+		class Foo
+		  def respond_to_missing?(name, include_all)
+		    name == :find_user
+		  end
+		end
+
+		Foo.new.respond_to?(:delete_user)
+		`, false},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestDefineMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		class Foo
+		  ["bar", "baz"].each do |name|
+		    define_method(name) do |x|
+		      name + x.to_s
+		    end
+		  end
+		end
+
+		Foo.new.bar(1) + Foo.new.baz(2)
+		`, "bar1baz2"},
+		{`
+		class Foo
+		  define_method(:me) do
+		    self.class.name
+		  end
+		end
+
+		Foo.new.me
+		`, "Foo"},
+		{`
+		class Foo; end
+		Foo.define_method(:double, ->(x) { x * 2 })
+		Foo.new.double(3)
+		`, 6},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestDefineMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`class Foo; end
+		Foo.define_method(:bar)`, "ArgumentError: Can't define method without a block", 2},
+		{`class Foo; end
+		Foo.define_method(1) do; end`, "TypeError: Expect argument to be String. got: Integer", 2},
+		{`class Foo
+		  define_method(:bar) do |x|; x; end
+		end
+		Foo.new.bar`, "ArgumentError: Expect 1 arguments. got: 0", 4},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestInstanceVariablesAndPublicSend(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		class Foo
+		  def initialize
+		    @a = 1
+		    @b = 2
+		  end
+		end
+
+		Foo.new.instance_variables.to_s
+		`, "[:@a, :@b]"},
+		{`Object.new.instance_variables.length`, 0},
+		{`1.public_send(:+, 2)`, 3},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestMethodVisibility(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		class Foo
+		  def bar
+		    secret + self.secret
+		  end
+
+		  private
+
+		  def secret
+		    21
+		  end
+		end
+
+		Foo.new.bar
+		`, 42},
+		{`
+		class Foo
+		  def bar
+		    [1, 2].map do |x|
+		      secret + x
+		    end
+		  end
+
+		  private
+
+		  def secret
+		    10
+		  end
+		end
+
+		Foo.new.bar.to_s
+		`, "[11, 12]"},
+		{`
+		class Foo
+		  private
+
+		  def secret
+		    10
+		  end
+
+		  public
+
+		  def bar
+		    secret
+		  end
+		end
+
+		Foo.new.bar
+		`, 10},
+		{`
+		class Foo
+		  def secret
+		    10
+		  end
+
+		  private :secret
+		end
+
+		Foo.new.send(:secret)
+		`, 10},
+		{`
+		class Account
+		  def initialize(balance)
+		    @balance = balance
+		  end
+
+		  def richer_than?(other)
+		    balance > other.balance
+		  end
+
+		  protected
+
+		  def balance
+		    @balance
+		  end
+		end
+
+		Account.new(10).richer_than?(Account.new(5))
+		`, true},
+		{`
+		class Foo
+		  private
+
+		  def secret
+		    10
+		  end
+		end
+
+		class Bar < Foo
+		  public :secret
+		end
+
+		Bar.new.secret
+		`, 10},
+		{`
+		class Foo
+		  private
+
+		  def secret; end
+		end
+
+		Foo.new.respond_to?(:secret)
+		`, false},
+		{`
+		class Foo
+		  private
+
+		  def secret; end
+		end
+
+		Foo.new.respond_to?(:secret, true)
+		`, true},
+		{`
+		class Foo
+		  private
+
+		  define_method(:secret) do
+		    10
+		  end
+		end
+
+		Foo.new.methods.include?("secret")
+		`, false},
+		{`
+		class Foo
+		  private
+
+		  def secret; end
+		end
+
+		class Foo
+		  def bar
+		    10
+		  end
+		end
+
+		Foo.new.bar
+		`, 10},
+		{`
+		class Foo
+		  def initialize(x)
+		    self.x = x
+		  end
+
+		  def double
+		    x * 2
+		  end
+
+		  private
+
+		  attr_accessor :x
+		end
+
+		Foo.new(5).double
+		`, 10},
+		{`
+		class Money
+		  attr_reader :cents
+
+		  protected :cents
+
+		  def initialize(cents)
+		    @cents = cents
+		  end
+
+		  def ==(other)
+		    cents == other.cents
+		  end
+		end
+
+		Money.new(1) == Money.new(1)
+		`, true},
+		{`
+		class Money
+		  def initialize(cents)
+		    @cents = cents
+		  end
+
+		  def ==(other)
+		    cents == other.cents
+		  end
+
+		  protected
+
+		  attr_reader :cents
+		end
+
+		Money.new(1) == Money.new(2)
+		`, false},
+		{`
+		class Foo
+		  private
+
+		  attr_writer :x
+
+		  public
+
+		  attr_reader :x
+
+		  def set(x)
+		    self.x = x
+		  end
+		end
+
+		f = Foo.new
+		f.set(3)
+		f.x
+		`, 3},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestMethodVisibilityFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`class Foo
+		  private
+		  def secret; end
+		end
+		Foo.new.secret`, "UndefinedMethodError: Private method 'secret' called for <Instance of: Foo>", 5},
+		{`class Foo
+		  def secret; end
+		  private :secret
+		end
+		Foo.new.public_send(:secret)`, "UndefinedMethodError: Private method 'secret' called for <Instance of: Foo>", 5},
+		{`class Foo
+		  protected
+		  def secret; end
+		end
+		Foo.new.secret`, "UndefinedMethodError: Protected method 'secret' called for <Instance of: Foo>", 5},
+		{`class Foo
+		  private
+		  attr_reader :secret
+		end
+		Foo.new.secret`, "UndefinedMethodError: Private method 'secret' called for <Instance of: Foo>", 5},
+		{`class Foo
+		  private
+		  attr_accessor :secret
+		end
+		Foo.new.secret = 1`, "UndefinedMethodError: Private method 'secret=' called for <Instance of: Foo>", 5},
+		{`class Foo
+		  protected
+		  attr_writer :secret
+		end
+		Foo.new.secret = 1`, "UndefinedMethodError: Protected method 'secret=' called for <Instance of: Foo>", 5},
+		{`class Foo; end
+		Foo.private(:bar)`, "NameError: Undefined method 'bar' for class 'Foo'", 2},
+		{`class Foo; end
+		Foo.private(1)`, "TypeError: Expect argument to be String. got: Integer", 2},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestBuiltinClassMonkeyPatching(t *testing.T) {
 	input := `
 	class String
@@ -517,6 +1140,17 @@ func TestClassNamespace(t *testing.T) {
 		end
 
 		Foo::Baz.result`, 10},
+		{`
+		module Foo
+		  Bar = 10
+
+		  def self.double(n)
+		    n * 2
+		  end
+		end
+
+		Foo.double(Foo::Bar)
+		`, 20},
 	}
 
 	for i, tt := range tests {
@@ -614,6 +1248,71 @@ func TestRequireRelative(t *testing.T) {
 	v.checkSP(t, 0, 1)
 }
 
+func TestRequireRelativeBytecodeCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goby")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	lib := filepath.Join(dir, "lib")
+	libSource := `
+	class Lib
+	  def self.value
+	    %d
+	  end
+	end
+	`
+	ioutil.WriteFile(lib+".gb", []byte(fmt.Sprintf(libSource, 10)), 0644)
+
+	v := initTestVM()
+	v.cacheDir = filepath.Join(dir, "cache")
+	checkLoaded := func(expected int) {
+		t.Helper()
+		sets, err := v.loadRequiredFile(lib)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		v.ExecInstructions(sets, lib)
+		checkExpected(t, 0, v.testEval(t, "Lib.value", getFilename()), expected)
+	}
+
+	checkLoaded(10)
+
+	if _, err := os.Stat(v.bytecodeCachePath(lib + ".gb")); err != nil {
+		t.Fatalf("Expect bytecode cache to be written. got: %s", err.Error())
+	}
+
+	if _, err := os.Stat(lib + ".gbc"); err == nil {
+		t.Fatal("Expect bytecode cache not to be written next to the source")
+	}
+
+	// The cache is used when the source isn't modified
+	info, _ := os.Stat(lib + ".gb")
+	ioutil.WriteFile(lib+".gb", []byte(fmt.Sprintf(libSource, 20)), 0644)
+	os.Chtimes(lib+".gb", info.ModTime(), info.ModTime())
+	checkLoaded(10)
+
+	// The cache is recompiled when the source is modified
+	modTime := info.ModTime().Add(time.Second)
+	os.Chtimes(lib+".gb", modTime, modTime)
+	checkLoaded(20)
+
+	// Failing to write the cache is ignored
+	v.cacheDir = filepath.Join(lib+".gb", "cache")
+	ioutil.WriteFile(lib+".gb", []byte(fmt.Sprintf(libSource, 30)), 0644)
+	checkLoaded(30)
+
+	// The bytecode compiled by `goby -c` is loaded without source
+	data, err := compiler.CompileToBinary(fmt.Sprintf(libSource, 40), 0)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	ioutil.WriteFile(lib+".gbc", data, 0644)
+	os.Remove(lib + ".gb")
+	checkLoaded(40)
+}
+
 func TestRequireStandardLibSuccess(t *testing.T) {
 	input := `
 	require "uri"
@@ -721,6 +1420,36 @@ func TestClassGeneralComparisonOperation(t *testing.T) {
 	}
 }
 
+func TestGeneralHashAndEqlMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`1.eql?(1)`, true},
+		{`1.eql?(1.0)`, false},
+		{`[1, "a"].eql?([1, "a"])`, true},
+		{`[1, "a"].eql?(["a", 1])`, false},
+		{`{ a: 1, b: 2 }.eql?({ b: 2, a: 1 })`, true},
+		{`Object.new.eql?(Object.new)`, false},
+		{`"foo".hash == "foo".hash`, true},
+		{`[1, [2, "3"]].hash == [1, [2, "3"]].hash`, true},
+		{`{ a: 1, b: 2 }.hash == { b: 2, a: 1 }.hash`, true},
+		{`Object.new.hash == Object.new.hash`, false},
+		{`
+		o = Object.new
+		o.hash == o.hash
+		`, true},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestGeneralAssignmentByOperation(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -6,15 +6,34 @@ const (
 	IntegerClass  = "Integer"
 	FloatClass    = "Float"
 	StringClass   = "String"
+	SymbolClass   = "Symbol"
 	ArrayClass    = "Array"
 	HashClass     = "Hash"
 	BooleanClass  = "Boolean"
 	NullClass     = "Null"
 	ChannelClass  = "Channel"
 	RangeClass    = "Range"
+	ProcClass     = "Proc"
 	MethodClass   = "method"
 	PluginClass   = "Plugin"
 	GoObjectClass = "GoObject"
 	FileClass     = "File"
+	DirClass      = "Dir"
 	GoMapClass    = "GoMap"
+
+	RegexpClass    = "Regexp"
+	MatchDataClass = "MatchData"
+
+	ThreadClass = "Thread"
+
+	TimeClass     = "Time"
+	DurationClass = "Duration"
+
+	RandomClass = "Random"
+
+	MathModule       = "Math"
+	ProcessModule    = "Process"
+	SyncModule       = "Sync"
+	ComparableModule = "Comparable"
+	EnumerableModule = "Enumerable"
 )
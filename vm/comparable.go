@@ -0,0 +1,236 @@
+package vm
+
+import (
+	"github.com/goby-lang/goby/vm/classes"
+	"github.com/goby-lang/goby/vm/errors"
+)
+
+// Comparable is a module that provides comparison methods to the classes that include it.
+// The class only needs to define `<=>`, which returns a negative Integer, 0 or a positive Integer
+// when the receiver is less than, equal to or greater than the argument.
+// Integer, Float, String, Symbol and Duration include Comparable as well.
+//
+// ```ruby
+// class Version
+//   include Comparable
+//
+//   attr_reader :number
+//
+//   def initialize(number)
+//     @number = number
+//   end
+//
+//   def <=>(other)
+//     @number <=> other.number
+//   end
+// end
+//
+// Version.new(1) < Version.new(2)                          # => true
+// Version.new(2).between?(Version.new(1), Version.new(3))  # => true
+// [Version.new(3), Version.new(1)].sort.first.number       # => 1
+// ```
+//
+
+// Instance methods -----------------------------------------------------
+func builtinComparableInstanceMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			// Returns true if the receiver is less than the argument.
+			//
+			// ```ruby
+			// Version.new(1) < Version.new(2) # => true
+			// ```
+			//
+			// @param object [Object]
+			// @return [Boolean]
+			Name: "<",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.comparableCompare(receiver, args, func(r int) bool { return r < 0 })
+				}
+			},
+		},
+		{
+			// Returns true if the receiver is less than or equal to the argument.
+			//
+			// ```ruby
+			// Version.new(1) <= Version.new(1) # => true
+			// ```
+			//
+			// @param object [Object]
+			// @return [Boolean]
+			Name: "<=",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.comparableCompare(receiver, args, func(r int) bool { return r <= 0 })
+				}
+			},
+		},
+		{
+			// Returns true if `<=>` returns 0. Returns false if `<=>` doesn't return an Integer.
+			//
+			// ```ruby
+			// Version.new(1) == Version.new(1) # => true
+			// Version.new(1) == Version.new(2) # => false
+			// ```
+			//
+			// @param object [Object]
+			// @return [Boolean]
+			Name: "==",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					if receiver == args[0] {
+						return TRUE
+					}
+
+					r, err := t.compareObjects(receiver, args[0])
+
+					return toBooleanObject(err == nil && r == 0)
+				}
+			},
+		},
+		{
+			// Returns true if the receiver is greater than the argument.
+			//
+			// ```ruby
+			// Version.new(2) > Version.new(1) # => true
+			// ```
+			//
+			// @param object [Object]
+			// @return [Boolean]
+			Name: ">",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.comparableCompare(receiver, args, func(r int) bool { return r > 0 })
+				}
+			},
+		},
+		{
+			// Returns true if the receiver is greater than or equal to the argument.
+			//
+			// ```ruby
+			// Version.new(2) >= Version.new(2) # => true
+			// ```
+			//
+			// @param object [Object]
+			// @return [Boolean]
+			Name: ">=",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.comparableCompare(receiver, args, func(r int) bool { return r >= 0 })
+				}
+			},
+		},
+		{
+			// Returns true if the receiver is between min and max, inclusive.
+			//
+			// ```ruby
+			// 3.between?(1, 5)       # => true
+			// "b".between?("c", "d") # => false
+			// ```
+			//
+			// @param min [Object], max [Object]
+			// @return [Boolean]
+			Name: "between?",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 2 {
+						return t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, 2, len(args))
+					}
+
+					min, err := t.compareObjects(receiver, args[0])
+					if err != nil {
+						return err
+					}
+
+					max, err := t.compareObjects(receiver, args[1])
+					if err != nil {
+						return err
+					}
+
+					return toBooleanObject(min >= 0 && max <= 0)
+				}
+			},
+		},
+		{
+			// Returns min if the receiver is less than min, max if the receiver is greater than max,
+			// and the receiver itself otherwise.
+			//
+			// ```ruby
+			// 10.clamp(1, 5) # => 5
+			// 0.clamp(1, 5)  # => 1
+			// 3.clamp(1, 5)  # => 3
+			// ```
+			//
+			// @param min [Object], max [Object]
+			// @return [Object]
+			Name: "clamp",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 2 {
+						return t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, 2, len(args))
+					}
+
+					r, err := t.compareObjects(args[0], args[1])
+					if err != nil {
+						return err
+					}
+
+					if r > 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, "min argument must be smaller than max argument")
+					}
+
+					r, err = t.compareObjects(receiver, args[0])
+					if err != nil {
+						return err
+					}
+
+					if r < 0 {
+						return args[0]
+					}
+
+					r, err = t.compareObjects(receiver, args[1])
+					if err != nil {
+						return err
+					}
+
+					if r > 0 {
+						return args[1]
+					}
+
+					return receiver
+				}
+			},
+		},
+	}
+}
+
+// Internal functions ===================================================
+
+// Functions for initialization -----------------------------------------
+
+func (vm *VM) initComparableModule() *RClass {
+	cm := vm.initializeClass(classes.ComparableModule, true)
+	cm.setBuiltinMethods(builtinComparableInstanceMethods(), false)
+	return cm
+}
+
+// Other helper functions ----------------------------------------------
+
+// comparableCompare compares the receiver with the only argument, and returns whether the result satisfies the condition
+func (t *thread) comparableCompare(receiver Object, args []Object, cond func(int) bool) Object {
+	if len(args) != 1 {
+		return t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, 1, len(args))
+	}
+
+	r, err := t.compareObjects(receiver, args[0])
+	if err != nil {
+		return err
+	}
+
+	return toBooleanObject(cond(r))
+}
@@ -0,0 +1,79 @@
+package vm
+
+import (
+	"testing"
+)
+
+func TestComparableMethods(t *testing.T) {
+	version := `
+	class Version
+	  include Comparable
+
+	  attr_reader :number
+
+	  def initialize(number)
+	    @number = number
+	  end
+
+	  def <=>(other)
+	    @number <=> other.number
+	  end
+	end
+	`
+
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{version + `Version.new(1) < Version.new(2)`, true},
+		{version + `Version.new(2) <= Version.new(1)`, false},
+		{version + `Version.new(2) > Version.new(1)`, true},
+		{version + `Version.new(2) >= Version.new(2)`, true},
+		{version + `Version.new(2) == Version.new(2)`, true},
+		{version + `Version.new(2) == Version.new(3)`, false},
+		{version + `Version.new(2).between?(Version.new(1), Version.new(3))`, true},
+		{version + `Version.new(5).clamp(Version.new(1), Version.new(3)).number`, 3},
+		{version + `[Version.new(3), Version.new(1), Version.new(2)].sort.first.number`, 1},
+		{version + `[Version.new(3), Version.new(1), Version.new(2)].max.number`, 3},
+		{version + `Version.new(1).is_a?(Comparable)`, true},
+		{`3.between?(1, 5)`, true},
+		{`"b".between?("c", "d")`, false},
+		{`1.5.between?(1, 2)`, true},
+		{`10.clamp(1, 5)`, 5},
+		{`0.clamp(1, 5)`, 1},
+		{`3.clamp(1, 5)`, 3},
+		{`"z".clamp("a", "c")`, "c"},
+		{`Integer.ancestors.to_s`, "[Integer, Comparable, Object]"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestComparableMethodsFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`1.between?(1)`, "ArgumentError: Expect 2 arguments. got: 1", 1},
+		{`1.clamp(5, 1)`, "ArgumentError: min argument must be smaller than max argument", 1},
+		{`1.between?("a", "b")`, "ArgumentError: Comparison of Integer with String failed", 1},
+		{`
+		class Foo
+		  include Comparable
+		end
+
+		Foo.new < Foo.new
+		`, "ArgumentError: Comparison of Foo with Foo failed", 6},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
@@ -211,15 +211,15 @@ func builtinDBInstanceMethods() []*BuiltinMethodObject {
 			// puts id # => 2
 			//
 			// results = db.query("SELECT * FROM users WHERE id = $1", id)
-			// results.size          # => 1
-			// results.first[:name]  # => 'Stan'
-			// results.first[:age]   # => 23
+			// results.size           # => 1
+			// results.first["name"]  # => 'Stan'
+			// results.first["age"]   # => 23
 			//
 			// age = 21
 			// results2 = db.query("SELECT * FROM users WHERE age = $1", age)
-			// results2.size         # => 1
-			// results2.first[:name] # => 'Maxwell'
-			// results2.first[:age]  # => 21
+			// results2.size          # => 1
+			// results2.first["name"] # => 'Maxwell'
+			// results2.first["age"]  # => 21
 			//
 			// ```
 			//
@@ -101,7 +101,7 @@ func TestDBRun(t *testing.T) {
 
 	db.run("drop table test_items")
 
-	results.first[:exists]
+	results.first["exists"]
 	`
 
 	v := initTestVM()
@@ -125,7 +125,7 @@ func TestDBExec(t *testing.T) {
 			db = DB.open("postgres", "user=postgres dbname=goby_test sslmode=disable")
 			id = db.exec("INSERT INTO users (name, age) VALUES ('Stan', 23)")
 			results = db.query("SELECT * FROM users WHERE id = $1", id)
-			results.first[:name]
+			results.first["name"]
 			`,
 			"Stan"},
 		// Insert and delete
@@ -136,7 +136,7 @@ func TestDBExec(t *testing.T) {
 			id = db.exec("INSERT INTO users (name, age) VALUES ('Stan', 23)")
 			db.exec("DELETE FROM users WHERE id = $1", id)
 			results = db.query("SELECT EXISTS(SELECT * FROM users WHERE id = $1)", id)
-			results.first[:exists]
+			results.first["exists"]
 			`,
 			false},
 		// Insert and update and query
@@ -148,7 +148,7 @@ func TestDBExec(t *testing.T) {
 			id2 = db.exec("UPDATE users SET age=10 WHERE id = $1", id)
 			# See if update returns usable id, too
 			results = db.query("SELECT * FROM users WHERE id = $1", id2)
-			results.first[:age]
+			results.first["age"]
 			`,
 			10},
 	}
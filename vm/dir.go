@@ -0,0 +1,470 @@
+package vm
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/goby-lang/goby/vm/classes"
+	"github.com/goby-lang/goby/vm/errors"
+)
+
+// Dir provides class methods for listing, walking, creating and removing directories.
+// Relative paths are resolved from the current working directory, which can be changed by `Dir.chdir`.
+//
+// ```ruby
+// Dir.mkdir_p("build/assets")
+// Dir.glob("src/**/*.gb")     # => ["src/main.gb", "src/lib/util.gb"]
+// Dir.entries("build")        # => ["assets"]
+//
+// Dir.walk("src") do |path, stat|
+//   puts(path)
+// end
+//
+// Dir.rm_rf("build")
+// ```
+//
+// - `Dir.new` is not supported.
+
+// Class methods --------------------------------------------------------
+func builtinDirClassMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			// Changes the current working directory of the process.
+			// If a block is given, the directory is changed only while the block runs, and the block's value is returned.
+			//
+			// **Note:** the working directory is shared by all threads.
+			//
+			// ```ruby
+			// Dir.chdir("/tmp") # => 0
+			//
+			// Dir.chdir("/usr") do
+			//   Dir.pwd # => "/usr"
+			// end
+			// Dir.pwd # => "/tmp"
+			// ```
+			//
+			// @param path [String]
+			// @return [Object]
+			Name: "chdir",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					path, err := t.stringArg(args)
+
+					if err != nil {
+						return err
+					}
+
+					prev, e := os.Getwd()
+
+					if e != nil {
+						return t.vm.initErrorObject(errors.InternalError, "%s", e.Error())
+					}
+
+					if e := os.Chdir(path); e != nil {
+						return t.vm.initErrorObject(errors.InternalError, "%s", e.Error())
+					}
+
+					if blockFrame == nil {
+						return t.vm.initIntegerObject(0)
+					}
+
+					defer os.Chdir(prev)
+					return t.builtinMethodYield(blockFrame).Target
+				}
+			},
+		},
+		{
+			// Returns the names of the files and directories in the directory, which are sorted and don't include "." and "..".
+			//
+			// ```ruby
+			// Dir.entries("samples") # => ["alias.gb", "block.gb", ...]
+			// ```
+			//
+			// @param path [String]
+			// @return [Array]
+			Name: "entries",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					path, err := t.stringArg(args)
+
+					if err != nil {
+						return err
+					}
+
+					entries, e := ioutil.ReadDir(path)
+
+					if e != nil {
+						return t.vm.initErrorObject(errors.InternalError, "%s", e.Error())
+					}
+
+					names := []Object{}
+
+					for _, entry := range entries {
+						names = append(names, t.vm.initStringObject(entry.Name()))
+					}
+
+					return t.vm.initArrayObject(names)
+				}
+			},
+		},
+		{
+			// Returns true if the path is an existing directory.
+			//
+			// ```ruby
+			// Dir.exist?("/tmp")        # => true
+			// Dir.exist?("/etc/passwd") # => false
+			// ```
+			//
+			// @param path [String]
+			// @return [Boolean]
+			Name: "exist?",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					path, err := t.stringArg(args)
+
+					if err != nil {
+						return err
+					}
+
+					info, e := os.Stat(path)
+
+					return toBooleanObject(e == nil && info.IsDir())
+				}
+			},
+		},
+		{
+			// Returns the sorted paths which match the pattern.
+			// Besides the patterns of `*`, `?`, `[...]`, `**` matches any directories recursively, including none.
+			//
+			// ```ruby
+			// Dir.glob("samples/*.gb")   # => ["samples/alias.gb", ...]
+			// Dir.glob("vm/**/*.gb")     # => ["vm/lib/foo.gb", "vm/test.gb", ...]
+			// ```
+			//
+			// @param pattern [String]
+			// @return [Array]
+			Name: "glob",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					pattern, err := t.stringArg(args)
+
+					if err != nil {
+						return err
+					}
+
+					paths, e := glob(pattern)
+
+					if e != nil {
+						return t.vm.initErrorObject(errors.ArgumentError, "Invalid glob pattern: %s", pattern)
+					}
+
+					objs := []Object{}
+
+					for _, path := range paths {
+						objs = append(objs, t.vm.initStringObject(path))
+					}
+
+					return t.vm.initArrayObject(objs)
+				}
+			},
+		},
+		{
+			// Creates the directory and all its missing parents, like `mkdir -p`. Returns the path.
+			// It does nothing if the directory already exists.
+			//
+			// ```ruby
+			// Dir.mkdir_p("build/assets/images")       # => "build/assets/images"
+			// Dir.mkdir_p("build/assets/images", 0700) # => "build/assets/images"
+			// ```
+			//
+			// @param path [String], perm [Integer]
+			// @return [String]
+			Name: "mkdir_p",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					perm := 0755
+
+					if len(args) == 2 {
+						p, ok := args[1].(*IntegerObject)
+
+						if !ok {
+							return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.IntegerClass, args[1].Class().Name)
+						}
+
+						perm = p.value
+						args = args[:1]
+					}
+
+					path, err := t.stringArg(args)
+
+					if err != nil {
+						return err
+					}
+
+					if e := os.MkdirAll(path, os.FileMode(perm)); e != nil {
+						return t.vm.initErrorObject(errors.InternalError, "%s", e.Error())
+					}
+
+					return args[0]
+				}
+			},
+		},
+		{
+			Name: "new",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.unsupportedMethodError("#new", receiver)
+				}
+			},
+		},
+		{
+			// Returns the current working directory.
+			//
+			// ```ruby
+			// Dir.pwd # => "/home/goby"
+			// ```
+			//
+			// @return [String]
+			Name: "pwd",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, 0, len(args))
+					}
+
+					dir, err := os.Getwd()
+
+					if err != nil {
+						return t.vm.initErrorObject(errors.InternalError, "%s", err.Error())
+					}
+
+					return t.vm.initStringObject(dir)
+				}
+			},
+		},
+		{
+			// Removes the path and everything it contains, like `rm -rf`. Returns the path.
+			// It does nothing if the path doesn't exist.
+			//
+			// ```ruby
+			// Dir.rm_rf("build") # => "build"
+			// ```
+			//
+			// @param path [String]
+			// @return [String]
+			Name: "rm_rf",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					path, err := t.stringArg(args)
+
+					if err != nil {
+						return err
+					}
+
+					if e := os.RemoveAll(path); e != nil {
+						return t.vm.initErrorObject(errors.InternalError, "%s", e.Error())
+					}
+
+					return args[0]
+				}
+			},
+		},
+		{
+			// Yields every file and directory under the path, including the path itself, with its `File::Stat`.
+			// Paths are yielded in lexical order, and a directory is yielded before its contents.
+			//
+			// ```ruby
+			// Dir.walk("src") do |path, stat|
+			//   if stat.file?
+			//     puts(path) # => "src/main.gb", "src/lib/util.gb" ...
+			//   end
+			// end
+			// ```
+			//
+			// @param path [String]
+			// @return [Null]
+			Name: "walk",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					root, err := t.stringArg(args)
+
+					if err != nil {
+						return err
+					}
+
+					if blockFrame == nil {
+						return t.vm.initErrorObject(errors.InternalError, errors.CantYieldWithoutBlockFormat)
+					}
+
+					type walkedFile struct {
+						path string
+						info os.FileInfo
+					}
+
+					// Files are collected first, so the block can change the directory while walking it
+					files := []walkedFile{}
+					e := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+						if err != nil {
+							return err
+						}
+
+						files = append(files, walkedFile{path: path, info: info})
+						return nil
+					})
+
+					if e != nil {
+						return t.vm.initErrorObject(errors.InternalError, "%s", e.Error())
+					}
+
+					for _, f := range files {
+						result := t.builtinMethodYield(blockFrame, t.vm.initStringObject(f.path), t.vm.initFileStatObject(f.path, f.info))
+
+						if err, ok := result.Target.(*Error); ok && err.raised {
+							return err
+						}
+					}
+
+					return NULL
+				}
+			},
+		},
+	}
+}
+
+// Internal functions ===================================================
+
+// Functions for initialization -----------------------------------------
+
+func (vm *VM) initDirClass() *RClass {
+	dc := vm.initializeClass(classes.DirClass, false)
+	dc.setBuiltinMethods(builtinDirClassMethods(), true)
+	return dc
+}
+
+// Other helper functions ----------------------------------------------
+
+// stringArg returns the value of the only String argument
+func (t *thread) stringArg(args []Object) (string, *Error) {
+	if len(args) != 1 {
+		return "", t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, 1, len(args))
+	}
+
+	s, ok := args[0].(*StringObject)
+
+	if !ok {
+		return "", t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.StringClass, args[0].Class().Name)
+	}
+
+	return s.value, nil
+}
+
+// stringArgs returns the values of the given number of String arguments
+func (t *thread) stringArgs(args []Object, count int) ([]string, *Error) {
+	if len(args) != count {
+		return nil, t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, count, len(args))
+	}
+
+	values := []string{}
+
+	for _, arg := range args {
+		s, ok := arg.(*StringObject)
+
+		if !ok {
+			return nil, t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.StringClass, arg.Class().Name)
+		}
+
+		values = append(values, s.value)
+	}
+
+	return values, nil
+}
+
+// glob returns the sorted paths which match the pattern, `**` in the pattern matches zero or more directories
+func glob(pattern string) ([]string, error) {
+	if !strings.Contains(pattern, "**") {
+		return filepath.Glob(pattern)
+	}
+
+	segments := strings.Split(filepath.Clean(pattern), string(filepath.Separator))
+
+	// Only the directory before the first segment with a wildcard needs to be walked
+	base := []string{}
+
+	for _, segment := range segments {
+		if strings.ContainsAny(segment, `*?[\`) {
+			break
+		}
+
+		base = append(base, segment)
+	}
+
+	root := strings.Join(base, string(filepath.Separator))
+
+	switch {
+	case len(base) == 1 && base[0] == "":
+		root = string(filepath.Separator)
+	case len(base) == 0:
+		root = "."
+	}
+
+	paths := []string{}
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		// Unreadable directories are skipped like filepath.Glob does
+		if err != nil || path == "." {
+			return nil
+		}
+
+		matched, err := matchGlobSegments(segments, strings.Split(path, string(filepath.Separator)))
+
+		if err != nil {
+			return err
+		}
+
+		if matched {
+			paths = append(paths, path)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// matchGlobSegments matches a path's segments against a pattern's segments, where `**` matches zero or more segments
+func matchGlobSegments(pattern, path []string) (bool, error) {
+	if len(pattern) == 0 {
+		return len(path) == 0, nil
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(path); i++ {
+			matched, err := matchGlobSegments(pattern[1:], path[i:])
+
+			if err != nil || matched {
+				return matched, err
+			}
+		}
+
+		return false, nil
+	}
+
+	if len(path) == 0 {
+		return false, nil
+	}
+
+	matched, err := filepath.Match(pattern[0], path[0])
+
+	if err != nil || !matched {
+		return false, err
+	}
+
+	return matchGlobSegments(pattern[1:], path[1:])
+}
@@ -0,0 +1,106 @@
+package vm
+
+import (
+	"os"
+	"testing"
+)
+
+const dirTestSetup = `
+Dir.rm_rf("/tmp/goby_dir_test")
+Dir.mkdir_p("/tmp/goby_dir_test/a/b")
+File.open("/tmp/goby_dir_test/a/x.gb", "w", 0644) do |f|
+  f.write("hello")
+end
+File.open("/tmp/goby_dir_test/a/b/y.gb", "w", 0644) do |f|
+  f.write("hi")
+end
+File.open("/tmp/goby_dir_test/z.txt", "w", 0644) do |f|
+  f.write("hi")
+end
+`
+
+func TestDirMethods(t *testing.T) {
+	defer os.RemoveAll("/tmp/goby_dir_test")
+
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`Dir.entries("/tmp/goby_dir_test").to_s`, `["a", "z.txt"]`},
+		{`Dir.entries("/tmp/goby_dir_test/a/b").to_s`, `["y.gb"]`},
+		{`Dir.exist?("/tmp/goby_dir_test/a")`, true},
+		{`Dir.exist?("/tmp/goby_dir_test/z.txt")`, false},
+		{`Dir.exist?("/tmp/goby_dir_test/none")`, false},
+		{`Dir.glob("/tmp/goby_dir_test/*").to_s`, `["/tmp/goby_dir_test/a", "/tmp/goby_dir_test/z.txt"]`},
+		{`Dir.glob("/tmp/goby_dir_test/**/*.gb").to_s`, `["/tmp/goby_dir_test/a/b/y.gb", "/tmp/goby_dir_test/a/x.gb"]`},
+		{`Dir.glob("/tmp/goby_dir_test/**/b/*").to_s`, `["/tmp/goby_dir_test/a/b/y.gb"]`},
+		{`Dir.glob("/tmp/goby_dir_test/*.gb").to_s`, `[]`},
+		{`
+		Dir.chdir("/tmp/goby_dir_test") do
+		  Dir.glob("**/*.gb")
+		end.to_s
+		`, `["a/b/y.gb", "a/x.gb"]`},
+		{`
+		pwd = Dir.pwd
+		inner = Dir.chdir("/tmp/goby_dir_test/a") do
+		  Dir.pwd
+		end
+		inner + " " + (pwd == Dir.pwd).to_s
+		`, "/tmp/goby_dir_test/a true"},
+		{`Dir.mkdir_p("/tmp/goby_dir_test/a/b")`, "/tmp/goby_dir_test/a/b"},
+		{`
+		Dir.mkdir_p("/tmp/goby_dir_test/c/d", 0700)
+		File.stat("/tmp/goby_dir_test/c/d").mode == 0700
+		`, true},
+		{`
+		Dir.rm_rf("/tmp/goby_dir_test/a")
+		Dir.entries("/tmp/goby_dir_test").to_s
+		`, `["z.txt"]`},
+		{`Dir.rm_rf("/tmp/goby_dir_test/none")`, "/tmp/goby_dir_test/none"},
+		{`
+		paths = []
+		Dir.walk("/tmp/goby_dir_test") do |path, stat|
+		  paths.push(path + ":" + stat.directory?.to_s)
+		end
+		paths.to_s
+		`, `["/tmp/goby_dir_test:true", "/tmp/goby_dir_test/a:true", "/tmp/goby_dir_test/a/b:true", "/tmp/goby_dir_test/a/b/y.gb:false", "/tmp/goby_dir_test/a/x.gb:false", "/tmp/goby_dir_test/z.txt:false"]`},
+		{`
+		size = 0
+		Dir.walk("/tmp/goby_dir_test/a") do |path, stat|
+		  if stat.file?
+		    size += stat.size
+		  end
+		end
+		size
+		`, 7},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, dirTestSetup+tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestDirMethodsFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`Dir.new`, "UnsupportedMethodError: Unsupported Method #new for Dir", 1},
+		{`Dir.entries(1)`, "TypeError: Expect argument to be String. got: Integer", 1},
+		{`Dir.entries("/tmp/goby_dir_test_none")`, "InternalError: open /tmp/goby_dir_test_none: no such file or directory", 1},
+		{`Dir.chdir("/tmp/goby_dir_test_none")`, "InternalError: chdir /tmp/goby_dir_test_none: no such file or directory", 1},
+		{`Dir.glob("[")`, "ArgumentError: Invalid glob pattern: [", 1},
+		{`Dir.mkdir_p("/tmp", "a")`, "TypeError: Expect argument to be Integer. got: String", 1},
+		{`Dir.pwd(1)`, "ArgumentError: Expect 0 arguments. got: 1", 1},
+		{`Dir.walk("/tmp")`, "InternalError: Can't yield without a block", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
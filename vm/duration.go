@@ -0,0 +1,529 @@
+package vm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/goby-lang/goby/vm/classes"
+	"github.com/goby-lang/goby/vm/errors"
+)
+
+// DurationObject represents the elapsed time between two instants, which is backed by Go's `time.Duration`.
+// Subtracting a Time from another returns a Duration, and a Duration can be added to a Time.
+//
+// ```ruby
+// start = Time.now
+// # do some work
+// elapsed = Time.now - start
+// elapsed.seconds # => 0.001234
+//
+// d = Duration.parse("1h30m")
+// d.minutes # => 90.0
+// d.to_s    # => "1h30m0s"
+// ```
+//
+type DurationObject struct {
+	*baseObj
+	value time.Duration
+}
+
+// Class methods --------------------------------------------------------
+func builtinDurationClassMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			// Returns a Duration of the given hours.
+			//
+			// ```ruby
+			// Duration.hours(1.5).to_s # => "1h30m0s"
+			// ```
+			//
+			// @param hours [Numeric]
+			// @return [Duration]
+			Name: "hours",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.durationOf(args, time.Hour)
+				}
+			},
+		},
+		{
+			// Returns a Duration of the given milliseconds.
+			//
+			// ```ruby
+			// Duration.milliseconds(1500).to_s # => "1.5s"
+			// ```
+			//
+			// @param milliseconds [Numeric]
+			// @return [Duration]
+			Name: "milliseconds",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.durationOf(args, time.Millisecond)
+				}
+			},
+		},
+		{
+			// Returns a Duration of the given minutes.
+			//
+			// ```ruby
+			// Duration.minutes(5).to_s # => "5m0s"
+			// ```
+			//
+			// @param minutes [Numeric]
+			// @return [Duration]
+			Name: "minutes",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.durationOf(args, time.Minute)
+				}
+			},
+		},
+		{
+			// Returns a Duration of the given nanoseconds.
+			//
+			// ```ruby
+			// Duration.nanoseconds(1000).to_s # => "1µs"
+			// ```
+			//
+			// @param nanoseconds [Integer]
+			// @return [Duration]
+			Name: "nanoseconds",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.durationOf(args, time.Nanosecond)
+				}
+			},
+		},
+		{
+			// Returns a Duration of the given seconds.
+			//
+			// ```ruby
+			// Duration.seconds(90).to_s # => "1m30s"
+			// ```
+			//
+			// @param seconds [Numeric]
+			// @return [Duration]
+			Name: "seconds",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.durationOf(args, time.Second)
+				}
+			},
+		},
+		{
+			Name: "new",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.unsupportedMethodError("#new", receiver)
+				}
+			},
+		},
+		{
+			// Parses a duration string, which is a sequence of numbers with units, like "300ms", "1.5h" or "2h45m".
+			// Valid units are "ns", "us" (or "µs"), "ms", "s", "m" and "h".
+			//
+			// ```ruby
+			// Duration.parse("2h45m").minutes # => 165.0
+			// ```
+			//
+			// @param duration [String]
+			// @return [Duration]
+			Name: "parse",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					s, ok := args[0].(*StringObject)
+
+					if !ok {
+						return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.StringClass, args[0].Class().Name)
+					}
+
+					d, err := time.ParseDuration(s.value)
+
+					if err != nil {
+						return t.vm.initErrorObject(errors.ArgumentError, "Invalid duration: %s", s.value)
+					}
+
+					return t.vm.initDurationObject(d)
+				}
+			},
+		},
+	}
+}
+
+// Instance methods -----------------------------------------------------
+func builtinDurationInstanceMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			// Returns the Duration multiplied by the number.
+			//
+			// ```ruby
+			// (Duration.seconds(10) * 1.5).to_s # => "15s"
+			// ```
+			//
+			// @param number [Numeric]
+			// @return [Duration]
+			Name: "*",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					n, err := t.numericArg(args)
+
+					if err != nil {
+						return err
+					}
+
+					return t.vm.initDurationObject(time.Duration(float64(receiver.(*DurationObject).value) * n))
+				}
+			},
+		},
+		{
+			// Returns the sum of two Durations.
+			//
+			// ```ruby
+			// (Duration.minutes(1) + Duration.seconds(30)).to_s # => "1m30s"
+			// ```
+			//
+			// @param duration [Duration]
+			// @return [Duration]
+			Name: "+",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					d, err := t.durationArg(args)
+
+					if err != nil {
+						return err
+					}
+
+					return t.vm.initDurationObject(receiver.(*DurationObject).value + d)
+				}
+			},
+		},
+		{
+			// Returns the difference of two Durations.
+			//
+			// ```ruby
+			// (Duration.minutes(1) - Duration.seconds(30)).to_s # => "30s"
+			// ```
+			//
+			// @param duration [Duration]
+			// @return [Duration]
+			Name: "-",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					d, err := t.durationArg(args)
+
+					if err != nil {
+						return err
+					}
+
+					return t.vm.initDurationObject(receiver.(*DurationObject).value - d)
+				}
+			},
+		},
+		{
+			// Returns the Duration divided by the number.
+			//
+			// ```ruby
+			// (Duration.seconds(10) / 4).to_s # => "2.5s"
+			// ```
+			//
+			// @param number [Numeric]
+			// @return [Duration]
+			Name: "/",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					n, err := t.numericArg(args)
+
+					if err != nil {
+						return err
+					}
+
+					if n == 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, "Divided by 0")
+					}
+
+					return t.vm.initDurationObject(time.Duration(float64(receiver.(*DurationObject).value) / n))
+				}
+			},
+		},
+		{
+			// Compares two Durations, returns -1, 0 or 1 if the receiver is shorter than, equal to or longer than the argument.
+			// Returns nil if the argument isn't a Duration.
+			//
+			// ```ruby
+			// Duration.seconds(1) <=> Duration.seconds(2) # => -1
+			// ```
+			//
+			// @param duration [Duration]
+			// @return [Integer]
+			Name: "<=>",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					d, ok := args[0].(*DurationObject)
+
+					if !ok {
+						return NULL
+					}
+
+					return t.vm.initIntegerObject(compareInt64(int64(receiver.(*DurationObject).value), int64(d.value)))
+				}
+			},
+		},
+		{
+			// Returns true if the argument is a Duration of the same length.
+			//
+			// ```ruby
+			// Duration.minutes(1) == Duration.seconds(60) # => true
+			// ```
+			//
+			// @param object [Object]
+			// @return [Boolean]
+			Name: "==",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					d, ok := args[0].(*DurationObject)
+
+					return toBooleanObject(ok && receiver.(*DurationObject).value == d.value)
+				}
+			},
+		},
+		{
+			// Returns the Duration as floating point hours.
+			//
+			// ```ruby
+			// Duration.minutes(90).hours # => 1.5
+			// ```
+			//
+			// @return [Float]
+			Name: "hours",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initFloatObject(receiver.(*DurationObject).value.Hours())
+				}
+			},
+		},
+		{
+			// Returns the Duration as integer milliseconds.
+			//
+			// ```ruby
+			// Duration.seconds(1.5).milliseconds # => 1500
+			// ```
+			//
+			// @return [Integer]
+			Name: "milliseconds",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initIntegerObject(int(receiver.(*DurationObject).value / time.Millisecond))
+				}
+			},
+		},
+		{
+			// Returns the Duration as floating point minutes.
+			//
+			// ```ruby
+			// Duration.seconds(90).minutes # => 1.5
+			// ```
+			//
+			// @return [Float]
+			Name: "minutes",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initFloatObject(receiver.(*DurationObject).value.Minutes())
+				}
+			},
+		},
+		{
+			// Returns the Duration as integer nanoseconds.
+			//
+			// ```ruby
+			// Duration.milliseconds(1).nanoseconds # => 1000000
+			// ```
+			//
+			// @return [Integer]
+			Name: "nanoseconds",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initIntegerObject(int(receiver.(*DurationObject).value.Nanoseconds()))
+				}
+			},
+		},
+		{
+			// Returns the Duration as floating point seconds.
+			//
+			// ```ruby
+			// Duration.milliseconds(1500).seconds # => 1.5
+			// ```
+			//
+			// @return [Float]
+			Name: "seconds",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initFloatObject(receiver.(*DurationObject).value.Seconds())
+				}
+			},
+		},
+		{
+			// Returns the Duration as integer seconds, the fraction is truncated.
+			//
+			// ```ruby
+			// Duration.milliseconds(1500).to_i # => 1
+			// ```
+			//
+			// @return [Integer]
+			Name: "to_i",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initIntegerObject(int(receiver.(*DurationObject).value / time.Second))
+				}
+			},
+		},
+		{
+			// Returns the Duration as a JSON string.
+			//
+			// ```ruby
+			// Duration.seconds(90).to_json # => "\"1m30s\""
+			// { elapsed: Duration.seconds(90) }.to_json # => "{\"elapsed\":\"1m30s\"}"
+			// ```
+			//
+			// @return [String]
+			Name: "to_json",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initStringObject(receiver.toJSON())
+				}
+			},
+		},
+		{
+			// Returns the Duration in the format like "1h30m0s".
+			//
+			// ```ruby
+			// Duration.seconds(5400).to_s # => "1h30m0s"
+			// ```
+			//
+			// @return [String]
+			Name: "to_s",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initStringObject(receiver.toString())
+				}
+			},
+		},
+	}
+}
+
+// Internal functions ===================================================
+
+// Functions for initialization -----------------------------------------
+
+func (vm *VM) initDurationObject(d time.Duration) *DurationObject {
+	return &DurationObject{baseObj: &baseObj{class: vm.topLevelClass(classes.DurationClass)}, value: d}
+}
+
+func (vm *VM) initDurationClass() *RClass {
+	dc := vm.initializeClass(classes.DurationClass, false)
+	// Class methods are also set as instance methods, so they're set first to not override the unit conversions like `Duration#hours`
+	dc.setBuiltinMethods(builtinDurationClassMethods(), true)
+	dc.setBuiltinMethods(builtinDurationInstanceMethods(), false)
+	dc.includeModule(vm.topLevelClass(classes.ComparableModule))
+	return dc
+}
+
+// Polymorphic helper functions -----------------------------------------
+
+// Value returns the duration
+func (d *DurationObject) Value() interface{} {
+	return d.value
+}
+
+// toString returns the duration in the format like "1h30m0s"
+func (d *DurationObject) toString() string {
+	return d.value.String()
+}
+
+// toJSON returns the duration as a JSON string
+func (d *DurationObject) toJSON() string {
+	return fmt.Sprintf("%q", d.value.String())
+}
+
+// Other helper functions ----------------------------------------------
+
+// durationOf returns a Duration of the only Numeric argument in the given unit
+func (t *thread) durationOf(args []Object, unit time.Duration) Object {
+	if len(args) != 1 {
+		return t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, 1, len(args))
+	}
+
+	d, ok := toDuration(args[0], unit)
+
+	if !ok {
+		return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, "Numeric", args[0].Class().Name)
+	}
+
+	return t.vm.initDurationObject(d)
+}
+
+// durationArg returns the value of the only Duration argument
+func (t *thread) durationArg(args []Object) (time.Duration, *Error) {
+	if len(args) != 1 {
+		return 0, t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, 1, len(args))
+	}
+
+	d, ok := args[0].(*DurationObject)
+
+	if !ok {
+		return 0, t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.DurationClass, args[0].Class().Name)
+	}
+
+	return d.value, nil
+}
+
+// numericArg returns the value of the only Numeric argument
+func (t *thread) numericArg(args []Object) (float64, *Error) {
+	if len(args) != 1 {
+		return 0, t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, 1, len(args))
+	}
+
+	n, ok := toFloat(args[0])
+
+	if !ok {
+		return 0, t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, "Numeric", args[0].Class().Name)
+	}
+
+	return n, nil
+}
+
+// toDuration converts a Duration, or a number in the given unit to a time.Duration
+func toDuration(obj Object, unit time.Duration) (time.Duration, bool) {
+	switch o := obj.(type) {
+	case *DurationObject:
+		return o.value, true
+	case *IntegerObject:
+		return time.Duration(o.value) * unit, true
+	case *FloatObject:
+		return time.Duration(o.value * float64(unit)), true
+	}
+
+	return 0, false
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
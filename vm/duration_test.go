@@ -0,0 +1,83 @@
+package vm
+
+import (
+	"testing"
+)
+
+func TestDurationClassMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`Duration.hours(1.5).to_s`, "1h30m0s"},
+		{`Duration.minutes(5).to_s`, "5m0s"},
+		{`Duration.seconds(90).to_s`, "1m30s"},
+		{`Duration.milliseconds(1500).to_s`, "1.5s"},
+		{`Duration.nanoseconds(1000).nanoseconds`, 1000},
+		{`Duration.parse("2h45m").to_s`, "2h45m0s"},
+		{`Duration.parse("300ms").milliseconds`, 300},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestDurationInstanceMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`Duration.minutes(90).hours`, 1.5},
+		{`Duration.seconds(90).minutes`, 1.5},
+		{`Duration.milliseconds(1500).seconds`, 1.5},
+		{`Duration.seconds(1.5).milliseconds`, 1500},
+		{`Duration.milliseconds(1500).to_i`, 1},
+		{`(Duration.minutes(1) + Duration.seconds(30)).to_s`, "1m30s"},
+		{`(Duration.minutes(1) - Duration.seconds(30)).to_s`, "30s"},
+		{`(Duration.seconds(10) * 1.5).to_s`, "15s"},
+		{`(Duration.seconds(10) / 4).to_s`, "2.5s"},
+		{`Duration.seconds(1) <=> Duration.seconds(2)`, -1},
+		{`Duration.seconds(1) <=> 1`, nil},
+		{`Duration.seconds(2) > Duration.seconds(1)`, true},
+		{`Duration.minutes(1) == Duration.seconds(60)`, true},
+		{`Duration.minutes(1) == 60`, false},
+		{`Duration.seconds(90).to_json`, `"1m30s"`},
+		{`{ elapsed: Duration.seconds(90) }.to_json`, `{"elapsed":"1m30s"}`},
+		{`sleep(Duration.milliseconds(1)).to_s`, "1ms"},
+		{`sleep(0.001)`, 0.001},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestDurationMethodsFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`Duration.new`, "UnsupportedMethodError: Unsupported Method #new for Duration", 1},
+		{`Duration.seconds("1")`, "TypeError: Expect argument to be Numeric. got: String", 1},
+		{`Duration.seconds`, "ArgumentError: Expect 1 arguments. got: 0", 1},
+		{`Duration.parse("1x")`, "ArgumentError: Invalid duration: 1x", 1},
+		{`Duration.seconds(1) + 1`, "TypeError: Expect argument to be Duration. got: Integer", 1},
+		{`Duration.seconds(1) * "a"`, "TypeError: Expect argument to be Numeric. got: String", 1},
+		{`Duration.seconds(1) / 0`, "ArgumentError: Divided by 0", 1},
+		{`sleep("1")`, "TypeError: Expect argument to be Numeric or Duration. got: String", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
@@ -0,0 +1,478 @@
+package vm
+
+import (
+	"github.com/goby-lang/goby/compiler/bytecode"
+	"github.com/goby-lang/goby/vm/classes"
+	"github.com/goby-lang/goby/vm/errors"
+)
+
+// Enumerable is a module that provides collection methods to the classes that include it.
+// The class only needs to define `each`, which yields every element to the block.
+// Array, Hash and Range include Enumerable as well, the elements of a Hash are its `[key, value]` pairs.
+//
+// ```ruby
+// class NumberList
+//   include Enumerable
+//
+//   def initialize(numbers)
+//     @numbers = numbers
+//   end
+//
+//   def each
+//     @numbers.each do |n|
+//       yield(n)
+//     end
+//   end
+// end
+//
+// list = NumberList.new([3, 1, 2])
+// list.sort # => [1, 2, 3]
+// list.map do |n|
+//   n * 2
+// end
+// # => [6, 2, 4]
+// list.include?(2) # => true
+// ```
+//
+
+// Instance methods -----------------------------------------------------
+func builtinEnumerableInstanceMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			// Returns true if the block returns a truthy value for any element.
+			//
+			// ```ruby
+			// (1..3).any? do |e|
+			//   e > 2
+			// end
+			// # => true
+			// ```
+			//
+			// @return [Boolean]
+			Name: "any?",
+			Fn:   delegateToArray("any?"),
+		},
+		{
+			// Returns the number of elements, or the number of elements that the block returns true for if a block is given.
+			//
+			// ```ruby
+			// (1..5).count # => 5
+			// (1..5).count do |e|
+			//   e > 3
+			// end
+			// # => 2
+			// ```
+			//
+			// @return [Integer]
+			Name: "count",
+			Fn:   delegateToArray("count"),
+		},
+		{
+			// Loops through the elements in slices of the given size, passing each slice to the block.
+			//
+			// ```ruby
+			// (1..5).each_slice(2) do |slice|
+			//   puts(slice)
+			// end
+			// # => [1, 2]
+			// # => [3, 4]
+			// # => [5]
+			// ```
+			//
+			// @param size [Integer]
+			// @return [Array]
+			Name: "each_slice",
+			Fn:   delegateToArray("each_slice"),
+		},
+		{
+			// Loops through the elements with the given block, passing the element and its index.
+			//
+			// ```ruby
+			// { a: 1 }.each_with_index do |pair, i|
+			//   puts(pair.to_s + i.to_s)
+			// end
+			// # => ["a", 1]0
+			// ```
+			//
+			// @return [Array]
+			Name: "each_with_index",
+			Fn:   delegateToArray("each_with_index"),
+		},
+		{
+			// Returns the first element that the block returns true for, or nil if there's none.
+			//
+			// ```ruby
+			// (1..10).find do |e|
+			//   e * e > 10
+			// end
+			// # => 4
+			// ```
+			//
+			// @return [Object]
+			Name: "find",
+			Fn:   delegateToArray("find"),
+		},
+		{
+			// Returns the first element, or an array of the first n elements if n is given.
+			//
+			// ```ruby
+			// { a: 1, b: 2 }.first # => ["a", 1]
+			// ```
+			//
+			// @param n [Integer]
+			// @return [Object]
+			Name: "first",
+			Fn:   delegateToArray("first"),
+		},
+		{
+			// Calls the block with each element and concatenates the returned arrays into one array.
+			//
+			// ```ruby
+			// (1..2).flat_map do |e|
+			//   [e, e * 10]
+			// end
+			// # => [1, 10, 2, 20]
+			// ```
+			//
+			// @return [Array]
+			Name: "flat_map",
+			Fn:   delegateToArray("flat_map"),
+		},
+		{
+			// Groups the elements by the results of the block.
+			//
+			// ```ruby
+			// (1..4).group_by do |e|
+			//   e % 2
+			// end
+			// # => { 1 => [1, 3], 0 => [2, 4] }
+			// ```
+			//
+			// @return [Hash]
+			Name: "group_by",
+			Fn:   delegateToArray("group_by"),
+		},
+		{
+			// Returns true if any element is `==` to the given object.
+			//
+			// ```ruby
+			// { a: 1 }.include?(["a", 1]) # => true
+			// ```
+			//
+			// @param object [Object]
+			// @return [Boolean]
+			Name: "include?",
+			Fn:   delegateToArray("include?"),
+		},
+		{
+			// Returns a new array with the results of calling the block with each element.
+			//
+			// ```ruby
+			// (1..3).map do |e|
+			//   e * 2
+			// end
+			// # => [2, 4, 6]
+			//
+			// { a: 1, b: 2 }.map do |k, v|
+			//   k + v.to_s
+			// end
+			// # => ["a1", "b2"]
+			// ```
+			//
+			// @return [Array]
+			Name: "map",
+			Fn:   delegateToArray("map"),
+		},
+		{
+			// Returns the largest element, elements are compared with `<=>`.
+			//
+			// ```ruby
+			// (1..3).max # => 3
+			// ```
+			//
+			// @return [Object]
+			Name: "max",
+			Fn:   delegateToArray("max"),
+		},
+		{
+			// Returns the smallest element, elements are compared with `<=>`.
+			//
+			// ```ruby
+			// (1..3).min # => 1
+			// ```
+			//
+			// @return [Object]
+			Name: "min",
+			Fn:   delegateToArray("min"),
+		},
+		{
+			// Returns two arrays, the first one contains the elements that the block returns true for, and the second one contains the rest.
+			//
+			// ```ruby
+			// (1..4).partition do |e|
+			//   e.even?
+			// end
+			// # => [[2, 4], [1, 3]]
+			// ```
+			//
+			// @return [Array]
+			Name: "partition",
+			Fn:   delegateToArray("partition"),
+		},
+		{
+			// Combines the elements by calling the block with the accumulated value and each element.
+			//
+			// ```ruby
+			// (1..4).reduce(0) do |sum, e|
+			//   sum + e
+			// end
+			// # => 10
+			// ```
+			//
+			// @param initial [Object]
+			// @return [Object]
+			Name: "reduce",
+			Fn:   delegateToArray("reduce"),
+		},
+		{
+			// Returns an array of the elements that the block returns true for.
+			//
+			// ```ruby
+			// (1..6).select do |e|
+			//   e % 3 == 0
+			// end
+			// # => [3, 6]
+			// ```
+			//
+			// @return [Array]
+			Name: "select",
+			Fn:   delegateToArray("select"),
+		},
+		{
+			// Returns a sorted array of the elements, elements are compared with `<=>` or the given block.
+			//
+			// ```ruby
+			// (1..3).sort do |a, b|
+			//   b <=> a
+			// end
+			// # => [3, 2, 1]
+			// ```
+			//
+			// @return [Array]
+			Name: "sort",
+			Fn:   delegateToArray("sort"),
+		},
+		{
+			// Returns an array of the elements sorted by the results of the block.
+			//
+			// ```ruby
+			// { a: 2, b: 1 }.sort_by do |k, v|
+			//   v
+			// end
+			// # => [["b", 1], ["a", 2]]
+			// ```
+			//
+			// @return [Array]
+			Name: "sort_by",
+			Fn:   delegateToArray("sort_by"),
+		},
+		{
+			// Returns the sum of the elements, or the sum of the block's results if a block is given.
+			//
+			// ```ruby
+			// (1..4).sum # => 10
+			// ```
+			//
+			// @param initial [Object]
+			// @return [Object]
+			Name: "sum",
+			Fn:   delegateToArray("sum"),
+		},
+		{
+			// Returns the elements before the first element that the block returns false for.
+			//
+			// ```ruby
+			// (1..5).take_while do |e|
+			//   e < 3
+			// end
+			// # => [1, 2]
+			// ```
+			//
+			// @return [Array]
+			Name: "take_while",
+			Fn:   delegateToArray("take_while"),
+		},
+		{
+			// Returns an array of the elements.
+			//
+			// ```ruby
+			// (1..3).to_a    # => [1, 2, 3]
+			// { a: 1 }.to_a  # => [["a", 1]]
+			// ```
+			//
+			// @return [Array]
+			Name: "to_a",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					elems, err := t.enumerableElements(receiver)
+					if err != nil {
+						return err
+					}
+
+					return t.vm.initArrayObject(elems)
+				}
+			},
+		},
+		{
+			// Returns an array of the elements without duplicates.
+			//
+			// ```ruby
+			// [1, 1, 2].uniq # => [1, 2]
+			// ```
+			//
+			// @return [Array]
+			Name: "uniq",
+			Fn:   delegateToArray("uniq"),
+		},
+		{
+			// Merges the elements with the elements of the given arrays at the same index.
+			//
+			// ```ruby
+			// (1..2).zip(["a", "b"]) # => [[1, "a"], [2, "b"]]
+			// ```
+			//
+			// @param arrays [Array]
+			// @return [Array]
+			Name: "zip",
+			Fn:   delegateToArray("zip"),
+		},
+	}
+}
+
+// Internal functions ===================================================
+
+// Functions for initialization -----------------------------------------
+
+func (vm *VM) initEnumerableModule() *RClass {
+	em := vm.initializeClass(classes.EnumerableModule, true)
+	em.setBuiltinMethods(builtinEnumerableInstanceMethods(), false)
+	return em
+}
+
+// Other helper functions ----------------------------------------------
+
+// delegateToArray returns a method body which collects the receiver's elements into an array,
+// and calls the Array method with the same name on it
+func delegateToArray(methodName string) func(receiver Object) builtinMethodBody {
+	return func(receiver Object) builtinMethodBody {
+		return func(t *thread, args []Object, blockFrame *callFrame) Object {
+			elems, err := t.enumerableElements(receiver)
+			if err != nil {
+				return err
+			}
+
+			m, _ := t.vm.topLevelClass(classes.ArrayClass).Methods.get(methodName)
+
+			return m.(*BuiltinMethodObject).Fn(t.vm.initArrayObject(elems))(t, args, blockFrame)
+		}
+	}
+}
+
+// enumerableElements returns the elements of the receiver.
+// Built-in collections are converted directly, other objects are iterated by calling their `each` method.
+func (t *thread) enumerableElements(receiver Object) ([]Object, *Error) {
+	switch r := receiver.(type) {
+	case *ArrayObject:
+		return append([]Object{}, r.Elements...), nil
+	case *HashObject:
+		elems := []Object{}
+
+		for _, pair := range r.pairs {
+			elems = append(elems, t.vm.initArrayObject([]Object{pair.key, pair.value}))
+		}
+
+		return elems, nil
+	case *RangeObject:
+		elems := []Object{}
+		start, end := r.Start, r.End
+
+		if start > end {
+			start, end = end, start
+		}
+
+		for i := start; i <= end; i++ {
+			elems = append(elems, t.vm.initIntegerObject(i))
+		}
+
+		return elems, nil
+	}
+
+	elems := []Object{}
+	blockFrame := t.goBlockFrame(receiver, func(args []Object) Object {
+		switch len(args) {
+		case 0:
+			elems = append(elems, NULL)
+		case 1:
+			elems = append(elems, args[0])
+		default:
+			elems = append(elems, t.vm.initArrayObject(args))
+		}
+
+		return NULL
+	})
+
+	var result Object
+	t.callFrameStack.push(blockFrame)
+
+	switch m := receiver.findMethod("each").(type) {
+	case *MethodObject:
+		result = t.callMethod(receiver, m, blockFrame)
+	case *BuiltinMethodObject:
+		result = m.Fn(receiver)(t, []Object{}, blockFrame)
+	default:
+		result = t.vm.initErrorObject(errors.UndefinedMethodError, "Undefined Method '%+v' for %+v", "each", receiver.toString())
+	}
+
+	// The block frame is left on the stack if `each` never yields
+	if t.callFrameStack.top() == blockFrame {
+		t.callFrameStack.pop()
+	}
+
+	if err, ok := result.(*Error); ok {
+		return nil, err
+	}
+
+	return elems, nil
+}
+
+// goBlockFrame returns a block frame which calls the Go function with the block's arguments,
+// so a built-in method can pass a block to a Goby method
+func (t *thread) goBlockFrame(self Object, fn func(args []Object) Object) *callFrame {
+	call := &action{
+		name: bytecode.CallGoBlock,
+		operation: func(t *thread, cf *callFrame, args ...interface{}) {
+			blockArgs := []Object{}
+
+			for _, p := range cf.locals {
+				if p == nil {
+					break
+				}
+
+				blockArgs = append(blockArgs, p.Target)
+			}
+
+			t.stack.push(&Pointer{Target: fn(blockArgs)})
+		},
+	}
+
+	is := &instructionSet{name: "go_block", isType: bytecode.Block, filename: t.callFrameStack.top().instructionSet.filename}
+	is.define(0, call)
+	is.define(0, builtinActions[bytecode.Leave])
+
+	c := newCallFrame(is)
+	c.isBlock = true
+	c.ep = t.callFrameStack.top()
+	c.self = self
+
+	return c
+}
@@ -0,0 +1,236 @@
+package vm
+
+import (
+	"testing"
+)
+
+func TestEnumerableMethods(t *testing.T) {
+	numberList := `
+	class NumberList
+	  include Enumerable
+
+	  def initialize(numbers)
+	    @numbers = numbers
+	  end
+
+	  def each
+	    @numbers.each do |n|
+	      yield(n)
+	    end
+	  end
+	end
+
+	list = NumberList.new([3, 1, 2])
+	`
+
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{numberList + `list.to_a.to_s`, "[3, 1, 2]"},
+		{numberList + `list.sort.to_s`, "[1, 2, 3]"},
+		{numberList + `
+		r = list.map do |n|
+		  n * 2
+		end
+		r.to_s
+		`, "[6, 2, 4]"},
+		{numberList + `
+		r = list.select do |n|
+		  n > 1
+		end
+		r.to_s
+		`, "[3, 2]"},
+		{numberList + `list.min`, 1},
+		{numberList + `list.max`, 3},
+		{numberList + `list.include?(2)`, true},
+		{numberList + `list.include?(5)`, false},
+		{numberList + `list.count`, 3},
+		{numberList + `list.sum`, 6},
+		{numberList + `
+		list.reduce(10) do |sum, n|
+		  sum + n
+		end
+		`, 16},
+		{numberList + `
+		list.find do |n|
+		  n < 3
+		end
+		`, 1},
+		{numberList + `
+		r = list.sort_by do |n|
+		  -n
+		end
+		r.to_s
+		`, "[3, 2, 1]"},
+		{numberList + `list.is_a?(Enumerable)`, true},
+		{numberList + `NumberList.new([]).to_a.to_s`, "[]"},
+		{numberList + `
+		r = NumberList.new([]).map do |n|
+		  n
+		end
+		r.to_s
+		`, "[]"},
+		{`
+		class Pairs
+		  include Enumerable
+
+		  def each
+		    yield(1, 2)
+		    yield(3, 4)
+		  end
+		end
+
+		Pairs.new.to_a.to_s
+		`, "[[1, 2], [3, 4]]"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestEnumerableBuiltinCollections(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`[].is_a?(Enumerable)`, true},
+		{`{}.is_a?(Enumerable)`, true},
+		{`(1..2).is_a?(Enumerable)`, true},
+		{`Array.ancestors.to_s`, "[Array, Enumerable, Object]"},
+		{`
+		r = { a: 1, b: 2 }.map do |k, v|
+		  k + v.to_s
+		end
+		r.to_s
+		`, `["a1", "b2"]`},
+		{`{ a: 1, b: 2 }.first.to_s`, `["a", 1]`},
+		{`{ a: 1 }.include?(["a", 1])`, true},
+		{`
+		r = { a: 2, b: 1 }.sort_by do |k, v|
+		  v
+		end
+		r.to_s
+		`, `[["b", 1], ["a", 2]]`},
+		{`(1..4).sum`, 10},
+		{`
+		r = (1..6).select do |n|
+		  n % 3 == 0
+		end
+		r.to_s
+		`, "[3, 6]"},
+		{`
+		r = (1..3).map do |n|
+		  n * n
+		end
+		r.to_s
+		`, "[1, 4, 9]"},
+		{`(3..1).to_a.to_s`, "[1, 2, 3]"},
+		{`(1..3).max`, 3},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestIncludeModuleInManyClasses(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		module Greet
+		  def greet
+		    "hi " + name
+		  end
+		end
+
+		class Foo
+		  include Greet
+
+		  def name
+		    "foo"
+		  end
+		end
+
+		class Bar
+		  include Greet
+
+		  def name
+		    "bar"
+		  end
+		end
+
+		Foo.new.greet + ", " + Bar.new.greet
+		`, "hi foo, hi bar"},
+		{`
+		module M
+		end
+
+		class Foo
+		  include M
+		end
+
+		class Bar
+		  include M
+		end
+
+		Foo.ancestors.to_s + Bar.ancestors.to_s
+		`, "[Foo, M, Object][Bar, M, Object]"},
+		{`
+		module Inner
+		  def inner
+		    "inner"
+		  end
+		end
+
+		module Outer
+		  include Inner
+		end
+
+		class Foo
+		  include Outer
+		end
+
+		Foo.new.inner + Foo.ancestors.to_s
+		`, "inner[Foo, Outer, Inner, Object]"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestEnumerableMethodsFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`
+		class Foo
+		  include Enumerable
+		end
+
+		Foo.new.to_a
+		`, "UndefinedMethodError: Undefined Method 'each' for <Instance of: Foo>", 6},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
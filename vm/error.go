@@ -3,27 +3,201 @@ package vm
 import (
 	"fmt"
 
+	"github.com/goby-lang/goby/vm/classes"
 	"github.com/goby-lang/goby/vm/errors"
 )
 
-// Error class is actually a special struct to hold internal error types with messages.
-// Goby developers need not to take care of the struct.
-// Goby maintainers should consider using the appropriate error type.
-// Cannot create instances of Error class, or inherit Error class.
+// Error class is a special struct that holds error types with messages.
+// All built-in error classes inherit from `StandardError`, and Goby developers can define their own
+// error classes by inheriting `StandardError` or its subclasses.
 //
-// The type of internal errors:
+// ```ruby
+// class MyError < StandardError
+// end
 //
-// * `InternalError`: default error type
-// * `ArgumentError`: an argument-related error
-// * `NameError`: a constant-related error
-// * `TypeError`: a type-related error
-// * `UndefinedMethodError`: undefined-method error
-// * `UnsupportedMethodError`: intentionally unsupported-method error
+// begin
+//   raise(MyError, "something went wrong")
+// rescue StandardError => e
+//   e.class.name # => "MyError"
+//   e.message    # => "something went wrong"
+// end
+// ```
+//
+// The built-in error classes are `StandardError` and its direct subclasses:
+//
+// * `StandardError`: the root of all error classes, rescuing it rescues every error
+//   * `InternalError`: default error type
+//   * `ArgumentError`: an argument-related error
+//   * `NameError`: a constant-related error
+//   * `TypeError`: a type-related error
+//   * `UndefinedMethodError`: undefined-method error
+//   * `UnsupportedMethodError`: intentionally unsupported-method error
+//   * `ConstantAlreadyInitializedError`: constant re-declaration error
+//   * `HTTPError`: a failed HTTP request
+//   * `RegexpError`: an invalid regular expression
+//   * `ChannelCloseError`: delivering to or closing a closed channel
 //
 type Error struct {
 	*baseObj
 	Message string
 	Type    string
+	// message is the error's message without its type and location
+	message string
+	// backtrace is nil until the error is raised
+	backtrace []*errorLocation
+	// raised is true when the error is being raised, rescued errors are just normal objects
+	raised bool
+}
+
+// errorLocation represents an entry of error's backtrace
+type errorLocation struct {
+	label    string
+	filename filename
+	line     int
+}
+
+func (l *errorLocation) String() string {
+	return fmt.Sprintf("%s:%d:in `%s'", l.filename, l.line, l.label)
+}
+
+// Class methods --------------------------------------------------------
+func builtinErrorClassMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			// Creates an error object with the given message, which can be raised later.
+			// The message is the class's name by default.
+			//
+			// ```ruby
+			// e = ArgumentError.new("wrong argument")
+			// e.message # => "wrong argument"
+			// raise(e)
+			// ```
+			//
+			// `new` calls the error's `initialize` with the arguments, so subclasses can define their own `initialize`
+			// and set the message by calling `super`:
+			//
+			// ```ruby
+			// class CodeError < StandardError
+			//   def initialize(code)
+			//     super("code: " + code.to_s)
+			//   end
+			// end
+			//
+			// CodeError.new(42).message # => "code: 42"
+			// ```
+			//
+			// @param message [String] (optional)
+			// @return [Error]
+			Name: "new",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.newError(receiver.(*RClass), args, blockFrame)
+				}
+			},
+		},
+	}
+}
+
+// Instance methods -----------------------------------------------------
+func builtinErrorInstanceMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			// Sets the error's message, it's called by `new` with the given arguments.
+			// The message is the class's name by default.
+			//
+			// ```ruby
+			// class AppError < StandardError
+			//   def initialize(msg)
+			//     super("app: " + msg)
+			//   end
+			// end
+			//
+			// AppError.new("failed").message # => "app: failed"
+			// ```
+			//
+			// @param message [String] (optional)
+			// @return [Error]
+			Name: "initialize",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					err := receiver.(*Error)
+
+					if len(args) > 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect at most 1 argument. got: %d", len(args))
+					}
+
+					if len(args) == 1 {
+						s, ok := args[0].(*StringObject)
+
+						if !ok {
+							return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.StringClass, args[0].Class().Name)
+						}
+
+						err.message = s.value
+						err.Message = fmt.Sprintf("%s: %s", err.Type, s.value)
+					}
+
+					return err
+				}
+			},
+		},
+		{
+			// Returns the error's message.
+			//
+			// ```ruby
+			// begin
+			//   raise(ArgumentError, "wrong argument")
+			// rescue ArgumentError => e
+			//   e.message # => "wrong argument"
+			// end
+			// ```
+			//
+			// @return [String]
+			Name: "message",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					err := receiver.(*Error)
+					return t.vm.initStringObject(err.message)
+				}
+			},
+		},
+		{
+			// Returns the call stack where the error was raised, innermost frame first.
+			// Returns nil if the error hasn't been raised.
+			//
+			// ```ruby
+			// def foo
+			//   raise(ArgumentError, "wrong argument")
+			// end
+			//
+			// begin
+			//   foo
+			// rescue ArgumentError => e
+			//   e.backtrace # => ["foo.gb:2:in `foo'", "foo.gb:6:in `<main>'"]
+			// end
+			// ```
+			//
+			// @return [Array]
+			Name: "backtrace",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					err := receiver.(*Error)
+
+					if err.backtrace == nil {
+						return NULL
+					}
+
+					locations := []Object{}
+
+					for _, l := range err.backtrace {
+						locations = append(locations, t.vm.initStringObject(l.String()))
+					}
+
+					return t.vm.initArrayObject(locations)
+				}
+			},
+		},
+	}
 }
 
 // Internal functions ===================================================
@@ -33,34 +207,120 @@ type Error struct {
 func (vm *VM) initErrorObject(errorType, format string, args ...interface{}) *Error {
 	errClass := vm.objectClass.getClassConstant(errorType)
 
-	t := vm.mainThread
-	cf := t.callFrameStack.top()
+	return vm.initErrorObjectFromClass(errClass, format, args...)
+}
 
-	// If program counter is 0 means we need to trace back to previous call frame
-	if cf.pc == 0 {
-		t.callFrameStack.pop()
-		cf = t.callFrameStack.top()
-	}
+func (vm *VM) initErrorObjectFromClass(errClass *RClass, format string, args ...interface{}) *Error {
+	err := newErrorFromClass(errClass, fmt.Sprintf(format, args...))
+	err.raised = true
 
-	i := cf.instructionSet.instructions[cf.pc-1]
+	return err
+}
 
+func newErrorFromClass(errClass *RClass, message string) *Error {
 	return &Error{
-		baseObj: &baseObj{class: errClass},
-		// Add 1 to source line because it's zero indexed
-		Message: fmt.Sprintf("%s. At %s:%d", fmt.Sprintf(errorType+": "+format, args...), cf.instructionSet.filename, i.sourceLine+1),
-		Type:    errorType,
+		baseObj: &baseObj{class: errClass, InstanceVariables: newEnvironment()},
+		Message: fmt.Sprintf("%s: %s", errClass.Name, message),
+		Type:    errClass.Name,
+		message: message,
+	}
+}
+
+// newError creates an error of the class and calls its `initialize` method with the arguments.
+// If `initialize` raises an error, that error is returned instead.
+func (t *thread) newError(class *RClass, args []Object, blockFrame *callFrame) *Error {
+	err := newErrorFromClass(class, class.Name)
+	var result Object
+
+	switch m := class.lookupMethod("initialize").(type) {
+	case *MethodObject:
+		result = t.callMethod(err, m, blockFrame, args...)
+	case *BuiltinMethodObject:
+		result = m.Fn(err)(t, args, blockFrame)
+	}
+
+	if e, ok := result.(*Error); ok && e.raised {
+		return e
+	}
+
+	return err
+}
+
+// raiseNewError creates an error of the class like `new` does and raises it
+func (t *thread) raiseNewError(class *RClass, args []Object) *Error {
+	err := t.newError(class, args, nil)
+	err.raised = true
+
+	return err
+}
+
+// uncaughtErrorMessage returns the message printed for the uncaught error, an overridden `message` method is called to get it
+func (t *thread) uncaughtErrorMessage(err *Error) string {
+	m, ok := err.findMethod("message").(*MethodObject)
+
+	if !ok {
+		return err.Message
+	}
+
+	// The error is unmarked while calling the method, otherwise pushing it to the stack raises it again
+	err.raised = false
+	s, ok := t.callMethod(err, m, nil).(*StringObject)
+	err.raised = true
+
+	if !ok {
+		return err.Message
+	}
+
+	if len(err.backtrace) == 0 {
+		return fmt.Sprintf("%s: %s", err.Type, s.value)
+	}
+
+	l := err.backtrace[0]
+	return fmt.Sprintf("%s: %s. At %s:%d", err.Type, s.value, l.filename, l.line)
+}
+
+// locateError captures the thread's call frames as the error's backtrace and appends the location to its message
+func (t *thread) locateError(err *Error) {
+	err.backtrace = []*errorLocation{}
+
+	for i := t.cfp - 1; i >= 0; i-- {
+		cf := t.callFrameStack.callFrames[i]
+
+		// Frames that haven't been executed yet, like block frames, are skipped
+		if cf.pc == 0 {
+			continue
+		}
+
+		err.backtrace = append(err.backtrace, &errorLocation{label: cf.label(), filename: cf.instructionSet.filename, line: cf.sourceLine()})
+	}
+
+	if len(err.backtrace) > 0 {
+		l := err.backtrace[0]
+		err.Message = fmt.Sprintf("%s: %s. At %s:%d", err.Type, err.message, l.filename, l.line)
 	}
 }
 
 func (vm *VM) initErrorClasses() {
-	errTypes := []string{errors.InternalError, errors.ArgumentError, errors.NameError, errors.TypeError, errors.UndefinedMethodError, errors.UnsupportedMethodError, errors.ConstantAlreadyInitializedError, errors.HTTPError}
+	errTypes := []string{errors.InternalError, errors.ArgumentError, errors.NameError, errors.TypeError, errors.UndefinedMethodError, errors.UnsupportedMethodError, errors.ConstantAlreadyInitializedError, errors.HTTPError, errors.RegexpError, errors.ChannelCloseError}
+
+	sc := vm.initializeClass(errors.StandardError, false)
+	sc.setBuiltinMethods(builtinErrorInstanceMethods(), false)
+	sc.setBuiltinMethods(builtinErrorClassMethods(), true)
+	vm.objectClass.setClassConstant(sc)
 
 	for _, errType := range errTypes {
 		c := vm.initializeClass(errType, false)
+		c.inherits(sc)
 		vm.objectClass.setClassConstant(c)
 	}
 }
 
+// isErrorClass returns true if the class is StandardError or inherits from it
+func (vm *VM) isErrorClass(c *RClass) bool {
+	sc := vm.objectClass.getClassConstant(errors.StandardError)
+	return c == sc || c.alreadyInherit(sc)
+}
+
 // Polymorphic helper functions -----------------------------------------
 
 // toString returns the object's name as the string format
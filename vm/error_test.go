@@ -185,6 +185,485 @@ func TestConstantAlreadyInitializedError(t *testing.T) {
 	}
 }
 
+func TestBeginRescueEvaluation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		begin
+		  10
+		rescue
+		  20
+		end
+		`, 10},
+		{`
+		begin
+		  foo
+		  10
+		rescue
+		  20
+		end
+		`, 20},
+		{`
+		begin
+		  raise(ArgumentError, "bad")
+		rescue TypeError
+		  "type"
+		rescue NameError, ArgumentError => e
+		  e.class.name
+		end
+		`, "ArgumentError"},
+		{`
+		begin
+		  1
+		rescue
+		  2
+		else
+		  3
+		end
+		`, 3},
+		{`
+		x = []
+		begin
+		  x.push(1)
+		ensure
+		  x.push(2)
+		end
+		x.to_s
+		`, "[1, 2]"},
+		{`
+		def foo
+		  [1, 2].each do |i|
+		    raise("boom")
+		  end
+		end
+
+		msg = begin
+		  foo
+		rescue => e
+		  e.to_s
+		end
+		msg
+		`, "ERROR: InternalError: boom. At " + getFilename() + ":4"},
+		{`
+		x = []
+		begin
+		  begin
+		    raise(TypeError)
+		  rescue ArgumentError
+		    x.push(1)
+		  ensure
+		    x.push(2)
+		  end
+		rescue TypeError
+		  x.push(3)
+		end
+		x.to_s
+		`, "[2, 3]"},
+		{`
+		begin
+		  begin
+		    raise(TypeError, "inner")
+		  rescue => e
+		    raise(e)
+		  end
+		rescue => err
+		  err.to_s
+		end
+		`, "ERROR: TypeError: inner. At " + getFilename() + ":4"},
+		{`
+		x = []
+		i = 0
+		while i < 3 do
+		  i += 1
+		  begin
+		    next
+		  ensure
+		    x.push(i)
+		  end
+		end
+		x.to_s
+		`, "[1, 2, 3]"},
+		{`
+		def foo
+		  begin
+		    return 10
+		  ensure
+		    @x = 1
+		  end
+		end
+		foo + @x
+		`, 11},
+		// `raise` without arguments raises the error being rescued again
+		{`
+		class MyError < StandardError; end
+
+		def foo
+		  raise(MyError, "foo")
+		end
+
+		begin
+		  begin
+		    foo
+		  rescue MyError
+		    raise
+		  end
+		rescue => e
+		  e.class.name + " " + e.message + " " + e.backtrace.length.to_s
+		end
+		`, "MyError foo 2"},
+		{`
+		begin
+		  begin
+		    raise(ArgumentError, "a")
+		  rescue
+		    begin
+		      raise(TypeError, "b")
+		    rescue
+		    end
+		    raise
+		  end
+		rescue => e
+		  e.class.name
+		end
+		`, "ArgumentError"},
+		{`
+		begin
+		  begin
+		    raise(TypeError, "a")
+		  rescue
+		    [1].each do |i|
+		      raise
+		    end
+		  end
+		rescue => e
+		  e.class.name
+		end
+		`, "TypeError"},
+		{`
+		begin
+		  raise(TypeError, "a")
+		rescue
+		end
+
+		begin
+		  raise
+		rescue => e
+		  e.class.name
+		end
+		`, "InternalError"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestErrorClassHierarchy(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`ArgumentError.superclass.name`, "StandardError"},
+		{`HTTPError.superclass.name`, "StandardError"},
+		{`
+		class MyError < StandardError
+		  def code
+		    42
+		  end
+		end
+
+		class SubError < MyError; end
+
+		begin
+		  raise(SubError, "custom")
+		rescue MyError => e
+		  e.class.name + e.message + e.code.to_s
+		end
+		`, "SubErrorcustom42"},
+		{`
+		begin
+		  raise(TypeError, "foo")
+		rescue StandardError => e
+		  e.message
+		end
+		`, "foo"},
+		{`
+		begin
+		  raise(ArgumentError)
+		rescue => e
+		  e.backtrace[0]
+		end
+		`, getFilename() + ":3:in `<main>'"},
+		{`ArgumentError.new("bar").message`, "bar"},
+		{`ArgumentError.new.message`, "ArgumentError"},
+		{`ArgumentError.new("bar").backtrace`, nil},
+		{`
+		e = ArgumentError.new("bar")
+
+		begin
+		  raise(e)
+		rescue ArgumentError => err
+		  err.message + err.backtrace[0]
+		end
+		`, "bar" + getFilename() + ":5:in `<main>'"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestErrorSubclassInitialize(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		class CodeError < StandardError
+		  def initialize(code)
+		    super("code " + code.to_s)
+		  end
+		end
+
+		CodeError.new(42).message
+		`, "code 42"},
+		{`
+		class AppError < StandardError
+		  def initialize(msg)
+		    super("app: " + msg)
+		  end
+		end
+
+		begin
+		  raise(AppError, "failed")
+		rescue AppError => e
+		  e.message
+		end
+		`, "app: failed"},
+		{`
+		class CodeError < StandardError
+		  def initialize(code)
+		    @code = code
+		  end
+
+		  def code
+		    @code
+		  end
+		end
+
+		e = CodeError.new(3)
+		e.code.to_s + e.message
+		`, "3CodeError"},
+		{`
+		class CodeError < ArgumentError
+		  def initialize(code)
+		    super("code " + code.to_s)
+		  end
+		end
+
+		class NotFoundError < CodeError
+		  def initialize
+		    super(404)
+		  end
+		end
+
+		begin
+		  raise(NotFoundError)
+		rescue ArgumentError => e
+		  e.class.name + " " + e.message
+		end
+		`, "NotFoundError code 404"},
+		{`
+		class QuietError < StandardError
+		  def message
+		    "quiet"
+		  end
+		end
+
+		begin
+		  raise(QuietError, "loud")
+		rescue QuietError => e
+		  e.message
+		end
+		`, "quiet"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestUncaughtErrorMessage(t *testing.T) {
+	v := initTestVM()
+	evaluated := v.testEval(t, `
+	class QuietError < StandardError
+	  def message
+	    "quiet"
+	  end
+	end
+
+	raise(QuietError, "loud")
+	`, getFilename())
+
+	err, ok := evaluated.(*Error)
+
+	if !ok {
+		t.Fatalf("Expect Error. got=%T (%+v)", evaluated, evaluated)
+	}
+
+	expected := fmt.Sprintf("QuietError: quiet. At %s:8", getFilename())
+
+	if msg := v.mainThread.uncaughtErrorMessage(err); msg != expected {
+		t.Errorf("Expect uncaught error message to be %s. got: %s", expected, msg)
+	}
+}
+
+func TestErrorBacktrace(t *testing.T) {
+	fn := getFilename()
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		def foo
+		  raise(ArgumentError, "foo")
+		end
+
+		def bar
+		  foo
+		end
+
+		begin
+		  bar
+		rescue => e
+		  e.backtrace.to_s
+		end
+		`, fmt.Sprintf("[\"%s:3:in `foo'\", \"%s:7:in `bar'\", \"%s:11:in `<main>'\"]", fn, fn, fn)},
+		{`
+		def foo
+		  [1].each do |i|
+		    raise(TypeError, "foo")
+		  end
+		end
+
+		begin
+		  foo
+		rescue => e
+		  e.backtrace.to_s
+		end
+		`, fmt.Sprintf("[\"%s:4:in `block in foo'\", \"%s:3:in `foo'\", \"%s:9:in `<main>'\"]", fn, fn, fn)},
+		{`
+		class Foo
+		  def self.bar
+		    raise("bar")
+		  end
+		end
+
+		begin
+		  Foo.bar
+		rescue => e
+		  e.backtrace.to_s
+		end
+		`, fmt.Sprintf("[\"%s:4:in `bar'\", \"%s:9:in `<main>'\"]", fn, fn)},
+		{`
+		def foo
+		  raise(ArgumentError, "foo")
+		end
+
+		c = Channel.new
+
+		thread do
+		  begin
+		    foo
+		  rescue => e
+		    c.deliver(e.backtrace.to_s)
+		  end
+		end
+
+		c.receive
+		`, fmt.Sprintf("[\"%s:3:in `foo'\", \"%s:10:in `block in <main>'\"]", fn, fn)},
+		{`
+		def foo
+		  raise(ArgumentError, "foo")
+		end
+
+		t = thread do
+		  [1].each do |i|
+		    foo
+		  end
+		  5
+		end
+
+		begin
+		  t.join
+		rescue => e
+		  e.backtrace.to_s
+		end
+		`, fmt.Sprintf("[\"%s:3:in `foo'\", \"%s:8:in `block in block in <main>'\", \"%s:7:in `block in <main>'\"]", fn, fn, fn)},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestRaiseError(t *testing.T) {
+	tests := []errorTestCase{
+		{`raise`, "InternalError: unhandled exception", 1},
+		{`raise("foo")`, "InternalError: foo", 1},
+		{`raise(TypeError)`, "TypeError: TypeError", 1},
+		{`raise(String)`, "TypeError: exception class/object expected. got: String", 1},
+		{`raise(String, "foo")`, "TypeError: exception class expected. got: String", 1},
+		{`raise(TypeError, 1)`, "TypeError: Expect argument to be String. got: Integer", 1},
+		{`ArgumentError.new("foo", "bar")`, "ArgumentError: Expect at most 1 argument. got: 2", 1},
+		{`class CodeError < StandardError
+		  def initialize(code); end
+		end
+		CodeError.new`, "ArgumentError: Expect at least 1 args for method 'initialize'. got: 0", 4},
+		{`class FooError < StandardError; end
+		raise(FooError.new("foo"))
+		`, "FooError: foo", 2},
+		{`begin
+		  raise(ArgumentError, "bar")
+		rescue TypeError
+		  1
+		end
+		`, "ArgumentError: bar", 2},
+		{`begin
+		  raise(ArgumentError, "bar")
+		rescue
+		  raise
+		end
+		`, "ArgumentError: bar", 2},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func checkError(t *testing.T, index int, evaluated Object, expectedErrMsg, fn string, line int) {
 	err, ok := evaluated.(*Error)
 	if !ok {
@@ -1,6 +1,8 @@
 package errors
 
 const (
+	// StandardError is the root of all error types
+	StandardError = "StandardError"
 	// InternalError is the default error type
 	InternalError = "InternalError"
 	// ArgumentError is for an argument-related error
@@ -17,6 +19,10 @@ const (
 	ConstantAlreadyInitializedError = "ConstantAlreadyInitializedError"
 	// HTTPError is returned when when a request fails to return a proper response
 	HTTPError = "HTTPError"
+	// RegexpError is for an invalid regular expression
+	RegexpError = "RegexpError"
+	// ChannelCloseError is for delivering to or closing a closed channel
+	ChannelCloseError = "ChannelCloseError"
 )
 
 /*
@@ -1352,8 +1352,8 @@ func TestAssignmentEvaluation(t *testing.T) {
 		`, 10},
 		{`
 		a = b = { foo: 100 }
-		b[:foo] = 10
-		a[:foo]
+		b["foo"] = 10
+		a["foo"]
 		`, 100},
 		{`
 		a = b = [1, 2]
@@ -1362,8 +1362,8 @@ func TestAssignmentEvaluation(t *testing.T) {
 		`, 2},
 		{`
 		@a = b = { foo: 100 }
-		b[:foo] = 10
-		@a[:foo]
+		b["foo"] = 10
+		@a["foo"]
 		`, 100},
 		{`
 		@a = b = [1, 2]
@@ -1372,8 +1372,8 @@ func TestAssignmentEvaluation(t *testing.T) {
 		`, 2},
 		{`
 		a = @b = { foo: 100 }
-		@b[:foo] = 10
-		a[:foo]
+		@b["foo"] = 10
+		a["foo"]
 		`, 100},
 		{`
 		a = @b = [1, 2]
@@ -1382,8 +1382,8 @@ func TestAssignmentEvaluation(t *testing.T) {
 		`, 2},
 		{`
 		@a = @b = { foo: 100 }
-		@b[:foo] = 10
-		@a[:foo]
+		@b["foo"] = 10
+		@a["foo"]
 		`, 100},
 		{`
 		@a = @b = [1, 2]
@@ -1407,18 +1407,18 @@ func TestAssignmentEvaluation(t *testing.T) {
 		`, 2},
 		{`
 		h = { foo: 2 }
-		h[:foo] += 2
-		h[:foo]
+		h["foo"] += 2
+		h["foo"]
 		`, 4},
 		{`
 		h = { foo: 2 }
-		h[:foo] -= 2
-		h[:foo]
+		h["foo"] -= 2
+		h["foo"]
 		`, 0},
 		{`
 		h = {}
-		h[:foo] ||= 2
-		h[:foo]
+		h["foo"] ||= 2
+		h["foo"]
 		`, 2},
 	}
 
@@ -2,6 +2,7 @@ package vm
 
 import (
 	"bufio"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -12,16 +13,21 @@ import (
 )
 
 // FileObject is a special type that contains file pointer so we can keep track on target file.
+// Reading methods like `gets`, `each_line` and `read(n)` read the file through a buffer,
+// so large files can be processed without loading them into memory.
 type FileObject struct {
 	*baseObj
-	File *os.File
+	File   *os.File
+	reader *bufio.Reader
 }
 
 var fileModeTable = map[string]int{
 	"r":  syscall.O_RDONLY,
 	"r+": syscall.O_RDWR,
-	"w":  syscall.O_WRONLY,
-	"w+": syscall.O_RDWR,
+	"w":  syscall.O_WRONLY | syscall.O_CREAT | syscall.O_TRUNC,
+	"w+": syscall.O_RDWR | syscall.O_CREAT | syscall.O_TRUNC,
+	"a":  syscall.O_WRONLY | syscall.O_CREAT | syscall.O_APPEND,
+	"a+": syscall.O_RDWR | syscall.O_CREAT | syscall.O_APPEND,
 }
 
 // Class methods --------------------------------------------------------
@@ -73,6 +79,35 @@ func builtinFileClassMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Copies the file's content and permission to the destination, and returns the number of bytes copied.
+			// If the destination is a directory, the file is copied into it with the same name.
+			//
+			// ```ruby
+			// File.copy("config.gb", "config.gb.bak") # => 321
+			// File.copy("config.gb", "backup")        # => 321, copied to "backup/config.gb"
+			// ```
+			// @param source [String], destination [String]
+			// @return [Integer]
+			Name: "copy",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					paths, err := t.stringArgs(args, 2)
+
+					if err != nil {
+						return err
+					}
+
+					n, e := copyFile(paths[0], paths[1])
+
+					if e != nil {
+						return t.vm.initErrorObject(errors.InternalError, "%s", e.Error())
+					}
+
+					return t.vm.initIntegerObject(int(n))
+				}
+			},
+		},
 		{
 			Name: "delete",
 			Fn: func(receiver Object) builtinMethodBody {
@@ -143,11 +178,14 @@ func builtinFileClassMethods() []*BuiltinMethodObject {
 		},
 		{
 			// Finds the file with given filename and initializes a file object with it.
+			// The mode can be "r", "r+", "w", "w+", "a" or "a+", and it's "r" by default.
+			// "w" modes truncate the file and "a" modes append to it, both of them create the file with the permission if it doesn't exist.
 			//
 			// ```ruby
 			// File.new("./samples/server.gb")
+			// File.new("./log/app.log", "a", 0644)
 			// ```
-			// @param filename [String]
+			// @param filename [String], mode [String], perm [Integer]
 			// @return [File]
 			Name: "new",
 			Fn: func(receiver Object) builtinMethodBody {
@@ -173,12 +211,7 @@ func builtinFileClassMethods() []*BuiltinMethodObject {
 								return t.vm.initErrorObject(errors.InternalError, "Unknown file mode: %s", m)
 							}
 
-							if md == syscall.O_RDWR || md == syscall.O_WRONLY {
-								os.Create(fn)
-							}
-
 							mode = md
-							perm = os.FileMode(0755)
 
 							if len(args) == 3 {
 								p := args[2].(*IntegerObject).value
@@ -200,6 +233,31 @@ func builtinFileClassMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Renames or moves the file or directory. Returns 0.
+			//
+			// ```ruby
+			// File.rename("draft.md", "posts/final.md") # => 0
+			// ```
+			// @param from [String], to [String]
+			// @return [Integer]
+			Name: "rename",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					paths, err := t.stringArgs(args, 2)
+
+					if err != nil {
+						return err
+					}
+
+					if e := os.Rename(paths[0], paths[1]); e != nil {
+						return t.vm.initErrorObject(errors.InternalError, "%s", e.Error())
+					}
+
+					return t.vm.initIntegerObject(0)
+				}
+			},
+		},
 		{
 			// Returns size of file in bytes.
 			//
@@ -246,6 +304,36 @@ func builtinFileClassMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Returns the information of the file as a `File::Stat`. Symbolic links are followed.
+			//
+			// ```ruby
+			// stat = File.stat("loop.gb")
+			// stat.size       # => 321123
+			// stat.directory? # => false
+			// stat.mtime      # => 2017-09-05 14:30:00 +0800
+			// ```
+			// @param filename [String]
+			// @return [File::Stat]
+			Name: "stat",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					filename, err := t.stringArg(args)
+
+					if err != nil {
+						return err
+					}
+
+					info, e := os.Lstat(filename)
+
+					if e != nil {
+						return t.vm.initErrorObject(errors.InternalError, "%s", e.Error())
+					}
+
+					return t.vm.initFileStatObject(filename, info)
+				}
+			},
+		},
 	}
 }
 
@@ -256,13 +344,131 @@ func builtinFileInstanceMethods() []*BuiltinMethodObject {
 			Name: "close",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
-					file := receiver.(*FileObject).File
-					file.Close()
+					f := receiver.(*FileObject)
+					f.reader = nil
+					f.File.Close()
 
 					return NULL
 				}
 			},
 		},
+		{
+			// Yields each line of the file from the current position, the line includes its trailing newline.
+			// Only one line is held in memory at a time, so it can be used on files which are too large to `read`.
+			//
+			// ```ruby
+			// File.open("access.log") do |f|
+			//   f.each_line do |line|
+			//     puts(line)
+			//   end
+			// end
+			// ```
+			// @return [File]
+			Name: "each_line",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, 0, len(args))
+					}
+
+					if blockFrame == nil {
+						return t.vm.initErrorObject(errors.InternalError, errors.CantYieldWithoutBlockFormat)
+					}
+
+					f := receiver.(*FileObject)
+					yielded := false
+
+					for {
+						line, err := f.bufferedReader().ReadString('\n')
+
+						if err != nil && err != io.EOF {
+							return t.vm.initErrorObject(errors.InternalError, "%s", err.Error())
+						}
+
+						if len(line) > 0 {
+							yielded = true
+							result := t.builtinMethodYield(blockFrame, t.vm.initStringObject(line))
+
+							if err, ok := result.Target.(*Error); ok && err.raised {
+								return err
+							}
+						}
+
+						if err == io.EOF {
+							break
+						}
+					}
+
+					// The block's frame is left on the stack if it's never yielded
+					if !yielded {
+						t.callFrameStack.pop()
+					}
+
+					return f
+				}
+			},
+		},
+		{
+			// Commits the written data to the disk. It does nothing for files like `STDOUT`.
+			//
+			// ```ruby
+			// File.open("app.log", "a") do |f|
+			//   f.write("started\n")
+			//   f.flush
+			// end
+			// ```
+			// @return [File]
+			Name: "flush",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					f := receiver.(*FileObject)
+					info, err := f.File.Stat()
+
+					if err != nil {
+						return t.vm.initErrorObject(errors.InternalError, "%s", err.Error())
+					}
+
+					if info.Mode().IsRegular() {
+						if err := f.File.Sync(); err != nil {
+							return t.vm.initErrorObject(errors.InternalError, "%s", err.Error())
+						}
+					}
+
+					return f
+				}
+			},
+		},
+		{
+			// Reads the next line from the file, including its trailing newline. Returns nil at the end of the file.
+			//
+			// ```ruby
+			// name = STDIN.gets
+			//
+			// f = File.new("access.log")
+			// f.gets # => "GET / 200\n"
+			// ```
+			// @return [String]
+			Name: "gets",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, 0, len(args))
+					}
+
+					line, err := receiver.(*FileObject).bufferedReader().ReadString('\n')
+
+					if err != nil && err != io.EOF {
+						return t.vm.initErrorObject(errors.InternalError, "%s", err.Error())
+					}
+
+					if len(line) == 0 {
+						return NULL
+					}
+
+					return t.vm.initStringObject(line)
+				}
+			},
+		},
 		{
 			Name: "name",
 			Fn: func(receiver Object) builtinMethodBody {
@@ -273,28 +479,192 @@ func builtinFileInstanceMethods() []*BuiltinMethodObject {
 			},
 		},
 		{
+			// Returns the current position in the file in bytes.
+			//
+			// ```ruby
+			// f = File.new("access.log")
+			// f.gets
+			// f.pos # => 10
+			// ```
+			// @return [Integer]
+			Name: "pos",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					f := receiver.(*FileObject)
+					pos, err := f.File.Seek(0, io.SeekCurrent)
+
+					if err != nil {
+						return t.vm.initErrorObject(errors.InternalError, "%s", err.Error())
+					}
+
+					// The data in the buffer hasn't been read yet
+					if f.reader != nil {
+						pos -= int64(f.reader.Buffered())
+					}
+
+					return t.vm.initIntegerObject(int(pos))
+				}
+			},
+		},
+		{
+			// Writes the arguments' string format to the file. Returns nil.
+			//
+			// ```ruby
+			// STDERR.print("Loading...")
+			// ```
+			// @return [Null]
+			Name: "print",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					var s string
+
+					for _, arg := range args {
+						s += arg.toString()
+					}
+
+					return receiver.(*FileObject).writeString(t, s)
+				}
+			},
+		},
+		{
+			// Writes each argument's string format to the file, followed by a newline. Returns nil.
+			//
+			// ```ruby
+			// STDERR.puts("Something went wrong")
+			// STDOUT.puts("foo", "bar")
+			// ```
+			// @return [Null]
+			Name: "puts",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					var s string
+
+					for _, arg := range args {
+						s += arg.toString() + "\n"
+					}
+
+					if len(args) == 0 {
+						s = "\n"
+					}
+
+					return receiver.(*FileObject).writeString(t, s)
+				}
+			},
+		},
+		{
+			// Reads the rest of the file from the current position.
+			// With a length, it reads at most the given number of bytes and returns nil at the end of the file.
+			//
+			// **Note:** `STDIN.read` without a length reads a line, which is the same as `STDIN.gets`.
+			//
+			// ```ruby
+			// File.new("loop.gb").read    # => "10.times do |i|\n  puts(i)\nend\n"
+			//
+			// f = File.new("loop.gb")
+			// f.read(8) # => "10.times"
+			// ```
+			// @param length [Integer]
+			// @return [String]
 			Name: "read",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
-					var result string
-					var f []byte
-					var err error
+					f := receiver.(*FileObject)
+
+					switch len(args) {
+					case 0:
+						var b []byte
+						var err error
+
+						if f.File.Name() == "/dev/stdin" {
+							var line string
+							line, err = f.bufferedReader().ReadString('\n')
+							b = []byte(line)
+						} else {
+							b, err = ioutil.ReadAll(f.bufferedReader())
+						}
 
-					file := receiver.(*FileObject).File
+						if err != nil && err != io.EOF {
+							return t.vm.initErrorObject(errors.InternalError, "%s", err.Error())
+						}
+
+						return t.vm.initStringObject(string(b))
+					case 1:
+						length, ok := args[0].(*IntegerObject)
+
+						if !ok {
+							return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.IntegerClass, args[0].Class().Name)
+						}
+
+						if length.value < 0 {
+							return t.vm.initErrorObject(errors.ArgumentError, "Expect length to be positive. got: %d", length.value)
+						}
+
+						b := make([]byte, length.value)
+						n, err := io.ReadFull(f.bufferedReader(), b)
+
+						if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+							return t.vm.initErrorObject(errors.InternalError, "%s", err.Error())
+						}
 
-					if file.Name() == "/dev/stdin" {
-						reader := bufio.NewReader(os.Stdin)
-						result, err = reader.ReadString('\n')
-					} else {
-						f, err = ioutil.ReadFile(file.Name())
-						result = string(f)
+						if n == 0 && length.value > 0 {
+							return NULL
+						}
+
+						return t.vm.initStringObject(string(b[:n]))
+					default:
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect 0 or 1 argument. got: %d", len(args))
+					}
+				}
+			},
+		},
+		{
+			// Moves the position to the beginning of the file. Returns 0.
+			//
+			// ```ruby
+			// f = File.new("loop.gb")
+			// f.read
+			// f.rewind
+			// f.gets # => "10.times do |i|\n"
+			// ```
+			// @return [Integer]
+			Name: "rewind",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return receiver.(*FileObject).seek(t, 0, io.SeekStart)
+				}
+			},
+		},
+		{
+			// Moves the position by the offset in bytes. Returns 0.
+			// The offset is from the beginning of the file by default, or from the position given by `File::SEEK_CUR` or `File::SEEK_END`.
+			//
+			// ```ruby
+			// f = File.new("loop.gb")
+			// f.seek(3)
+			// f.read(5)                 # => "times"
+			// f.seek(-4, File::SEEK_END)
+			// f.read                    # => "end\n"
+			// ```
+			// @param offset [Integer], whence [Integer]
+			// @return [Integer]
+			Name: "seek",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) == 1 {
+						args = append(args, t.vm.initIntegerObject(io.SeekStart))
 					}
 
+					values, err := t.integerArgs(args, 2)
+
 					if err != nil {
-						return t.vm.initErrorObject(errors.InternalError, err.Error())
+						return err
+					}
+
+					if values[1] != io.SeekStart && values[1] != io.SeekCurrent && values[1] != io.SeekEnd {
+						return t.vm.initErrorObject(errors.ArgumentError, "Invalid whence: %d", values[1])
 					}
 
-					return t.vm.initStringObject(result)
+					return receiver.(*FileObject).seek(t, int64(values[0]), values[1])
 				}
 			},
 		},
@@ -320,15 +690,30 @@ func builtinFileInstanceMethods() []*BuiltinMethodObject {
 			},
 		},
 		{
+			// Writes the string at the current position, or at the end of the file in "a" modes.
+			// Returns the number of bytes written.
+			//
+			// ```ruby
+			// File.open("app.log", "a") do |f|
+			//   f.write("started\n") # => 8
+			// end
+			// ```
+			// @param data [String]
+			// @return [Integer]
 			Name: "write",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
-					file := receiver.(*FileObject).File
+					f := receiver.(*FileObject)
 					data := args[0].(*StringObject).value
-					length, err := file.Write([]byte(data))
+
+					if err := f.discardBuffer(); err != nil {
+						return t.vm.initErrorObject(errors.InternalError, "%s", err.Error())
+					}
+
+					length, err := f.File.Write([]byte(data))
 
 					if err != nil {
-						return t.vm.initErrorObject(errors.InternalError, err.Error())
+						return t.vm.initErrorObject(errors.InternalError, "%s", err.Error())
 					}
 
 					return t.vm.initIntegerObject(length)
@@ -354,11 +739,23 @@ func (vm *VM) initFileClass() *RClass {
 	fc.setBuiltinMethods(builtinFileClassMethods(), true)
 	fc.setBuiltinMethods(builtinFileInstanceMethods(), false)
 
+	stat := vm.initializeClass(fileStatClassName, false)
+	stat.setBuiltinMethods(builtinFileStatInstanceMethods(), false)
+	fc.setClassConstant(stat)
+
 	vm.libFiles = append(vm.libFiles, "file.gb")
 
 	return fc
 }
 
+// initFileConstants needs Integer class to initialize the constants for `File#seek`
+func (vm *VM) initFileConstants() {
+	fc := vm.topLevelClass(classes.FileClass)
+	fc.constants["SEEK_SET"] = &Pointer{Target: vm.initIntegerObject(io.SeekStart)}
+	fc.constants["SEEK_CUR"] = &Pointer{Target: vm.initIntegerObject(io.SeekCurrent)}
+	fc.constants["SEEK_END"] = &Pointer{Target: vm.initIntegerObject(io.SeekEnd)}
+}
+
 // Polymorphic helper functions -----------------------------------------
 
 // toString returns the object's name as the string format
@@ -375,3 +772,90 @@ func (f *FileObject) toJSON() string {
 func (f *FileObject) Value() interface{} {
 	return f.File
 }
+
+// Other helper functions ----------------------------------------------
+
+// bufferedReader returns the reader which is shared by the reading methods, it's created on the first read
+func (f *FileObject) bufferedReader() *bufio.Reader {
+	if f.reader == nil {
+		f.reader = bufio.NewReader(f.File)
+	}
+
+	return f.reader
+}
+
+// discardBuffer drops the reader's buffer and moves the file's offset back to the position which has actually been read
+func (f *FileObject) discardBuffer() error {
+	if f.reader == nil {
+		return nil
+	}
+
+	buffered := f.reader.Buffered()
+	f.reader = nil
+
+	if buffered == 0 {
+		return nil
+	}
+
+	_, err := f.File.Seek(int64(-buffered), io.SeekCurrent)
+	return err
+}
+
+func (f *FileObject) seek(t *thread, offset int64, whence int) Object {
+	if err := f.discardBuffer(); err != nil {
+		return t.vm.initErrorObject(errors.InternalError, "%s", err.Error())
+	}
+
+	if _, err := f.File.Seek(offset, whence); err != nil {
+		return t.vm.initErrorObject(errors.InternalError, "%s", err.Error())
+	}
+
+	return t.vm.initIntegerObject(0)
+}
+
+func (f *FileObject) writeString(t *thread, s string) Object {
+	if err := f.discardBuffer(); err != nil {
+		return t.vm.initErrorObject(errors.InternalError, "%s", err.Error())
+	}
+
+	if _, err := f.File.WriteString(s); err != nil {
+		return t.vm.initErrorObject(errors.InternalError, "%s", err.Error())
+	}
+
+	return NULL
+}
+
+// copyFile copies the file's content and permission, the file is copied into the destination if it's a directory
+func copyFile(src, dst string) (int64, error) {
+	in, err := os.Open(src)
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer in.Close()
+
+	info, err := in.Stat()
+
+	if err != nil {
+		return 0, err
+	}
+
+	if d, err := os.Stat(dst); err == nil && d.IsDir() {
+		dst = filepath.Join(dst, filepath.Base(src))
+	}
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := io.Copy(out, in)
+
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+
+	return n, err
+}
@@ -0,0 +1,183 @@
+package vm
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/goby-lang/goby/vm/classes"
+)
+
+// fileStatClassName is the name of the class of `File.stat`'s result, which is defined under File
+const fileStatClassName = "Stat"
+
+// FileStatObject holds the information of a file, it's returned by `File.stat` and yielded by `Dir.walk`.
+// The information is taken when the object is created, so it isn't updated when the file changes.
+//
+// ```ruby
+// stat = File.stat("samples/server.gb")
+// stat.size        # => 1024
+// stat.mode        # => 420 (0644)
+// stat.file?       # => true
+// stat.directory?  # => false
+// stat.mtime.year  # => 2017
+// ```
+//
+type FileStatObject struct {
+	*baseObj
+	path    string
+	info    os.FileInfo
+	symlink bool
+}
+
+// Instance methods -----------------------------------------------------
+func builtinFileStatInstanceMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			// Returns true if the file is a directory.
+			//
+			// ```ruby
+			// File.stat("/tmp").directory? # => true
+			// ```
+			//
+			// @return [Boolean]
+			Name: "directory?",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return toBooleanObject(receiver.(*FileStatObject).info.IsDir())
+				}
+			},
+		},
+		{
+			// Returns true if the file is a regular file.
+			//
+			// ```ruby
+			// File.stat("/etc/hosts").file? # => true
+			// ```
+			//
+			// @return [Boolean]
+			Name: "file?",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return toBooleanObject(receiver.(*FileStatObject).info.Mode().IsRegular())
+				}
+			},
+		},
+		{
+			// Returns the permission bits of the file.
+			//
+			// ```ruby
+			// File.chmod(0755, "test.sh")
+			// File.stat("test.sh").mode == 0755 # => true
+			// ```
+			//
+			// @return [Integer]
+			Name: "mode",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initIntegerObject(int(receiver.(*FileStatObject).info.Mode().Perm()))
+				}
+			},
+		},
+		{
+			// Returns the last modification time of the file.
+			//
+			// ```ruby
+			// File.stat("test.gb").mtime.year # => 2017
+			// ```
+			//
+			// @return [Time]
+			Name: "mtime",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initTimeObject(receiver.(*FileStatObject).info.ModTime())
+				}
+			},
+		},
+		{
+			// Returns the base name of the file.
+			//
+			// ```ruby
+			// File.stat("samples/server.gb").name # => "server.gb"
+			// ```
+			//
+			// @return [String]
+			Name: "name",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initStringObject(receiver.(*FileStatObject).info.Name())
+				}
+			},
+		},
+		{
+			// Returns the size of the file in bytes.
+			//
+			// ```ruby
+			// File.stat("test.gb").size # => 22
+			// ```
+			//
+			// @return [Integer]
+			Name: "size",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initIntegerObject(int(receiver.(*FileStatObject).info.Size()))
+				}
+			},
+		},
+		{
+			// Returns true if the path is a symbolic link. Other methods return the information of the link's target.
+			//
+			// ```ruby
+			// File.stat("/usr/bin/python").symlink? # => true
+			// ```
+			//
+			// @return [Boolean]
+			Name: "symlink?",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return toBooleanObject(receiver.(*FileStatObject).symlink)
+				}
+			},
+		},
+	}
+}
+
+// Internal functions ===================================================
+
+// Functions for initialization -----------------------------------------
+
+// initFileStatObject returns the stat of the path, the information is expected to be taken by os.Lstat so symbolic links can be detected
+func (vm *VM) initFileStatObject(path string, info os.FileInfo) *FileStatObject {
+	s := &FileStatObject{
+		baseObj: &baseObj{class: vm.topLevelClass(classes.FileClass).getClassConstant(fileStatClassName)},
+		path:    path,
+		info:    info,
+	}
+
+	if info.Mode()&os.ModeSymlink != 0 {
+		s.symlink = true
+
+		// A broken link keeps the link's own information
+		if target, err := os.Stat(path); err == nil {
+			s.info = target
+		}
+	}
+
+	return s
+}
+
+// Polymorphic helper functions -----------------------------------------
+
+// Value returns the file information
+func (s *FileStatObject) Value() interface{} {
+	return s.info
+}
+
+// toString returns the object's name and path as the string format
+func (s *FileStatObject) toString() string {
+	return fmt.Sprintf("<File::Stat: %s>", s.path)
+}
+
+// toJSON just delegates to toString
+func (s *FileStatObject) toJSON() string {
+	return s.toString()
+}
@@ -33,6 +33,24 @@ func TestFileObject(t *testing.T) {
 	 	end
 	 	file
 		`, "this file's size is\n22"},
+		// The file is closed even if the block raises an error
+		{`
+		file = nil
+		begin
+		  File.open("../test_fixtures/file_test/size.gb") do |f|
+		    file = f
+		    raise(ArgumentError, "boom")
+		  end
+		rescue ArgumentError => e
+		  msg = e.message
+		end
+
+		begin
+		  file.read
+		rescue => e
+		  msg + " " + e.message
+		end
+		`, "boom read ../test_fixtures/file_test/size.gb: file already closed"},
 	}
 
 	for i, tt := range tests {
@@ -209,3 +227,245 @@ func TestFileSplitMethod(t *testing.T) {
 }
 
 //@TODO add test for chmod form a847c8b41f29657b380c1731ec36a660dbf49bc4
+
+func TestFileStatMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`File.stat("../test_fixtures/file_test/size.gb").size`, 22},
+		{`File.stat("../test_fixtures/file_test/size.gb").name`, "size.gb"},
+		{`File.stat("../test_fixtures/file_test/size.gb").file?`, true},
+		{`File.stat("../test_fixtures/file_test/size.gb").directory?`, false},
+		{`File.stat("../test_fixtures/file_test").directory?`, true},
+		{`File.stat("../test_fixtures/file_test/size.gb").symlink?`, false},
+		{`File.stat("../test_fixtures/file_test/size.gb").mtime.class.name`, "Time"},
+		{`File.stat("../test_fixtures/file_test/size.gb").class.name`, "Stat"},
+		{`
+		File.open("/tmp/out_stat.txt", "w", 0755)
+		File.chmod(0600, "/tmp/out_stat.txt")
+		File.stat("/tmp/out_stat.txt").mode == 0600
+		`, true},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestFileRenameAndCopyMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		File.open("/tmp/out_rename.txt", "w", 0755) do |f|
+		  f.write("foo")
+		end
+		File.rename("/tmp/out_rename.txt", "/tmp/out_renamed.txt")
+		File.exist?("/tmp/out_rename.txt").to_s + " " + File.new("/tmp/out_renamed.txt").read
+		`, "false foo"},
+		{`
+		File.open("/tmp/out_copy.txt", "w", 0755) do |f|
+		  f.write("Goby")
+		end
+		n = File.copy("/tmp/out_copy.txt", "/tmp/out_copied.txt")
+		n.to_s + " " + File.new("/tmp/out_copied.txt").read
+		`, "4 Goby"},
+		{`
+		File.open("/tmp/out_copy.txt", "w", 0640)
+		File.chmod(0640, "/tmp/out_copy.txt")
+		Dir.rm_rf("/tmp/out_copy_dir")
+		Dir.mkdir_p("/tmp/out_copy_dir")
+		File.copy("/tmp/out_copy.txt", "/tmp/out_copy_dir")
+		File.stat("/tmp/out_copy_dir/out_copy.txt").mode == 0640
+		`, true},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestFileStatAndCopyMethodsFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`File.stat("/tmp/goby_none.txt")`, "InternalError: lstat /tmp/goby_none.txt: no such file or directory", 1},
+		{`File.stat(1)`, "TypeError: Expect argument to be String. got: Integer", 1},
+		{`File.rename("/tmp/goby_none.txt", "/tmp/goby_none2.txt")`, "InternalError: rename /tmp/goby_none.txt /tmp/goby_none2.txt: no such file or directory", 1},
+		{`File.copy("/tmp/goby_none.txt")`, "ArgumentError: Expect 2 arguments. got: 1", 1},
+		{`File.copy("/tmp/goby_none.txt", "/tmp/goby_none2.txt")`, "InternalError: open /tmp/goby_none.txt: no such file or directory", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestFileStreamingMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		lines = []
+		File.open("../test_fixtures/file_test/size.gb") do |f|
+		  f.each_line do |line|
+		    lines.push(line)
+		  end
+		end
+		lines.join("|")
+		`, "this file's size is\n|22"},
+		{`
+		f = File.new("../test_fixtures/file_test/size.gb")
+		f.gets + f.gets + f.gets.to_s
+		`, "this file's size is\n22"},
+		{`
+		f = File.new("../test_fixtures/file_test/size.gb")
+		f.read(4) + "|" + f.read(5) + "|" + f.pos.to_s
+		`, "this| file|9"},
+		{`
+		f = File.new("../test_fixtures/file_test/size.gb")
+		f.read(100)
+		f.read(1)
+		`, nil},
+		{`
+		f = File.new("../test_fixtures/file_test/size.gb")
+		f.gets
+		f.pos
+		`, 20},
+		{`
+		f = File.new("../test_fixtures/file_test/size.gb")
+		f.gets
+		f.seek(-2, File::SEEK_END)
+		f.read
+		`, "22"},
+		{`
+		f = File.new("../test_fixtures/file_test/size.gb")
+		f.seek(3)
+		f.seek(2, File::SEEK_CUR)
+		f.read(4)
+		`, "file"},
+		{`
+		f = File.new("../test_fixtures/file_test/size.gb")
+		f.read
+		f.rewind
+		f.gets
+		`, "this file's size is\n"},
+		{`
+		File.open("../test_fixtures/file_test/size.gb") do |f|
+		  f.gets
+		end
+		`, "this file's size is\n"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestFileModes(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		File.open("/tmp/out_mode.txt", "w") do |f|
+		  f.write("foo\n")
+		end
+		File.open("/tmp/out_mode.txt", "a") do |f|
+		  f.write("bar\n")
+		  f.flush
+		end
+		File.new("/tmp/out_mode.txt").read
+		`, "foo\nbar\n"},
+		{`
+		File.open("/tmp/out_mode.txt", "w") do |f|
+		  f.puts("foo", 1)
+		  f.print("bar", 2)
+		end
+		File.new("/tmp/out_mode.txt").read
+		`, "foo\n1\nbar2"},
+		{`
+		File.open("/tmp/out_mode.txt", "w+") do |f|
+		  f.write("Goby is fun")
+		  f.seek(0)
+		  f.read(4)
+		end
+		`, "Goby"},
+		{`
+		File.open("/tmp/out_mode.txt", "w") do |f|
+		  f.write("Goby is fun")
+		end
+		File.open("/tmp/out_mode.txt", "r+") do |f|
+		  f.read(5)
+		  f.write("IS")
+		  f.rewind
+		  f.read
+		end
+		`, "Goby IS fun"},
+		{`
+		File.open("/tmp/out_mode.txt", "w") do |f|
+		  f.write("foo")
+		end
+		File.open("/tmp/out_mode.txt", "a+") do |f|
+		  f.write("bar")
+		  f.rewind
+		  f.read
+		end
+		`, "foobar"},
+		{`
+		Dir.rm_rf("/tmp/out_mode_perm.txt")
+		File.open("/tmp/out_mode_perm.txt", "w", 0600)
+		File.stat("/tmp/out_mode_perm.txt").mode == 0600
+		`, true},
+		{`
+		f = File.open("/tmp/out_mode.txt", "w")
+		f.write("foo")
+		f.close
+		`, nil},
+		{`STDOUT.class.name + STDERR.class.name + STDIN.class.name`, "FileFileFile"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestFileStreamingMethodsFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`File.new("../test_fixtures/file_test/size.gb").read(-1)`, "ArgumentError: Expect length to be positive. got: -1", 1},
+		{`File.new("../test_fixtures/file_test/size.gb").read("1")`, "TypeError: Expect argument to be Integer. got: String", 1},
+		{`File.new("../test_fixtures/file_test/size.gb").seek(1, 5)`, "ArgumentError: Invalid whence: 5", 1},
+		{`File.new("../test_fixtures/file_test/size.gb").gets(1)`, "ArgumentError: Expect 0 arguments. got: 1", 1},
+		{`File.new("../test_fixtures/file_test/size.gb").each_line`, "InternalError: Can't yield without a block", 1},
+		{`File.new("/tmp/out.txt", "x")`, "InternalError: Unknown file mode: x", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
@@ -312,6 +312,185 @@ func builtinFloatInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Returns the absolute value of self.
+			//
+			// ```Ruby
+			// (-1.5).abs # => 1.5
+			// ```
+			// @return [Float]
+			Name: "abs",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initFloatObject(math.Abs(receiver.(*FloatObject).value))
+				}
+			},
+		},
+		{
+			// Returns the smallest number greater than or equal to self, with the precision of the given decimal digits.
+			// Returns an Integer if digits is omitted, zero, or negative.
+			//
+			// ```Ruby
+			// 1.2.ceil        # => 2
+			// (-1.2).ceil     # => -1
+			// 1.234.ceil(2)   # => 1.24
+			// 1234.5.ceil(-2) # => 1300
+			// ```
+			// @param digits [Integer]
+			// @return [Integer, Float]
+			Name: "ceil",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return receiver.(*FloatObject).roundingOperation(t, args, math.Ceil)
+				}
+			},
+		},
+		{
+			// Returns if self is neither infinite nor NaN.
+			//
+			// ```Ruby
+			// 1.5.finite?             # => true
+			// Float::INFINITY.finite? # => false
+			// ```
+			// @return [Boolean]
+			Name: "finite?",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					v := receiver.(*FloatObject).value
+					return toBooleanObject(!math.IsInf(v, 0) && !math.IsNaN(v))
+				}
+			},
+		},
+		{
+			// Returns the largest number less than or equal to self, with the precision of the given decimal digits.
+			// Returns an Integer if digits is omitted, zero, or negative.
+			//
+			// ```Ruby
+			// 1.8.floor      # => 1
+			// (-1.2).floor   # => -2
+			// 1.236.floor(2) # => 1.23
+			// ```
+			// @param digits [Integer]
+			// @return [Integer, Float]
+			Name: "floor",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return receiver.(*FloatObject).roundingOperation(t, args, math.Floor)
+				}
+			},
+		},
+		{
+			// Returns if self is positive or negative infinity.
+			//
+			// ```Ruby
+			// Float::INFINITY.infinite? # => true
+			// 1.5.infinite?             # => false
+			// ```
+			// @return [Boolean]
+			Name: "infinite?",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return toBooleanObject(math.IsInf(receiver.(*FloatObject).value, 0))
+				}
+			},
+		},
+		{
+			// Returns if self is NaN (not a number).
+			//
+			// ```Ruby
+			// Float::NAN.nan? # => true
+			// 1.5.nan?        # => false
+			// ```
+			// @return [Boolean]
+			Name: "nan?",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return toBooleanObject(math.IsNaN(receiver.(*FloatObject).value))
+				}
+			},
+		},
+		{
+			// Returns if self is less than 0.
+			//
+			// ```Ruby
+			// (-1.5).negative? # => true
+			// 0.0.negative?    # => false
+			// ```
+			// @return [Boolean]
+			Name: "negative?",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return toBooleanObject(receiver.(*FloatObject).value < 0)
+				}
+			},
+		},
+		{
+			// Returns if self is greater than 0.
+			//
+			// ```Ruby
+			// 1.5.positive? # => true
+			// 0.0.positive? # => false
+			// ```
+			// @return [Boolean]
+			Name: "positive?",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return toBooleanObject(receiver.(*FloatObject).value > 0)
+				}
+			},
+		},
+		{
+			// Returns self rounded to the nearest value with the precision of the given decimal digits, halves are rounded away from zero.
+			// Returns an Integer if digits is omitted, zero, or negative.
+			//
+			// ```Ruby
+			// 1.5.round        # => 2
+			// (-1.5).round     # => -2
+			// 3.14159.round(2) # => 3.14
+			// 1250.0.round(-2) # => 1300
+			// ```
+			// @param digits [Integer]
+			// @return [Integer, Float]
+			Name: "round",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return receiver.(*FloatObject).roundingOperation(t, args, roundHalfAwayFromZero)
+				}
+			},
+		},
+		{
+			// Returns self truncated toward zero, with the precision of the given decimal digits.
+			// Returns an Integer if digits is omitted, zero, or negative.
+			//
+			// ```Ruby
+			// 1.8.truncate      # => 1
+			// (-1.8).truncate   # => -1
+			// 1.238.truncate(2) # => 1.23
+			// ```
+			// @param digits [Integer]
+			// @return [Integer, Float]
+			Name: "truncate",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return receiver.(*FloatObject).roundingOperation(t, args, math.Trunc)
+				}
+			},
+		},
+		{
+			// Returns if self is 0.
+			//
+			// ```Ruby
+			// 0.0.zero? # => true
+			// 0.1.zero? # => false
+			// ```
+			// @return [Boolean]
+			Name: "zero?",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return toBooleanObject(receiver.(*FloatObject).value == 0)
+				}
+			},
+		},
 		{
 			Name: "ptr",
 			Fn: func(receiver Object) builtinMethodBody {
@@ -339,6 +518,19 @@ func (vm *VM) initFloatClass() *RClass {
 	ic := vm.initializeClass(classes.FloatClass, false)
 	ic.setBuiltinMethods(builtinFloatInstanceMethods(), false)
 	ic.setBuiltinMethods(builtinFloatClassMethods(), true)
+	ic.includeModule(vm.topLevelClass(classes.ComparableModule))
+
+	floatConstants := map[string]float64{
+		"INFINITY": math.Inf(1),
+		"NAN":      math.NaN(),
+		"EPSILON":  math.Nextafter(1, 2) - 1,
+		"MAX":      math.MaxFloat64,
+	}
+
+	for name, value := range floatConstants {
+		ic.constants[name] = &Pointer{Target: &FloatObject{baseObj: &baseObj{class: ic}, value: value}}
+	}
+
 	return ic
 }
 
@@ -370,6 +562,39 @@ func (f *FloatObject) arithmeticOperation(t *thread, rightObject Object, operati
 	return t.vm.initFloatObject(result)
 }
 
+// Apply the passed rounding function with the precision of the optional digits argument.
+// The result is a Float only when the digits are positive, like `1.234.round(2)`.
+func (f *FloatObject) roundingOperation(t *thread, args []Object, rounding func(float64) float64) Object {
+	digits, err := t.roundingDigits(args)
+
+	if err != nil {
+		return err
+	}
+
+	if digits > 0 {
+		p := math.Pow10(digits)
+		return t.vm.initFloatObject(rounding(f.value*p) / p)
+	}
+
+	if math.IsInf(f.value, 0) || math.IsNaN(f.value) {
+		return t.vm.initErrorObject(errors.ArgumentError, "Can't convert %s to Integer", f.toString())
+	}
+
+	p := math.Pow10(-digits)
+	return t.vm.initIntegerObject(int(rounding(f.value/p) * p))
+}
+
+// roundHalfAwayFromZero returns the nearest integer value, halves are rounded away from zero
+func roundHalfAwayFromZero(x float64) float64 {
+	i := math.Trunc(x)
+
+	if math.Abs(x-i) >= 0.5 {
+		return i + math.Copysign(1, x)
+	}
+
+	return i
+}
+
 // Apply an equality test, returning true if the objects are considered equal,
 // and false otherwise.
 func (f *FloatObject) equalityTest(rightObject Object) bool {
@@ -404,6 +629,14 @@ func (f *FloatObject) numericComparison(t *thread, rightObject Object, operation
 // toString returns the object's value as the string format, in non
 // exponential format (straight number, without exponent `E<exp>`).
 func (f *FloatObject) toString() string {
+	if math.IsInf(f.value, 1) {
+		return "Infinity"
+	}
+
+	if math.IsInf(f.value, -1) {
+		return "-Infinity"
+	}
+
 	return strconv.FormatFloat(f.value, 'f', -1, 64)
 }
 
@@ -22,6 +22,36 @@ func TestFloatClassSuperclass(t *testing.T) {
 	}
 }
 
+func TestFloatLiteral(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`1.5.class.name`, "Float"},
+		{`1.5`, 1.5},
+		{`1e-3`, 0.001},
+		{`2.5e2 + 1`, 251.0},
+		{`1_000.25 * 2`, 2000.5},
+		{`0x1F`, 31},
+		{`0b1010 + 1_000`, 1010},
+		{`
+		def foo(x)
+		  x * 2
+		end
+
+		foo 1.5
+		`, 3.0},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestFloatArithmeticOperationWithFloat(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -212,3 +242,92 @@ func TestFloatConversions(t *testing.T) {
 		v.checkSP(t, i, 1)
 	}
 }
+
+func TestFloatRoundingMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`(-1.5).abs`, 1.5},
+		{`1.2.ceil`, 2},
+		{`(-1.2).ceil`, -1},
+		{`1.234.ceil(2)`, 1.24},
+		{`1234.5.ceil(-2)`, 1300},
+		{`1.8.floor`, 1},
+		{`(-1.2).floor`, -2},
+		{`1.236.floor(2)`, 1.23},
+		{`1250.0.floor(-2)`, 1200},
+		{`1.5.round`, 2},
+		{`(-1.5).round`, -2},
+		{`1.4.round`, 1},
+		{`2.5.round`, 3},
+		{`(-2.5).round`, -3},
+		{`0.49999999999999994.round`, 0},
+		{`3.14159.round(2)`, 3.14},
+		{`1250.0.round(-2)`, 1300},
+		{`1.8.truncate`, 1},
+		{`(-1.8).truncate`, -1},
+		{`1.238.truncate(2)`, 1.23},
+		{`1.5.round.class.name`, "Integer"},
+		{`1.5.round(1).class.name`, "Float"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestFloatPredicateMethodsAndConstants(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`1.5.finite?`, true},
+		{`Float::INFINITY.finite?`, false},
+		{`Float::NAN.finite?`, false},
+		{`Float::INFINITY.infinite?`, true},
+		{`(-Float::INFINITY).infinite?`, true},
+		{`1.5.infinite?`, false},
+		{`Float::NAN.nan?`, true},
+		{`1.5.nan?`, false},
+		{`(-1.5).negative?`, true},
+		{`0.0.negative?`, false},
+		{`1.5.positive?`, true},
+		{`0.0.positive?`, false},
+		{`0.0.zero?`, true},
+		{`0.1.zero?`, false},
+		{`Float::INFINITY.to_s`, "Infinity"},
+		{`(-Float::INFINITY).to_s`, "-Infinity"},
+		{`Float::INFINITY > Float::MAX`, true},
+		{`1.0 + Float::EPSILON > 1.0`, true},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestFloatRoundingMethodsFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`1.5.round("1")`, "TypeError: Expect argument to be Integer. got: String", 1},
+		{`1.5.floor(1, 2)`, "ArgumentError: Expect 1 arguments. got: 2", 1},
+		{`Float::INFINITY.round`, "ArgumentError: Can't convert Infinity to Integer", 1},
+		{`Float::NAN.ceil`, "ArgumentError: Can't convert NaN to Integer", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
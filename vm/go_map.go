@@ -37,8 +37,8 @@ func builtinGoMapClassMethods() []*BuiltinMethodObject {
 						return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.HashClass, args[0].Class().Name)
 					}
 
-					for k, v := range hash.Pairs {
-						m[k] = v.Value()
+					for _, p := range hash.pairs {
+						m[p.key.toString()] = p.value.Value()
 					}
 
 					return t.vm.initGoMap(m)
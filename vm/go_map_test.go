@@ -100,12 +100,12 @@ func TestGoMapToHashMethod(t *testing.T) {
 		h = { foo: "bar" }
 		m = GoMap.new(h)
 		h2 = m.to_hash
-		h2[:foo]
+		h2["foo"]
 		`, "bar"},
 		{`
 		m = GoMap.new
 		h = m.to_hash
-		h[:foo]
+		h["foo"]
 		`, nil},
 	}
 
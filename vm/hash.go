@@ -3,6 +3,8 @@ package vm
 import (
 	"bytes"
 	"fmt"
+	"hash/fnv"
+	"math"
 	"reflect"
 	"sort"
 	"strings"
@@ -19,9 +21,10 @@ import (
 //
 // - **Key:** an alphanumeric word that starts with alphabet, without containing space and punctuations.
 // Underscore `_` can also be used within the key.
-// String literal like "mickey mouse" cannot be used as a hash key.
-// The internal key is actually a String and **not a Symbol** for now (TBD).
-// Thus only a String object or a string literal should be used when referencing with `[ ]`.
+// String literal like "mickey mouse" cannot be used as a hash key in a hash literal.
+// The internal key of a literal is actually a String and **not a Symbol** for now (TBD).
+// Thus a String object or a string literal with the same name should be used when referencing with `[ ]`,
+// a Symbol is a different key.
 //
 // ```ruby
 // a = { balthazar1: 100 } # valid
@@ -29,24 +32,65 @@ import (
 // x = 'balthazar1'
 //
 // a["balthazar1"]  # => 100
+// a[:balthazar1]   # => nil
 // a[x]             # => 100
 // a[balthazar1]    # => error
 // ```
 //
 // - **value:** String literal and objects (Integer, String, Array, Hash, nil, etc) can be used.
 //
+// Any object can be used as a key with `[]=`. Keys are compared with their `hash` and `eql?` methods,
+// so a class can define both of them to make its instances usable as keys.
+//
+// ```ruby
+// h = {}
+// h[1] = "one"
+// h[[1, 2]] = "array"
+// h[1]      # => "one"
+// h[[1, 2]] # => "array"
+//
+// class Point
+//   attr_reader :x, :y
+//
+//   def initialize(x, y)
+//     @x = x
+//     @y = y
+//   end
+//
+//   def hash
+//     [@x, @y].hash
+//   end
+//
+//   def eql?(other)
+//     @x == other.x && @y == other.y
+//   end
+// end
+//
+// h[Point.new(1, 2)] = "point"
+// h[Point.new(1, 2)] # => "point"
+// ```
+//
 // **Note:**
-// - The order of key-value pairs are **not** preserved.
+// - The order of key-value pairs is the order they're inserted.
 // - Operator `=>` is not supported.
 // - `Hash.new` is not supported.
 type HashObject struct {
 	*baseObj
-	Pairs map[string]Object
+	// pairs keeps the key-value pairs in insertion order
+	pairs []*hashPair
+	// buckets groups the pairs by their keys' hash values for lookup
+	buckets map[int][]*hashPair
 
 	// See `[]` and `[]=` for the operational explanation of the default value.
 	Default Object
 }
 
+type hashPair struct {
+	key   Object
+	value Object
+	hash  int
+}
+
 // Class methods --------------------------------------------------------
 func builtinHashClassMethods() []*BuiltinMethodObject {
 	return []*BuiltinMethodObject{
@@ -93,16 +137,9 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 						return t.vm.initErrorObject(errors.ArgumentError, "Expect 1 argument. got: %d", len(args))
 					}
 
-					i := args[0]
-					key, ok := i.(*StringObject)
-
-					if !ok {
-						return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.StringClass, i.Class().Name)
-					}
-
 					h := receiver.(*HashObject)
 
-					value, ok := h.Pairs[key.value]
+					value, ok := h.get(t, args[0])
 
 					if !ok {
 						if h.Default != nil {
@@ -126,6 +163,7 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 			// h['b'] = "2"        #=> "2"
 			// h['c'] = [1, 2, 3]  #=> [1, 2, 3]
 			// h['d'] = { k: 'v' } #=> { k: 'v' }
+			// h[1] = "one"        #=> "one"
 			// ```
 			//
 			// @return [Object] The value
@@ -139,15 +177,8 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 						return t.vm.initErrorObject(errors.ArgumentError, "Expect 2 arguments. got: %d", len(args))
 					}
 
-					k := args[0]
-					key, ok := k.(*StringObject)
-
-					if !ok {
-						return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.StringClass, k.Class().Name)
-					}
-
 					h := receiver.(*HashObject)
-					h.Pairs[key.value] = args[1]
+					h.set(t, args[0], args[1])
 
 					return args[1]
 				}
@@ -192,13 +223,12 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 
 					hash := receiver.(*HashObject)
 
-					if len(hash.Pairs) == 0 {
+					if hash.length() == 0 {
 						t.callFrameStack.pop()
 					}
 
-					for stringKey, value := range hash.Pairs {
-						objectKey := t.vm.initStringObject(stringKey)
-						result := t.builtinMethodYield(blockFrame, objectKey, value)
+					for _, p := range hash.pairs {
+						result := t.builtinMethodYield(blockFrame, p.key, p.value)
 
 						booleanResult, isResultBoolean := result.Target.(*BooleanObject)
 
@@ -233,7 +263,7 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 
 					h := receiver.(*HashObject)
 
-					h.Pairs = make(map[string]Object)
+					h.clear()
 
 					return h
 				}
@@ -320,17 +350,8 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 					}
 
 					h := receiver.(*HashObject)
-					d := args[0]
-					deleteKey, ok := d.(*StringObject)
-
-					if !ok {
-						return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.StringClass, d.Class().Name)
-					}
+					h.delete(t, args[0])
 
-					deleteKeyValue := deleteKey.value
-					if _, ok := h.Pairs[deleteKeyValue]; ok {
-						delete(h.Pairs, deleteKeyValue)
-					}
 					return h
 				}
 			},
@@ -362,24 +383,23 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 
 					hash := receiver.(*HashObject)
 
-					if len(hash.Pairs) == 0 {
+					if hash.length() == 0 {
 						t.callFrameStack.pop()
 					}
 
 					// Note that from the Go specification, https://golang.org/ref/spec#For_statements,
 					// it's safe to delete elements from a Map, while iterating it.
-					for stringKey, value := range hash.Pairs {
-						objectKey := t.vm.initStringObject(stringKey)
-						result := t.builtinMethodYield(blockFrame, objectKey, value)
+					for _, p := range append([]*hashPair{}, hash.pairs...) {
+						result := t.builtinMethodYield(blockFrame, p.key, p.value)
 
 						booleanResult, isResultBoolean := result.Target.(*BooleanObject)
 
 						if isResultBoolean {
 							if booleanResult.value {
-								delete(hash.Pairs, stringKey)
+								hash.delete(t, p.key)
 							}
 						} else if result.Target != NULL {
-							delete(hash.Pairs, stringKey)
+							hash.delete(t, p.key)
 						}
 					}
 
@@ -392,10 +412,10 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 			// each step, returning nil if any intermediate step is nil.
 			//
 			// ```Ruby
-			// { a: 1 , b: 2 }.dig(:a)         # => 1
-			// { a: {}, b: 2 }.dig(:a, :b)     # => nil
-			// { a: {}, b: 2 }.dig(:a, :b, :c) # => nil
-			// { a: 1, b: 2 }.dig(:a, :b)      # => TypeError: Expect target to be Diggable
+			// { a: 1 , b: 2 }.dig("a")            # => 1
+			// { a: {}, b: 2 }.dig("a", "b")      # => nil
+			// { a: {}, b: 2 }.dig("a", "b", "c") # => nil
+			// { a: 1, b: 2 }.dig("a", "b")       # => TypeError: Expect target to be Diggable
 			// ```
 			//
 			// @return [Object]
@@ -414,7 +434,7 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 			},
 		},
 		{
-			// Calls block once for each key in the hash (in sorted key order), passing the
+			// Calls block once for each key in the hash (in insertion order), passing the
 			// key-value pair as parameters.
 			// Returns `self`.
 			//
@@ -423,8 +443,8 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 			// h.each do |k, v|
 			//   puts k.to_s + "->" + v.to_s
 			// end
-			// # => a->1
 			// # => b->2
+			// # => a->1
 			// ```
 			//
 			// @return [Hash]
@@ -441,16 +461,11 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 
 					h := receiver.(*HashObject)
 
-					if len(h.Pairs) == 0 {
+					if h.length() == 0 {
 						t.callFrameStack.pop()
 					} else {
-						keys := h.sortedKeys()
-
-						for _, k := range keys {
-							v := h.Pairs[k]
-							strK := t.vm.initStringObject(k)
-
-							t.builtinMethodYield(blockFrame, strK, v)
+						for _, p := range append([]*hashPair{}, h.pairs...) {
+							t.builtinMethodYield(blockFrame, p.key, p.value)
 						}
 					}
 
@@ -460,7 +475,7 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 		},
 		{
 			// Loop through keys of the hash with given block frame. It also returns array of
-			// keys in alphabetical order.
+			// keys in insertion order.
 			//
 			// ```Ruby
 			// h = { a: 1, b: "2", c: [1, 2, 3], d: { k: 'v' } }
@@ -487,26 +502,23 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 
 					h := receiver.(*HashObject)
 
-					if len(h.Pairs) == 0 {
+					if h.length() == 0 {
 						t.callFrameStack.pop()
 					}
 
-					keys := h.sortedKeys()
-					var arrOfKeys []Object
+					keys := h.keys()
 
 					for _, k := range keys {
-						obj := t.vm.initStringObject(k)
-						arrOfKeys = append(arrOfKeys, obj)
-						t.builtinMethodYield(blockFrame, obj)
+						t.builtinMethodYield(blockFrame, k)
 					}
 
-					return t.vm.initArrayObject(arrOfKeys)
+					return t.vm.initArrayObject(keys)
 				}
 			},
 		},
 		{
 			// Loop through values of the hash with given block frame. It also returns array of
-			// values of the hash in the alphabetical order of its key
+			// values of the hash in the insertion order of its key
 			//
 			// ```Ruby
 			// h = { a: 1, b: "2", c: [1, 2, 3], d: { k: "v" } }
@@ -532,20 +544,17 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 
 					h := receiver.(*HashObject)
 
-					if len(h.Pairs) == 0 {
+					if h.length() == 0 {
 						t.callFrameStack.pop()
 					}
 
-					keys := h.sortedKeys()
-					var arrOfValues []Object
+					values := h.values()
 
-					for _, k := range keys {
-						value := h.Pairs[k]
-						arrOfValues = append(arrOfValues, value)
-						t.builtinMethodYield(blockFrame, value)
+					for _, v := range values {
+						t.builtinMethodYield(blockFrame, v)
 					}
 
-					return t.vm.initArrayObject(arrOfValues)
+					return t.vm.initArrayObject(values)
 				}
 			},
 		},
@@ -592,7 +601,7 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 					c := args[0]
 					compare, ok := c.(*HashObject)
 
-					if ok && reflect.DeepEqual(h, compare) {
+					if ok && h.equal(t, compare) {
 						return TRUE
 					}
 					return FALSE
@@ -624,13 +633,7 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 					}
 
 					hash := receiver.(*HashObject)
-					key, ok := args[0].(*StringObject)
-
-					if !ok {
-						return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.StringClass, key.Class().Name)
-					}
-
-					value, ok := hash.Pairs[key.value]
+					value, ok := hash.get(t, args[0])
 
 					if ok {
 						if blockFrame != nil {
@@ -645,7 +648,7 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 					}
 
 					if blockFrame != nil {
-						return t.builtinMethodYield(blockFrame, key).Target
+						return t.builtinMethodYield(blockFrame, args[0]).Target
 					}
 
 					return t.vm.initErrorObject(errors.ArgumentError, "The value was not found, and no block has been provided")
@@ -653,16 +656,13 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 			},
 		},
 		{
-			// Returns true if the key exist in the hash. Currently, it can only input string
-			// type object.
+			// Returns true if the key exist in the hash.
 			//
 			// ```Ruby
 			// h = { a: 1, b: "2", c: [1, 2, 3], d: { k: "v" } }
 			// h.has_key?("a") # => true
 			// h.has_key?("e") # => false
-			// # TODO: Support Symbol Type Key Input
-			// h.has_key?(:b)  # => true
-			// h.has_key?(:f)  # => false
+			// h.has_key?(:a)  # => false
 			// ```
 			//
 			// @return [Boolean]
@@ -674,14 +674,8 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 					}
 
 					h := receiver.(*HashObject)
-					i := args[0]
-					input, ok := i.(*StringObject)
 
-					if !ok {
-						return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.StringClass, i.Class().Name)
-					}
-
-					if _, ok := h.Pairs[input.value]; ok {
+					if _, ok := h.get(t, args[0]); ok {
 						return TRUE
 					}
 					return FALSE
@@ -710,8 +704,8 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 
 					h := receiver.(*HashObject)
 
-					for _, v := range h.Pairs {
-						if reflect.DeepEqual(v, args[0]) {
+					for _, v := range h.values() {
+						if t.valueEqual(v, args[0]) {
 							return TRUE
 						}
 					}
@@ -720,11 +714,11 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 			},
 		},
 		{
-			// Returns an array of keys (in arbitrary order)
+			// Returns an array of keys (in insertion order)
 			//
 			// ```Ruby
 			// { a: 1, b: "2", c: [3, true, "Hello"] }.keys
-			// # =>  ["c", "b", "a"] or ["b", "a", "c"] ... etc
+			// # =>  ["a", "b", "c"]
 			// ```
 			//
 			// @return [Boolean]
@@ -736,11 +730,7 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 					}
 
 					h := receiver.(*HashObject)
-					var keys []Object
-					for k := range h.Pairs {
-						keys = append(keys, t.vm.initStringObject(k))
-					}
-					return t.vm.initArrayObject(keys)
+					return t.vm.initArrayObject(h.keys())
 				}
 			},
 		},
@@ -792,13 +782,13 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 
 					h := receiver.(*HashObject)
 
-					if len(h.Pairs) == 0 {
+					if h.length() == 0 {
 						t.callFrameStack.pop()
 					}
 
-					for k, v := range h.Pairs {
-						result := t.builtinMethodYield(blockFrame, v)
-						h.Pairs[k] = result.Target
+					for _, p := range append([]*hashPair{}, h.pairs...) {
+						result := t.builtinMethodYield(blockFrame, p.value)
+						p.value = result.Target
 					}
 					return h
 				}
@@ -822,9 +812,9 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 					}
 
 					h := receiver.(*HashObject)
-					result := make(map[string]Object)
-					for k, v := range h.Pairs {
-						result[k] = v
+					result := t.vm.initEmptyHashObject()
+					for _, p := range h.pairs {
+						result.set(t, p.key, p.value)
 					}
 
 					for _, obj := range args {
@@ -832,12 +822,12 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 						if !ok {
 							return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.HashClass, obj.Class().Name)
 						}
-						for k, v := range hashObj.Pairs {
-							result[k] = v
+						for _, p := range hashObj.pairs {
+							result.set(t, p.key, p.value)
 						}
 					}
 
-					return t.vm.initHashObject(result)
+					return result
 				}
 			},
 		},
@@ -873,33 +863,33 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 					}
 
 					sourceHash := receiver.(*HashObject)
-					destinationPairs := map[string]Object{}
+					destinationHash := t.vm.initEmptyHashObject()
 
-					if len(sourceHash.Pairs) == 0 {
+					if sourceHash.length() == 0 {
 						t.callFrameStack.pop()
 					}
 
-					for stringKey, value := range sourceHash.Pairs {
-						objectKey := t.vm.initStringObject(stringKey)
-						result := t.builtinMethodYield(blockFrame, objectKey, value)
+					for _, p := range append([]*hashPair{}, sourceHash.pairs...) {
+						result := t.builtinMethodYield(blockFrame, p.key, p.value)
 
 						booleanResult, isResultBoolean := result.Target.(*BooleanObject)
 
 						if isResultBoolean {
 							if booleanResult.value {
-								destinationPairs[stringKey] = value
+								destinationHash.set(t, p.key, p.value)
 							}
 						} else if result.Target != NULL {
-							destinationPairs[stringKey] = value
+							destinationHash.set(t, p.key, p.value)
 						}
 					}
 
-					return t.vm.initHashObject(destinationPairs)
+					return destinationHash
 				}
 			},
 		},
 		{
-			// Returns an array of keys (in arbitrary order)
+			// Returns an array of sorted keys. Integer and Float keys are sorted by their values
+			// and come before other keys, which are sorted by their string forms.
 			//
 			// ```Ruby
 			// { a: 1, b: "2", c: [3, true, "Hello"] }.sorted_keys
@@ -921,12 +911,7 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 					}
 
 					h := receiver.(*HashObject)
-					sortedKeys := h.sortedKeys()
-					var keys []Object
-					for _, k := range sortedKeys {
-						keys = append(keys, t.vm.initStringObject(k))
-					}
-					return t.vm.initArrayObject(keys)
+					return t.vm.initArrayObject(h.sortedKeys())
 				}
 			},
 		},
@@ -936,7 +921,9 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 			//
 			// ```Ruby
 			// { a: 1, b: 2, c: 3 }.to_a
-			// # => [["a", 1], ["c", 3], ["b", 2]] or [["b", 2], ["c", 3], ["a", 1]] ... etc
+			// # => [["a", 1], ["b", 2], ["c", 3]]
+			// { b: 1, a: 2, c: 3 }.to_a
+			// # => [["b", 1], ["a", 2], ["c", 3]]
 			// { a: 1, b: 2, c: 3 }.to_a(true)
 			// # => [["a", 1], ["b", 2], ["c", 3]]
 			// { b: 1, a: 2, c: 3 }.to_a(true)
@@ -966,21 +953,15 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 						sorted = st.value
 					}
 
-					var resultArr []Object
+					keys := h.keys()
 					if sorted {
-						for _, k := range h.sortedKeys() {
-							var pairArr []Object
-							pairArr = append(pairArr, t.vm.initStringObject(k))
-							pairArr = append(pairArr, h.Pairs[k])
-							resultArr = append(resultArr, t.vm.initArrayObject(pairArr))
-						}
-					} else {
-						for k, v := range h.Pairs {
-							var pairArr []Object
-							pairArr = append(pairArr, t.vm.initStringObject(k))
-							pairArr = append(pairArr, v)
-							resultArr = append(resultArr, t.vm.initArrayObject(pairArr))
-						}
+						keys = h.sortedKeys()
+					}
+
+					var resultArr []Object
+					for _, k := range keys {
+						v, _ := h.get(t, k)
+						resultArr = append(resultArr, t.vm.initArrayObject([]Object{k, v}))
 					}
 					return t.vm.initArrayObject(resultArr)
 				}
@@ -995,6 +976,17 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 			// puts(h) #=> {"a":1,"b":[1, "2", [4, 5, null], {"foo":"bar"}]}
 			// ```
 			//
+			// JSON keys are strings, so keys are converted with `to_s`. When several keys have the same
+			// string form, like `1` and `"1"`, the JSON key is placed where the first one is and
+			// has the value of the last one:
+			//
+			// ```Ruby
+			// h = { a: 1 }
+			// h[1] = "one"
+			// h["1"] = "str"
+			// h.to_json #=> {"a":1,"1":"str"}
+			// ```
+			//
 			// @return [String]
 			Name: "to_json",
 			Fn: func(receiver Object) builtinMethodBody {
@@ -1017,6 +1009,14 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 			// puts(h) #=> "{ a: 1, b: [1, \"2\", [4, 5, null], { foo: \"bar \" }] }"
 			// ```
 			//
+			// Keys that can't be written in a hash literal are quoted and shown with `=>`:
+			//
+			// ```Ruby
+			// h = {}
+			// h["mickey mouse"] = 1
+			// h.to_s #=> "{ \"mickey mouse\" => 1 }"
+			// ```
+			//
 			// @return [String]
 			Name: "to_s",
 			Fn: func(receiver Object) builtinMethodBody {
@@ -1058,25 +1058,25 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 
 					h := receiver.(*HashObject)
 
-					if len(h.Pairs) == 0 {
+					if h.length() == 0 {
 						t.callFrameStack.pop()
 					}
 
-					resultHash := make(map[string]Object)
-					for k, v := range h.Pairs {
-						result := t.builtinMethodYield(blockFrame, v)
-						resultHash[k] = result.Target
+					resultHash := t.vm.initEmptyHashObject()
+					for _, p := range append([]*hashPair{}, h.pairs...) {
+						result := t.builtinMethodYield(blockFrame, p.value)
+						resultHash.set(t, p.key, result.Target)
 					}
-					return t.vm.initHashObject(resultHash)
+					return resultHash
 				}
 			},
 		},
 		{
-			// Returns an array of values (in arbitrary order)
+			// Returns an array of values (in insertion order)
 			//
 			// ```Ruby
-			// { a: 1, b: "2", c: [3, true, "Hello"] }.keys
-			// # =>  [1, "2", [3, true, "Hello"]] or ["2", [3, true, "Hello"], 1] ... etc
+			// { a: 1, b: "2", c: [3, true, "Hello"] }.values
+			// # =>  [1, "2", [3, true, "Hello"]]
 			// ```
 			//
 			// @return [Boolean]
@@ -1088,11 +1088,7 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 					}
 
 					h := receiver.(*HashObject)
-					var keys []Object
-					for _, v := range h.Pairs {
-						keys = append(keys, v)
-					}
-					return t.vm.initArrayObject(keys)
+					return t.vm.initArrayObject(h.values())
 				}
 			},
 		},
@@ -1111,13 +1107,7 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 					var result []Object
 
 					for _, objectKey := range args {
-						stringObjectKey, ok := objectKey.(*StringObject)
-
-						if !ok {
-							return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.StringClass, objectKey.Class().Name)
-						}
-
-						value, ok := hash.Pairs[stringObjectKey.value]
+						value, ok := hash.get(t, objectKey)
 
 						if !ok {
 							value = NULL
@@ -1137,10 +1127,28 @@ func builtinHashInstanceMethods() []*BuiltinMethodObject {
 
 // Functions for initialization -----------------------------------------
 
+// initHashObject creates a hash with String keys, the pairs are inserted in the order of their keys
 func (vm *VM) initHashObject(pairs map[string]Object) *HashObject {
+	h := vm.initEmptyHashObject()
+	keys := []string{}
+
+	for k := range pairs {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		h.set(nil, vm.initStringObject(k), pairs[k])
+	}
+
+	return h
+}
+
+func (vm *VM) initEmptyHashObject() *HashObject {
 	return &HashObject{
 		baseObj: &baseObj{class: vm.topLevelClass(classes.HashClass)},
-		Pairs:   pairs,
+		buckets: map[int][]*hashPair{},
 	}
 }
 
@@ -1148,14 +1156,21 @@ func (vm *VM) initHashClass() *RClass {
 	hc := vm.initializeClass(classes.HashClass, false)
 	hc.setBuiltinMethods(builtinHashInstanceMethods(), false)
 	hc.setBuiltinMethods(builtinHashClassMethods(), true)
+	hc.includeModule(vm.topLevelClass(classes.EnumerableModule))
 	return hc
 }
 
 // Polymorphic helper functions -----------------------------------------
 
-// Value returns the object
+// Value returns the pairs of the hash as a map, keys are converted into strings
 func (h *HashObject) Value() interface{} {
-	return h.Pairs
+	pairs := map[string]Object{}
+
+	for _, p := range h.pairs {
+		pairs[p.key.toString()] = p.value
+	}
+
+	return pairs
 }
 
 // toString returns the object's name as the string format
@@ -1163,12 +1178,12 @@ func (h *HashObject) toString() string {
 	var out bytes.Buffer
 	var pairs []string
 
-	for _, key := range h.sortedKeys() {
-		// TODO: Improve this conditional statement
-		if _, isString := h.Pairs[key].(*StringObject); isString {
-			pairs = append(pairs, fmt.Sprintf("%s: \"%s\"", key, h.Pairs[key].toString()))
+	for _, p := range h.pairs {
+		// String keys that are valid literal keys are shown like a hash literal, other keys are shown with `=>`
+		if k, ok := p.key.(*StringObject); ok && isLiteralKey(k.value) {
+			pairs = append(pairs, fmt.Sprintf("%s: %s", k.value, hashElementString(p.value)))
 		} else {
-			pairs = append(pairs, fmt.Sprintf("%s: %s", key, h.Pairs[key].toString()))
+			pairs = append(pairs, fmt.Sprintf("%s => %s", hashElementString(p.key), hashElementString(p.value)))
 		}
 	}
 
@@ -1183,11 +1198,21 @@ func (h *HashObject) toString() string {
 func (h *HashObject) toJSON() string {
 	var out bytes.Buffer
 	var values []string
-	pairs := h.Pairs
 	out.WriteString("{")
 
-	for key, value := range pairs {
-		values = append(values, generateJSONFromPair(key, value))
+	// Keys with the same string form collide in JSON, the last one's value is used
+	indexes := make(map[string]int)
+
+	for _, p := range h.pairs {
+		key := p.key.toString()
+
+		if i, ok := indexes[key]; ok {
+			values[i] = generateJSONFromPair(key, p.value)
+			continue
+		}
+
+		indexes[key] = len(values)
+		values = append(values, generateJSONFromPair(key, p.value))
 	}
 
 	out.WriteString(strings.Join(values, ","))
@@ -1197,30 +1222,63 @@ func (h *HashObject) toJSON() string {
 
 // Returns the length of the hash
 func (h *HashObject) length() int {
-	return len(h.Pairs)
+	return len(h.pairs)
 }
 
-// Returns the sorted keys of the hash
-func (h *HashObject) sortedKeys() []string {
-	var arr []string
-	for k := range h.Pairs {
-		arr = append(arr, k)
+// Returns the keys of the hash in insertion order
+func (h *HashObject) keys() []Object {
+	keys := []Object{}
+
+	for _, p := range h.pairs {
+		keys = append(keys, p.key)
 	}
-	sort.Strings(arr)
-	return arr
+
+	return keys
 }
 
-// Returns the duplicate of the Hash object
-func (h *HashObject) copy() Object {
-	elems := map[string]Object{}
+// Returns the values of the hash in insertion order
+func (h *HashObject) values() []Object {
+	values := []Object{}
 
-	for k, v := range h.Pairs {
-		elems[k] = v
+	for _, p := range h.pairs {
+		values = append(values, p.value)
 	}
 
+	return values
+}
+
+// Returns the sorted keys of the hash. Integer and Float keys are sorted by their values and come first,
+// others are sorted by their string forms.
+func (h *HashObject) sortedKeys() []Object {
+	keys := h.keys()
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		a, isNumA := toFloat(keys[i])
+		b, isNumB := toFloat(keys[j])
+
+		switch {
+		case isNumA && isNumB:
+			return a < b
+		case isNumA != isNumB:
+			return isNumA
+		}
+
+		return keys[i].toString() < keys[j].toString()
+	})
+
+	return keys
+}
+
+// Returns the duplicate of the Hash object
+func (h *HashObject) copy() Object {
 	newHash := &HashObject{
 		baseObj: &baseObj{class: h.class},
-		Pairs:   elems,
+		buckets: map[int][]*hashPair{},
+		Default: h.Default,
+	}
+
+	for _, p := range h.pairs {
+		newHash.insert(p.hash, p.key, p.value)
 	}
 
 	return newHash
@@ -1229,14 +1287,8 @@ func (h *HashObject) copy() Object {
 // recursive indexed access - see ArrayObject#dig documentation.
 func (h *HashObject) dig(t *thread, keys []Object) Object {
 	currentKey := keys[0]
-	stringCurrentKey, ok := currentKey.(*StringObject)
-
-	if !ok {
-		return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.StringClass, currentKey.Class().Name)
-	}
-
 	nextKeys := keys[1:]
-	currentValue, ok := h.Pairs[stringCurrentKey.value]
+	currentValue, ok := h.get(t, currentKey)
 
 	if !ok {
 		return NULL
@@ -1255,8 +1307,252 @@ func (h *HashObject) dig(t *thread, keys []Object) Object {
 	return diggableCurrentValue.dig(t, nextKeys)
 }
 
+// get returns the value of the given key.
+// The thread is only used for calling keys' own `hash` and `eql?` methods, so it can be nil if the key is a built-in object.
+func (h *HashObject) get(t *thread, key Object) (Object, bool) {
+	if p := h.find(t, key); p != nil {
+		return p.value, true
+	}
+
+	return nil, false
+}
+
+// getByName returns the value of the given String key, it doesn't need a thread
+func (h *HashObject) getByName(name string) (Object, bool) {
+	for _, p := range h.buckets[hashString(name)] {
+		if k, ok := p.key.(*StringObject); ok && k.value == name {
+			return p.value, true
+		}
+	}
+
+	return nil, false
+}
+
+// set assigns the value to the given key, a new key is appended to the end of the hash
+func (h *HashObject) set(t *thread, key, value Object) {
+	if p := h.find(t, key); p != nil {
+		p.value = value
+		return
+	}
+
+	h.insert(t.hashOf(key), key, value)
+}
+
+// delete removes the given key from the hash and returns its value
+func (h *HashObject) delete(t *thread, key Object) (Object, bool) {
+	p := h.find(t, key)
+
+	if p == nil {
+		return nil, false
+	}
+
+	bucket := h.buckets[p.hash]
+
+	for i, bp := range bucket {
+		if bp == p {
+			h.buckets[p.hash] = append(bucket[:i:i], bucket[i+1:]...)
+			break
+		}
+	}
+
+	if len(h.buckets[p.hash]) == 0 {
+		delete(h.buckets, p.hash)
+	}
+
+	for i, hp := range h.pairs {
+		if hp == p {
+			h.pairs = append(h.pairs[:i:i], h.pairs[i+1:]...)
+			break
+		}
+	}
+
+	return p.value, true
+}
+
+// clear removes all the pairs from the hash
+func (h *HashObject) clear() {
+	h.pairs = nil
+	h.buckets = map[int][]*hashPair{}
+}
+
+func (h *HashObject) find(t *thread, key Object) *hashPair {
+	for _, p := range h.buckets[t.hashOf(key)] {
+		if t.objectEql(p.key, key) {
+			return p
+		}
+	}
+
+	return nil
+}
+
+func (h *HashObject) insert(hash int, key, value Object) {
+	p := &hashPair{key: key, value: value, hash: hash}
+	h.pairs = append(h.pairs, p)
+	h.buckets[hash] = append(h.buckets[hash], p)
+}
+
+// equal returns true if both hashes have the same keys, and the values of the same keys are equal
+func (h *HashObject) equal(t *thread, other *HashObject) bool {
+	if h.length() != other.length() {
+		return false
+	}
+
+	for _, p := range h.pairs {
+		v, ok := other.get(t, p.key)
+
+		if !ok || !t.objectEql(p.value, v) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Other helper functions ----------------------------------------------
 
+// hashOf returns the hash value of the object.
+// Instances of Goby classes can define their own `hash` method, which should return an Integer.
+func (t *thread) hashOf(obj Object) int {
+	if o, ok := obj.(*RObject); ok {
+		if m, ok := o.findMethod("hash").(*MethodObject); ok {
+			if i, ok := t.callMethod(o, m, nil).(*IntegerObject); ok {
+				return i.value
+			}
+		}
+	}
+
+	return t.builtinHashOf(obj)
+}
+
+// builtinHashOf returns the hash value of built-in objects, other objects are hashed by their identities
+func (t *thread) builtinHashOf(obj Object) int {
+	switch o := obj.(type) {
+	case *StringObject:
+		return hashString(o.value)
+	case *SymbolObject:
+		return hashString(o.value)
+	case *IntegerObject:
+		return o.value
+	case *FloatObject:
+		return int(math.Float64bits(o.value))
+	case *BooleanObject:
+		if o.value {
+			return 1
+		}
+		return 0
+	case *NullObject:
+		return -1
+	case *RangeObject:
+		return o.Start*31 + o.End
+	case *ArrayObject:
+		h := 7
+		for _, e := range o.Elements {
+			h = h*31 + t.hashOf(e)
+		}
+		return h
+	case *HashObject:
+		// The order of pairs doesn't matter
+		h := 0
+		for _, p := range o.pairs {
+			h += t.hashOf(p.key)*31 ^ t.hashOf(p.value)
+		}
+		return h
+	default:
+		return obj.id()
+	}
+}
+
+// objectEql returns true if both objects are the same key of a hash.
+// Instances of Goby classes can define their own `eql?` method.
+func (t *thread) objectEql(a, b Object) bool {
+	if o, ok := a.(*RObject); ok {
+		if m, ok := o.findMethod("eql?").(*MethodObject); ok {
+			switch r := t.callMethod(o, m, nil, b).(type) {
+			case *BooleanObject:
+				return r.value
+			case *NullObject, *Error:
+				return false
+			default:
+				return true
+			}
+		}
+	}
+
+	return t.builtinEql(a, b)
+}
+
+// builtinEql compares built-in objects by their values, other objects are compared by their identities
+func (t *thread) builtinEql(a, b Object) bool {
+	switch x := a.(type) {
+	case *StringObject:
+		y, ok := b.(*StringObject)
+		return ok && x.value == y.value
+	case *IntegerObject:
+		y, ok := b.(*IntegerObject)
+		return ok && x.value == y.value
+	case *FloatObject:
+		y, ok := b.(*FloatObject)
+		return ok && x.value == y.value
+	case *RangeObject:
+		y, ok := b.(*RangeObject)
+		return ok && x.Start == y.Start && x.End == y.End
+	case *ArrayObject:
+		y, ok := b.(*ArrayObject)
+
+		if !ok || len(x.Elements) != len(y.Elements) {
+			return false
+		}
+
+		for i, e := range x.Elements {
+			if !t.objectEql(e, y.Elements[i]) {
+				return false
+			}
+		}
+
+		return true
+	case *HashObject:
+		y, ok := b.(*HashObject)
+		return ok && x.equal(t, y)
+	default:
+		return a == b
+	}
+}
+
+// valueEqual is used by `==`. Arrays and Hashes are compared by their elements, so the order of hash pairs doesn't matter.
+func (t *thread) valueEqual(a, b Object) bool {
+	return reflect.DeepEqual(a, b) || t.builtinEql(a, b)
+}
+
+func hashString(s string) int {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return int(h.Sum64())
+}
+
+// isLiteralKey reports whether the key can be written as `key:` in a hash literal
+func isLiteralKey(key string) bool {
+	for i, r := range key {
+		if !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || r == '_' || i > 0 && '0' <= r && r <= '9') {
+			return false
+		}
+	}
+
+	return key != ""
+}
+
+// hashElementString returns the string format of the key or the value in Hash#to_s
+func hashElementString(obj Object) string {
+	// TODO: Improve this conditional statement
+	switch v := obj.(type) {
+	case *StringObject:
+		return fmt.Sprintf("\"%s\"", v.toString())
+	case *SymbolObject:
+		return v.inspect()
+	default:
+		return v.toString()
+	}
+}
+
 // Return the JSON style strings of the Hash object
 func generateJSONFromPair(key string, v Object) string {
 	var data string
@@ -1269,3 +1565,15 @@ func generateJSONFromPair(key string, v Object) string {
 
 	return out.String()
 }
+
+// toFloat returns the value of an Integer or a Float as float64
+func toFloat(obj Object) (float64, bool) {
+	switch o := obj.(type) {
+	case *IntegerObject:
+		return float64(o.value), true
+	case *FloatObject:
+		return o.value, true
+	}
+
+	return 0, false
+}
@@ -38,8 +38,10 @@ func TestEvalHashExpression(t *testing.T) {
 		t.Fatalf("Expect evaluated value to be a hash. got: %T", evaluated)
 	}
 
-	for key, value := range h.Pairs {
-		switch key {
+	for _, p := range h.pairs {
+		value := p.value
+
+		switch p.key.toString() {
 		case "foo":
 			testIntegerObject(t, 0, value, 123)
 		case "bar":
@@ -66,19 +68,19 @@ func TestHashAccessOperation(t *testing.T) {
 		`, nil},
 		{`
 			{ foo123: 100 }[:foo123]
-		`, 100},
+		`, nil},
 		{`
 			{}["foo"]
 		`, nil},
 		{`
 			{ bar: "foo" }[:bar]
-		`, "foo"},
+		`, nil},
 		{`
 			{ bar: "foo" }["bar"]
 		`, "foo"},
 		{`
 			{ foo: 2, bar: "foo" }[:foo]
-		`, 2},
+		`, nil},
 		{`
 			{ foo: 2, bar: "foo" }["foo"]
 		`, 2},
@@ -182,8 +184,6 @@ func TestHashAccessWithDefaultOperation(t *testing.T) {
 func TestHashAccessOperationFail(t *testing.T) {
 	testsFail := []errorTestCase{
 		{`{ a: 1, b: 2 }[]`, "ArgumentError: Expect 1 argument. got: 0", 1},
-		{`{ a: 1, b: 2 }[true]`, "TypeError: Expect argument to be String. got: Boolean", 1},
-		{`{ a: 1, b: 2 }[true] = 1`, "TypeError: Expect argument to be String. got: Boolean", 1},
 	}
 
 	for i, tt := range testsFail {
@@ -195,6 +195,166 @@ func TestHashAccessOperationFail(t *testing.T) {
 	}
 }
 
+func TestHashArbitraryKeys(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		h = {}
+		h[1] = "one"
+		h[1]
+		`, "one"},
+		{`
+		h = {}
+		h[1] = "one"
+		h[1.0]
+		`, nil},
+		{`
+		h = {}
+		h[[1, "a"]] = 10
+		h[[1, "a"]]
+		`, 10},
+		{`
+		h = {}
+		h[{ a: 1, b: 2 }] = 10
+		h[{ b: 2, a: 1 }]
+		`, 10},
+		{`
+		h = {}
+		h[true] = 1
+		h[nil] = 2
+		h[true] + h[nil]
+		`, 3},
+		{`
+		h = {}
+		h[:a] = 1
+		h["a"] = 2
+		h.length.to_s + h[:a].to_s + h["a"].to_s
+		`, "212"},
+		{`
+		h = {}
+		h[1..3] = "range"
+		h[1..3]
+		`, "range"},
+		{`
+		h = { a: 1 }
+		h[2] = 3
+		h.has_key?(2)
+		`, true},
+		{`
+		h = { a: 1 }
+		h[2] = 3
+		h.delete(2)
+		h.length
+		`, 1},
+		{`
+		h = {}
+		h[[1, 2]] = 3
+		h.fetch([1, 2])
+		`, 3},
+		{`
+		h = {}
+		h[1] = { b: "c" }
+		h.dig(1, "b")
+		`, "c"},
+		{`
+		h = { a: 1 }
+		h[2] = "b"
+		h[[3]] = :c
+		h.to_s
+		`, `{ a: 1, 2 => "b", [3] => :c }`},
+		{`
+		class Foo; end
+
+		h = {}
+		h[Foo.new] = 1
+		h[Foo.new]
+		`, nil},
+		{`
+		class Foo; end
+
+		foo = Foo.new
+		h = {}
+		h[foo] = 1
+		h[foo]
+		`, 1},
+		{`
+		class Point
+		  attr_reader :x, :y
+
+		  def initialize(x, y)
+		    @x = x
+		    @y = y
+		  end
+
+		  def hash
+		    [@x, @y].hash
+		  end
+
+		  def eql?(other)
+		    @x == other.x && @y == other.y
+		  end
+		end
+
+		h = {}
+		h[Point.new(1, 2)] = "point"
+		h[Point.new(1, 2)] = "same point"
+		h.length.to_s + h[Point.new(1, 2)]
+		`, "1same point"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestHashInsertionOrder(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []interface{}
+	}{
+		{`{ c: 1, a: 2, b: 3 }.keys`, []interface{}{"c", "a", "b"}},
+		{`{ c: 1, a: 2, b: 3 }.values`, []interface{}{1, 2, 3}},
+		{`
+		h = { c: 1 }
+		h[3] = 2
+		h["a"] = 3
+		h.keys
+		`, []interface{}{"c", 3, "a"}},
+		{`
+		h = { c: 1, a: 2, b: 3 }
+		h.delete("c")
+		h["c"] = 4
+		h.keys
+		`, []interface{}{"a", "b", "c"}},
+		{`
+		h = { c: 1, a: 2 }
+		h["c"] = 3
+		h.values
+		`, []interface{}{3, 2}},
+		{`{ b: 1, a: 2 }.merge({ c: 3, b: 4 }).values`, []interface{}{4, 2, 3}},
+		{`
+		h = { b: 1, a: 2 }
+		h[10] = 3
+		h[2] = 4
+		h.sorted_keys
+		`, []interface{}{2, 10, "a", "b"}},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		testArrayObject(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestHashComparisonOperation(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -434,8 +594,6 @@ func TestHashDeleteMethodFail(t *testing.T) {
 	testsFail := []errorTestCase{
 		{`{ a: 1, b: "Hello", c: true }.delete`, "ArgumentError: Expect 1 argument. got: 0", 1},
 		{`{ a: 1, b: "Hello", c: true }.delete("a", "b")`, "ArgumentError: Expect 1 argument. got: 2", 1},
-		{`{ a: 1, b: "Hello", c: true }.delete(123)`, "TypeError: Expect argument to be String. got: Integer", 1},
-		{`{ a: 1, b: "Hello", c: true }.delete(true)`, "TypeError: Expect argument to be String. got: Boolean", 1},
 	}
 
 	for i, tt := range testsFail {
@@ -507,13 +665,13 @@ func TestHashDigMethod(t *testing.T) {
 		expected interface{}
 	}{
 		{`
-			{ a: 1, b: 2 }.dig(:a)
+			{ a: 1, b: 2 }.dig("a")
 		`, 1},
 		{`
-			{ a: {}, b: 2 }.dig(:a, :b)
+			{ a: {}, b: 2 }.dig("a", "b")
 		`, nil},
 		{`
-			{ a: {}, b: 2 }.dig(:a, :b, :c)
+			{ a: {}, b: 2 }.dig("a", "b", "c")
 		`, nil},
 	}
 
@@ -529,7 +687,7 @@ func TestHashDigMethod(t *testing.T) {
 func TestHashDigMethodFail(t *testing.T) {
 	testsFail := []errorTestCase{
 		{`{ a: [], b: 2 }.dig`, "ArgumentError: Expected 1+ arguments, got 0", 1},
-		{`{ a: 1, b: 2 }.dig(:a, :b)`, "TypeError: Expect target to be Diggable, got Integer", 1},
+		{`{ a: 1, b: 2 }.dig("a", "b")`, "TypeError: Expect target to be Diggable, got Integer", 1},
 	}
 
 	for i, tt := range testsFail {
@@ -576,7 +734,7 @@ func TestHashEachMethod(t *testing.T) {
 				output.push([k, v])
 			end
 			output
-		`, [][]interface{}{{"a", 1}, {"b", "2"}}},
+		`, [][]interface{}{{"b", "2"}, {"a", 1}}},
 	}
 
 	for i, tt := range tests2 {
@@ -618,7 +776,7 @@ func TestHashEachKeyMethod(t *testing.T) {
 			{ b: "Hello", c: "World", a: "Goby" }.each_key do |key|
 			  # Empty Block
 			end
-		`, []interface{}{"a", "b", "c"}},
+		`, []interface{}{"b", "c", "a"}},
 		{`
 			{ b: "Hello", c: "World", b: "Goby" }.each_key do |key|
 			  # Empty Block
@@ -681,7 +839,7 @@ func TestHashEachValueMethod(t *testing.T) {
 			{ b: "Hello", c: 123, a: true }.each_value do |v|
 			  # Empty Block
 			end
-		`, []interface{}{true, "Hello", 123}},
+		`, []interface{}{"Hello", 123, true}},
 		{`
 			{ a: "Hello", b: 123, a: true }.each_value do |v|
 			  # Empty Block
@@ -903,8 +1061,6 @@ func TestHashHasKeyMethodFail(t *testing.T) {
 	testsFail := []errorTestCase{
 		{`{ a: 1, b: 2 }.has_key?`, "ArgumentError: Expect 1 argument. got: 0", 1},
 		{`{ a: 1, b: 2 }.has_key?(true, { hello: "World" })`, "ArgumentError: Expect 1 argument. got: 2", 1},
-		{`{ a: 1, b: 2 }.has_key?(true)`, "TypeError: Expect argument to be String. got: Boolean", 1},
-		{`{ a: 1, b: 2 }.has_key?(123)`, "TypeError: Expect argument to be String. got: Integer", 1},
 	}
 
 	for i, tt := range testsFail {
@@ -1132,8 +1288,10 @@ func TestHashMergeMethod(t *testing.T) {
 			t.Fatalf("Expect evaluated value to be a hash. got: %T", evaluated)
 		}
 
-		for key, value := range h.Pairs {
-			switch key {
+		for _, p := range h.pairs {
+			value := p.value
+
+			switch p.key.toString() {
 			case "a":
 				testStringObject(t, i, value, "Hello")
 			case "b":
@@ -1484,6 +1642,34 @@ func TestHashToJSONMethodWithBasicTypes(t *testing.T) {
 	}
 }
 
+func TestHashToJSONMethodWithCollidingKeys(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		h = { a: 1 }
+		h[1] = "one"
+		h["1"] = "str"
+		h.to_json
+		`, `{"a":1,"1":"str"}`},
+		{`
+		h = { a: 1 }
+		h[:a] = 2
+		h[:b] = 3
+		h.to_json
+		`, `{"a":2,"b":3}`},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
 func TestHashToJSONMethodFail(t *testing.T) {
 	testsFail := []errorTestCase{
 		{`{ a: 1, b: 2 }.to_json(123)`, "ArgumentError: Expect 0 argument. got: 1", 1},
@@ -1506,6 +1692,14 @@ func TestHashToStringMethod(t *testing.T) {
 	}{
 		{`{ a: 1 }.to_s`, "{ a: 1 }"},
 		{`{ a: 1, b: "Hello" }.to_s`, "{ a: 1, b: \"Hello\" }"},
+		{`
+		h = { a1: 1 }
+		h["a1"] = 2
+		h["1"] = 3
+		h["a b"] = 4
+		h[""] = 5
+		h.to_s
+		`, "{ a1: 2, \"1\" => 3, \"a b\" => 4, \"\" => 5 }"},
 		{`{ a: 1, b: [1, true, "Hello", 1..2], c: { lang: "Goby" } }.to_s`, "{ a: 1, b: [1, true, \"Hello\", (1..2)], c: { lang: \"Goby\" } }"},
 	}
 
@@ -1689,7 +1883,6 @@ func TestHashValuesAtMethod(t *testing.T) {
 
 func TestHashValuesAtMethodFail(t *testing.T) {
 	testsFail := []errorTestCase{
-		{`{ a: 1, b: 2 }.values_at(123)`, "TypeError: Expect argument to be String. got: Integer", 1},
 	}
 
 	for i, tt := range testsFail {
@@ -146,7 +146,7 @@ func builtinHTTPClassMethods() []*BuiltinMethodObject {
 					ret := t.vm.initHashObject(map[string]Object{})
 
 					for k, v := range resp.Header {
-						ret.Pairs[k] = t.vm.initStringObject(strings.Join(v, " "))
+						ret.set(t, t.vm.initStringObject(k), t.vm.initStringObject(strings.Join(v, " ")))
 					}
 
 					return ret
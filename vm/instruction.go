@@ -2,20 +2,21 @@ package vm
 
 import (
 	"fmt"
+	"time"
+
 	"github.com/goby-lang/goby/compiler/bytecode"
 	"github.com/goby-lang/goby/vm/classes"
 	"github.com/goby-lang/goby/vm/errors"
-	"strings"
 )
 
 type operation func(t *thread, cf *callFrame, args ...interface{})
 
-type operationType = string
+type operationType = bytecode.Opcode
 
 type setType = string
 
 type action struct {
-	name      string
+	name      operationType
 	operation operation
 }
 
@@ -29,6 +30,7 @@ type instruction struct {
 
 type instructionSet struct {
 	name         string
+	isType       setType
 	instructions []*instruction
 	filename     filename
 	paramTypes   *bytecode.ArgSet
@@ -40,7 +42,8 @@ func (is *instructionSet) define(line int, a *action, params ...interface{}) *in
 	return i
 }
 
-var builtinActions = map[operationType]*action{
+// builtinActions is the jump table of the instructions' actions, indexed by their opcodes
+var builtinActions = [bytecode.OpcodeCount]*action{
 	bytecode.Pop: {
 		name: bytecode.Pop,
 		operation: func(t *thread, cf *callFrame, args ...interface{}) {
@@ -73,13 +76,13 @@ var builtinActions = map[operationType]*action{
 				return
 			}
 
-			c.isNamespace = args[1].(string) == "true"
-
 			if t.stack.top() != nil && t.stack.top().isNamespace {
 				t.stack.pop()
 			}
 
-			t.stack.push(c)
+			// The constant's pointer is shared, so the flag is set on a new pointer.
+			// Otherwise the same constant pushed earlier, like the receiver of `Math.sqrt(Math::PI)`, would be popped as a namespace.
+			t.stack.push(&Pointer{Target: c.Target, isNamespace: args[1].(bool)})
 		},
 	},
 	bytecode.GetLocal: {
@@ -259,15 +262,19 @@ var builtinActions = map[operationType]*action{
 		name: bytecode.NewHash,
 		operation: func(t *thread, cf *callFrame, args ...interface{}) {
 			argCount := args[0].(int)
-			pairs := map[string]Object{}
+			pairs := make([]*Pointer, argCount)
 
-			for i := 0; i < argCount/2; i++ {
-				v := t.stack.pop()
-				k := t.stack.pop()
-				pairs[k.Target.(*StringObject).value] = v.Target
+			// Pairs are popped in reverse order
+			for i := argCount - 1; i >= 0; i-- {
+				pairs[i] = t.stack.pop()
+			}
+
+			hash := t.vm.initEmptyHashObject()
+
+			for i := 0; i < argCount; i += 2 {
+				hash.set(t, pairs[i].Target, pairs[i+1].Target)
 			}
 
-			hash := t.vm.initHashObject(pairs)
 			t.stack.push(&Pointer{Target: hash})
 		},
 	},
@@ -332,8 +339,25 @@ var builtinActions = map[operationType]*action{
 	bytecode.PutString: {
 		name: bytecode.PutString,
 		operation: func(t *thread, cf *callFrame, args ...interface{}) {
-			object := t.vm.initObjectFromGoType(args[0])
-			t.stack.push(&Pointer{Target: object})
+			t.stack.push(&Pointer{Target: t.vm.initStringObject(args[0].(string))})
+		},
+	},
+	bytecode.PutSymbol: {
+		name: bytecode.PutSymbol,
+		operation: func(t *thread, cf *callFrame, args ...interface{}) {
+			t.stack.push(&Pointer{Target: t.vm.initSymbolObject(args[0].(string))})
+		},
+	},
+	bytecode.PutRegexp: {
+		name: bytecode.PutRegexp,
+		operation: func(t *thread, cf *callFrame, args ...interface{}) {
+			flags := ""
+
+			if len(args) > 1 {
+				flags = args[1].(string)
+			}
+
+			t.stack.push(&Pointer{Target: t.vm.initRegexpObject(args[0].(string), flags)})
 		},
 	},
 	bytecode.PutNull: {
@@ -346,22 +370,18 @@ var builtinActions = map[operationType]*action{
 		name: bytecode.DefMethod,
 		operation: func(t *thread, cf *callFrame, args ...interface{}) {
 			argCount := args[0].(int)
+			is := args[1].(*instructionSet)
 			methodName := t.stack.pop().Target.(*StringObject).value
-			is, ok := t.getMethodIS(methodName, cf.instructionSet.filename)
-
-			if !ok {
-				t.returnError(errors.InternalError, "Can't get method %s's instruction set.", methodName)
-				return
-			}
-
 			method := &MethodObject{Name: methodName, argc: argCount, instructionSet: is, baseObj: &baseObj{class: t.vm.topLevelClass(classes.MethodClass)}}
 
 			v := t.stack.pop().Target
 			switch self := v.(type) {
 			case *RClass:
-				self.Methods.set(methodName, method)
+				// The visibility is set by calling `private`, `protected` or `public` without arguments in the class body
+				method.visibility = cf.visibility
+				self.setMethod(methodName, method)
 			default:
-				self.Class().Methods.set(methodName, method)
+				self.Class().setMethod(methodName, method)
 			}
 		},
 	},
@@ -369,18 +389,18 @@ var builtinActions = map[operationType]*action{
 		name: bytecode.DefSingletonMethod,
 		operation: func(t *thread, cf *callFrame, args ...interface{}) {
 			argCount := args[0].(int)
+			is := args[1].(*instructionSet)
 			methodName := t.stack.pop().Target.(*StringObject).value
-			is, _ := t.getMethodIS(methodName, cf.instructionSet.filename)
 			method := &MethodObject{Name: methodName, argc: argCount, instructionSet: is, baseObj: &baseObj{class: t.vm.topLevelClass(classes.MethodClass)}}
 
 			v := t.stack.pop().Target
 
 			switch v := v.(type) {
 			case *RClass:
-				v.SingletonClass().Methods.set(methodName, method)
+				v.SingletonClass().setMethod(methodName, method)
 			default:
 				singletonClass := t.vm.createRClass(fmt.Sprintf("#<Class:#<%s:%s>>", v.Class().Name, v.id()))
-				singletonClass.Methods.set(methodName, method)
+				singletonClass.setMethod(methodName, method)
 				singletonClass.isSingleton = true
 				v.SetSingletonClass(singletonClass)
 			}
@@ -389,17 +409,18 @@ var builtinActions = map[operationType]*action{
 	bytecode.DefClass: {
 		name: bytecode.DefClass,
 		operation: func(t *thread, cf *callFrame, args ...interface{}) {
-			subject := strings.Split(args[0].(string), ":")
-			subjectType, subjectName := subject[0], subject[1]
+			isModule := args[0].(bool)
+			subjectName := args[1].(string)
+			is := args[2].(*instructionSet)
 
 			classPtr := cf.lookupConstant(subjectName)
 
 			if classPtr == nil {
-				class := t.vm.initializeClass(subjectName, subjectType == "module")
+				class := t.vm.initializeClass(subjectName, isModule)
 				classPtr = cf.storeConstant(class.Name, class)
 
-				if len(args) >= 2 {
-					superClassName := args[1].(string)
+				if len(args) >= 4 {
+					superClassName := args[3].(string)
 					superClass := t.vm.lookupConstant(cf, superClassName)
 					inheritedClass, ok := superClass.Target.(*RClass)
 
@@ -417,8 +438,6 @@ var builtinActions = map[operationType]*action{
 				}
 			}
 
-			is := t.getClassIS(subjectName, cf.instructionSet.filename)
-
 			t.stack.pop()
 			c := newCallFrame(is)
 			c.self = classPtr.Target
@@ -433,9 +452,17 @@ var builtinActions = map[operationType]*action{
 		operation: func(t *thread, cf *callFrame, args ...interface{}) {
 			var method Object
 
+			var blockProc Object
+
 			methodName := args[0].(string)
 			argCount := args[1].(int)
-			argSet := args[3].(*bytecode.ArgSet)
+			block := args[2].(*instructionSet)
+			argSet := args[4].(*bytecode.ArgSet)
+
+			// Block is given by a Proc object, like `foo(&blk)`
+			if args[3].(bool) {
+				blockProc = t.stack.pop().Target
+			}
 
 			if arr, ok := t.stack.top().Target.(*ArrayObject); ok && arr.splat {
 				// Pop array
@@ -451,7 +478,11 @@ var builtinActions = map[operationType]*action{
 			receiverPr := argPr - 1
 			receiver := t.stack.Data[receiverPr].Target
 
-			method = receiver.findMethod(methodName)
+			method = args[6].(*methodCache).lookup(receiver, methodName)
+
+			if method == nil {
+				method, argCount, argSet = t.methodMissing(receiver, methodName, argPr, argCount)
+			}
 
 			if method == nil {
 				err := t.vm.initErrorObject(errors.UndefinedMethodError, "Undefined Method '%+v' for %+v", methodName, receiver.toString())
@@ -460,7 +491,30 @@ var builtinActions = map[operationType]*action{
 				return
 			}
 
-			blockFrame := t.retrieveBlock(cf, args)
+			if err := t.checkMethodVisibility(cf, receiver, method, methodName, args[5].(bool)); err != nil {
+				t.stack.set(receiverPr, &Pointer{Target: err})
+				t.sp = argPr
+				return
+			}
+
+			var blockFrame *callFrame
+
+			switch b := blockProc.(type) {
+			case nil:
+				blockFrame = t.retrieveBlock(cf, block)
+			case *ProcObject:
+				blockFrame = t.retrieveProcBlock(b)
+			case *SymbolObject:
+				// Symbol is converted into a Proc by Symbol#to_proc, like `map(&:to_s)`
+				toProc := b.findMethod("to_proc").(*BuiltinMethodObject)
+				blockFrame = t.retrieveProcBlock(toProc.Fn(b)(t, []Object{}, nil).(*ProcObject))
+			case *NullObject:
+			default:
+				err := t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.ProcClass, b.Class().Name)
+				t.stack.set(receiverPr, &Pointer{Target: err})
+				t.sp = argPr
+				return
+			}
 
 			switch m := method.(type) {
 			case *MethodObject:
@@ -472,6 +526,17 @@ var builtinActions = map[operationType]*action{
 			}
 		},
 	},
+	bytecode.GetBlock: {
+		name: bytecode.GetBlock,
+		operation: func(t *thread, cf *callFrame, args ...interface{}) {
+			if cf.blockFrame == nil {
+				t.stack.push(&Pointer{Target: NULL})
+				return
+			}
+
+			t.stack.push(&Pointer{Target: t.vm.initProcObject(cf.blockFrame, false)})
+		},
+	},
 	bytecode.InvokeBlock: {
 		name: bytecode.InvokeBlock,
 		operation: func(t *thread, cf *callFrame, args ...interface{}) {
@@ -518,8 +583,22 @@ var builtinActions = map[operationType]*action{
 			c.ep = blockFrame.ep
 			c.self = receiver
 
-			for i := 0; i < argCount; i++ {
-				c.locals[i] = t.stack.Data[argPr+i]
+			if params := blockFrame.instructionSet.paramTypes; hasSpecialBlockParams(params) {
+				args := []Object{}
+
+				for i := 0; i < argCount; i++ {
+					args = append(args, t.stack.Data[argPr+i].Target)
+				}
+
+				for i, arg := range t.blockParamArgs(params, args, nil) {
+					if arg != nil {
+						c.insertLCL(i, 0, arg)
+					}
+				}
+			} else {
+				for i := 0; i < argCount; i++ {
+					c.locals[i] = t.stack.Data[argPr+i]
+				}
 			}
 
 			t.callFrameStack.push(c)
@@ -529,6 +608,61 @@ var builtinActions = map[operationType]*action{
 			t.sp = receiverPr + 1
 		},
 	},
+	bytecode.PushHandler: {
+		name: bytecode.PushHandler,
+		operation: func(t *thread, cf *callFrame, args ...interface{}) {
+			cf.handlers = append(cf.handlers, &errorHandler{pc: args[0].(int), sp: t.sp})
+		},
+	},
+	bytecode.PopHandler: {
+		name: bytecode.PopHandler,
+		operation: func(t *thread, cf *callFrame, args ...interface{}) {
+			cf.handlers = cf.handlers[:len(cf.handlers)-1]
+		},
+	},
+	bytecode.MatchError: {
+		name: bytecode.MatchError,
+		operation: func(t *thread, cf *callFrame, args ...interface{}) {
+			classCount := args[0].(int)
+			classes := make([]Object, classCount)
+
+			for i := classCount - 1; i >= 0; i-- {
+				classes[i] = t.stack.pop().Target
+			}
+
+			err := t.stack.top().Target
+
+			for _, c := range classes {
+				class, ok := c.(*RClass)
+
+				if !ok {
+					t.returnError(errors.TypeError, "class or module required for rescue clause. got: %s", c.Class().Name)
+					return
+				}
+
+				if err.Class() == class || err.Class().alreadyInherit(class) {
+					t.stack.push(&Pointer{Target: TRUE})
+					return
+				}
+			}
+
+			t.stack.push(&Pointer{Target: FALSE})
+		},
+	},
+	bytecode.Throw: {
+		name: bytecode.Throw,
+		operation: func(t *thread, cf *callFrame, args ...interface{}) {
+			err, ok := t.stack.pop().Target.(*Error)
+
+			if !ok {
+				t.returnError(errors.TypeError, "exception object expected")
+				return
+			}
+
+			err.raised = true
+			t.stack.push(&Pointer{Target: err})
+		},
+	},
 	bytecode.Leave: {
 		name: bytecode.Leave,
 		operation: func(t *thread, cf *callFrame, args ...interface{}) {
@@ -560,6 +694,8 @@ func (vm *VM) initObjectFromGoType(value interface{}) Object {
 	switch v := value.(type) {
 	case nil:
 		return NULL
+	case Object:
+		return v
 	case int:
 		return vm.initIntegerObject(v)
 	case int64:
@@ -591,6 +727,10 @@ func (vm *VM) initObjectFromGoType(value interface{}) Object {
 		}
 
 		return FALSE
+	case time.Time:
+		return vm.initTimeObject(v)
+	case time.Duration:
+		return vm.initDurationObject(v)
 	case []interface{}:
 		var objs []Object
 
@@ -3,27 +3,23 @@ package vm
 import (
 	"fmt"
 	"github.com/goby-lang/goby/compiler/bytecode"
-	"strconv"
+	"strings"
 )
 
 // instructionTranslator is responsible for parsing bytecodes
 type instructionTranslator struct {
-	vm         *VM
-	line       int
-	setTable   map[setType]map[string][]*instructionSet
-	blockTable map[string]*instructionSet
-	filename   filename
-	program    *instructionSet
+	vm       *VM
+	line     int
+	filename filename
+	program  *instructionSet
+	// sets maps the compiled instruction sets to the translated ones, so instructions can refer to method, class and block bodies directly
+	sets map[*bytecode.InstructionSet]*instructionSet
 }
 
 // newInstructionTranslator initializes instructionTranslator and its instruction set table then returns it
 func newInstructionTranslator(file filename) *instructionTranslator {
 	it := &instructionTranslator{filename: file}
-	it.blockTable = make(map[string]*instructionSet)
-	it.setTable = map[setType]map[string][]*instructionSet{
-		bytecode.MethodDef: make(map[string][]*instructionSet),
-		bytecode.ClassDef:  make(map[string][]*instructionSet),
-	}
+	it.sets = make(map[*bytecode.InstructionSet]*instructionSet)
 
 	return it
 }
@@ -33,49 +29,37 @@ func (it *instructionTranslator) setMetadata(is *instructionSet, set *bytecode.I
 	n := set.Name()
 
 	is.name = n
+	is.isType = t
 
-	switch t {
-	case bytecode.Program:
+	if t == bytecode.Program {
 		it.program = is
-	case bytecode.Block:
-		it.blockTable[n] = is
-	default:
-		it.setTable[t][n] = append(it.setTable[t][n], is)
 	}
 }
 
-func (it *instructionTranslator) parseParam(param string) interface{} {
-	integer, e := strconv.ParseInt(param, 0, 64)
-	if e != nil {
-		return param
-	}
-
-	i := int(integer)
-
-	return i
-}
-
 func (it *instructionTranslator) transferInstructionSets(sets []*bytecode.InstructionSet) []*instructionSet {
 	iss := []*instructionSet{}
 
+	// Instruction sets are created before translating any instruction, because an instruction can refer to a body compiled after it
 	for _, set := range sets {
-		it.transferInstructionSet(iss, set)
+		is := &instructionSet{filename: it.filename}
+		it.setMetadata(is, set)
+		it.sets[set] = is
+		iss = append(iss, is)
+	}
+
+	for _, set := range sets {
+		it.transferInstructionSet(it.sets[set], set)
 	}
 
 	return iss
 }
 
-func (it *instructionTranslator) transferInstructionSet(iss []*instructionSet, set *bytecode.InstructionSet) {
-	is := &instructionSet{filename: it.filename}
-	it.setMetadata(is, set)
-
+func (it *instructionTranslator) transferInstructionSet(is *instructionSet, set *bytecode.InstructionSet) {
 	for _, i := range set.Instructions {
 		it.transferInstruction(is, i)
 	}
 
 	is.paramTypes = set.ArgTypes()
-
-	iss = append(iss, is)
 }
 
 // transferInstruction transfer a bytecode.Instruction into an vm instruction and append it into given instruction set.
@@ -83,16 +67,23 @@ func (it *instructionTranslator) transferInstruction(is *instructionSet, i *byte
 	var params []interface{}
 	act := i.Action
 
-	action := builtinActions[act]
-
-	if action == nil {
+	if act >= bytecode.OpcodeCount || builtinActions[act] == nil {
 		panic(fmt.Sprintf("Unknown command: %s. line: %d", act, i.Line()))
 	}
 
+	action := builtinActions[act]
+
 	switch act {
-	case bytecode.PutString:
-		params = append(params, i.Params[0])
-	case bytecode.BranchUnless, bytecode.BranchIf, bytecode.Jump:
+	case bytecode.PutObject:
+		switch v := i.Params[0].(type) {
+		case float64:
+			params = append(params, it.vm.initFloatObject(v))
+		case bool:
+			params = append(params, toBooleanObject(v))
+		default:
+			params = append(params, v)
+		}
+	case bytecode.BranchUnless, bytecode.BranchIf, bytecode.Jump, bytecode.PushHandler:
 		line, err := i.AnchorLine()
 
 		if err != nil {
@@ -100,17 +91,39 @@ func (it *instructionTranslator) transferInstruction(is *instructionSet, i *byte
 		}
 
 		params = append(params, line)
+	case bytecode.DefMethod, bytecode.DefSingletonMethod:
+		params = append(params, i.Params[0], it.body(i))
+	case bytecode.DefClass:
+		// Class subject is like "class:Foo" or "module:Foo"
+		subject := strings.Split(i.Params[0].(string), ":")
+		params = append(params, subject[0] == "module", subject[1], it.body(i))
+
+		if len(i.Params) > 1 {
+			params = append(params, i.Params[1])
+		}
 	case bytecode.Send:
-		for _, param := range i.Params {
-			params = append(params, it.parseParam(param))
+		var block *instructionSet
+
+		if i.Body != nil {
+			block = it.body(i)
 		}
-		params = append(params, i.ArgSet)
+
+		params = append(params, i.Params[0], i.Params[1], block, i.Params[2] == bytecode.ProcBlock, i.ArgSet, i.SelfCall, &methodCache{})
 	default:
-		for _, param := range i.Params {
-			params = append(params, it.parseParam(param))
-		}
+		params = append(params, i.Params...)
 	}
 
 	vmI := is.define(i.Line(), action, params...)
 	vmI.sourceLine = i.SourceLine()
 }
+
+// body returns the translated instruction set of the method, class or block that the instruction refers to
+func (it *instructionTranslator) body(i *bytecode.Instruction) *instructionSet {
+	is, ok := it.sets[i.Body]
+
+	if !ok {
+		panic(fmt.Sprintf("Can't find the body of %s. line: %d", i.Action, i.Line()))
+	}
+
+	return is
+}
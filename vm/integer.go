@@ -644,6 +644,163 @@ func builtinIntegerInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Returns the absolute value of self.
+			//
+			// ```Ruby
+			// (-10).abs # => 10
+			// ```
+			// @return [Integer]
+			Name: "abs",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					i := receiver.(*IntegerObject)
+
+					if i.value < 0 {
+						return t.vm.initIntegerObject(-i.value)
+					}
+
+					return i
+				}
+			},
+		},
+		{
+			// Returns the smallest number greater than or equal to self, with the precision of the given negative decimal digits.
+			// Returns self if digits is omitted or not negative.
+			//
+			// ```Ruby
+			// 1234.ceil     # => 1234
+			// 1234.ceil(-2) # => 1300
+			// ```
+			// @param digits [Integer]
+			// @return [Integer]
+			Name: "ceil",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return receiver.(*IntegerObject).roundingOperation(t, args, func(q, r, p int) int {
+						if r > 0 {
+							return q + 1
+						}
+
+						return q
+					})
+				}
+			},
+		},
+		{
+			// Returns the largest number less than or equal to self, with the precision of the given negative decimal digits.
+			// Returns self if digits is omitted or not negative.
+			//
+			// ```Ruby
+			// 1278.floor(-2)    # => 1200
+			// (-1278).floor(-2) # => -1300
+			// ```
+			// @param digits [Integer]
+			// @return [Integer]
+			Name: "floor",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return receiver.(*IntegerObject).roundingOperation(t, args, func(q, r, p int) int {
+						if r < 0 {
+							return q - 1
+						}
+
+						return q
+					})
+				}
+			},
+		},
+		{
+			// Returns if self is less than 0.
+			//
+			// ```Ruby
+			// (-1).negative? # => true
+			// 0.negative?    # => false
+			// ```
+			// @return [Boolean]
+			Name: "negative?",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return toBooleanObject(receiver.(*IntegerObject).value < 0)
+				}
+			},
+		},
+		{
+			// Returns if self is greater than 0.
+			//
+			// ```Ruby
+			// 1.positive? # => true
+			// 0.positive? # => false
+			// ```
+			// @return [Boolean]
+			Name: "positive?",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return toBooleanObject(receiver.(*IntegerObject).value > 0)
+				}
+			},
+		},
+		{
+			// Returns self rounded to the nearest value with the precision of the given negative decimal digits, halves are rounded away from zero.
+			// Returns self if digits is omitted or not negative.
+			//
+			// ```Ruby
+			// 1250.round(-2)    # => 1300
+			// (-1250).round(-2) # => -1300
+			// 1249.round(-2)    # => 1200
+			// ```
+			// @param digits [Integer]
+			// @return [Integer]
+			Name: "round",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return receiver.(*IntegerObject).roundingOperation(t, args, func(q, r, p int) int {
+						switch {
+						case r*2 >= p:
+							return q + 1
+						case r*2 <= -p:
+							return q - 1
+						}
+
+						return q
+					})
+				}
+			},
+		},
+		{
+			// Returns self truncated toward zero with the precision of the given negative decimal digits.
+			// Returns self if digits is omitted or not negative.
+			//
+			// ```Ruby
+			// 1278.truncate(-2)    # => 1200
+			// (-1278).truncate(-2) # => -1200
+			// ```
+			// @param digits [Integer]
+			// @return [Integer]
+			Name: "truncate",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return receiver.(*IntegerObject).roundingOperation(t, args, func(q, r, p int) int {
+						return q
+					})
+				}
+			},
+		},
+		{
+			// Returns if self is 0.
+			//
+			// ```Ruby
+			// 0.zero? # => true
+			// 1.zero? # => false
+			// ```
+			// @return [Boolean]
+			Name: "zero?",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return toBooleanObject(receiver.(*IntegerObject).value == 0)
+				}
+			},
+		},
 		{
 			Name: "ptr",
 			Fn: func(receiver Object) builtinMethodBody {
@@ -672,6 +829,7 @@ func (vm *VM) initIntegerClass() *RClass {
 	ic := vm.initializeClass(classes.IntegerClass, false)
 	ic.setBuiltinMethods(builtinIntegerInstanceMethods(), false)
 	ic.setBuiltinMethods(builtinIntegerClassMethods(), true)
+	ic.includeModule(vm.topLevelClass(classes.ComparableModule))
 	return ic
 }
 
@@ -714,6 +872,28 @@ func (i *IntegerObject) arithmeticOperation(
 	}
 }
 
+// Apply the passed rounding function with the precision of the optional negative digits argument.
+// The function receives the quotient and the remainder of self divided by 10**-digits, and returns the rounded quotient.
+func (i *IntegerObject) roundingOperation(t *thread, args []Object, rounding func(q, r, p int) int) Object {
+	digits, err := t.roundingDigits(args)
+
+	if err != nil {
+		return err
+	}
+
+	if digits >= 0 {
+		return i
+	}
+
+	// 10**19 overflows, and every Integer is rounded to 0 with such precision
+	if digits < -18 {
+		return t.vm.initIntegerObject(0)
+	}
+
+	p := int(math.Pow10(-digits))
+	return t.vm.initIntegerObject(rounding(i.value/p, i.value%p, p) * p)
+}
+
 // Apply an equality test, returning true if the objects are considered equal,
 // and false otherwise.
 // See comment on numericComparison().
@@ -777,3 +957,18 @@ func (i *IntegerObject) toJSON() string {
 func (i *IntegerObject) equal(e *IntegerObject) bool {
 	return i.value == e.value
 }
+
+// roundingDigits returns the optional digits argument of rounding methods like `round`, which is 0 by default
+func (t *thread) roundingDigits(args []Object) (int, *Error) {
+	if len(args) == 0 {
+		return 0, nil
+	}
+
+	digits, err := t.integerArgs(args, 1)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return digits[0], nil
+}
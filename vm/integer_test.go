@@ -344,3 +344,56 @@ func TestIntegerTimesMethodFail(t *testing.T) {
 		v.checkSP(t, i, 1)
 	}
 }
+
+func TestIntegerRoundingAndPredicateMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`(-10).abs`, 10},
+		{`10.abs`, 10},
+		{`1234.ceil`, 1234},
+		{`1234.ceil(2)`, 1234},
+		{`1234.ceil(-2)`, 1300},
+		{`(-1234).ceil(-2)`, -1200},
+		{`1278.floor(-2)`, 1200},
+		{`(-1278).floor(-2)`, -1300},
+		{`1200.floor(-2)`, 1200},
+		{`1250.round(-2)`, 1300},
+		{`1249.round(-2)`, 1200},
+		{`(-1250).round(-2)`, -1300},
+		{`(-1249).round(-2)`, -1200},
+		{`1278.truncate(-2)`, 1200},
+		{`(-1278).truncate(-2)`, -1200},
+		{`1234.round(-20)`, 0},
+		{`(-1).negative?`, true},
+		{`0.negative?`, false},
+		{`1.positive?`, true},
+		{`0.positive?`, false},
+		{`0.zero?`, true},
+		{`1.zero?`, false},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestIntegerRoundingMethodsFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`1234.round(1.5)`, "TypeError: Expect argument to be Integer. got: Float", 1},
+		{`1234.ceil(1, 2)`, "ArgumentError: Expect 1 arguments. got: 2", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
@@ -0,0 +1,355 @@
+package vm
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/goby-lang/goby/vm/classes"
+	"github.com/goby-lang/goby/vm/errors"
+)
+
+// MatchDataObject represents the result of a regexp match, it's returned by `Regexp#match` and `String#match`.
+// Group 0 is the whole match, and the groups in the pattern are numbered from 1.
+// Named groups can be accessed by their names as well.
+//
+// ```ruby
+// m = /(?<key>\w+)=(\d+)/.match("size: width=10")
+// m[0]          # => "width=10"
+// m[2]          # => "10"
+// m[:key]       # => "width"
+// m.pre_match   # => "size: "
+// m.captures    # => ["width", "10"]
+// ```
+//
+type MatchDataObject struct {
+	*baseObj
+	regexp *RegexpObject
+	str    string
+	// indexes are the byte offsets of the groups' beginnings and ends, like the result of `FindStringSubmatchIndex`
+	indexes []int
+}
+
+// Class methods --------------------------------------------------------
+func builtinMatchDataClassMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			Name: "new",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.unsupportedMethodError("#new", receiver)
+				}
+			},
+		},
+	}
+}
+
+// Instance methods -----------------------------------------------------
+func builtinMatchDataInstanceMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			// Returns the string of the group with the given index or name, or nil if the group didn't match.
+			//
+			// ```ruby
+			// m = /(?<key>\w+)=(\d+)/.match("width=10")
+			// m[0]      # => "width=10"
+			// m[2]      # => "10"
+			// m["key"]  # => "width"
+			// m[:key]   # => "width"
+			// ```
+			//
+			// @param group [Integer/String]
+			// @return [String]
+			Name: "[]",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					m := receiver.(*MatchDataObject)
+					i, err := m.groupIndex(t, args[0])
+					if err != nil {
+						return err
+					}
+
+					if i < 0 {
+						return NULL
+					}
+
+					return m.group(t, i)
+				}
+			},
+		},
+		{
+			// Returns the character index where the group begins, or nil if the group didn't match.
+			//
+			// ```ruby
+			// /(\d+)/.match("ab12").begin(1) # => 2
+			// ```
+			//
+			// @param group [Integer/String]
+			// @return [Integer]
+			Name: "begin",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return receiver.(*MatchDataObject).offset(t, args, 0)
+				}
+			},
+		},
+		{
+			// Returns the strings of the groups except the whole match.
+			//
+			// ```ruby
+			// /(\d+)-(\d+)/.match("12-34").captures # => ["12", "34"]
+			// ```
+			//
+			// @return [Array]
+			Name: "captures",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					m := receiver.(*MatchDataObject)
+					return t.vm.initArrayObject(m.groups(t)[1:])
+				}
+			},
+		},
+		{
+			// Returns the character index where the group ends, or nil if the group didn't match.
+			//
+			// ```ruby
+			// /(\d+)/.match("ab12c").end(1) # => 4
+			// ```
+			//
+			// @param group [Integer/String]
+			// @return [Integer]
+			Name: "end",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return receiver.(*MatchDataObject).offset(t, args, 1)
+				}
+			},
+		},
+		{
+			// Returns the number of groups, including the whole match.
+			//
+			// ```ruby
+			// /(\d+)-(\d+)/.match("12-34").length # => 3
+			// ```
+			//
+			// @return [Integer]
+			Name: "length",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initIntegerObject(len(receiver.(*MatchDataObject).indexes) / 2)
+				}
+			},
+		},
+		{
+			// Returns a hash of the named groups' names and strings.
+			//
+			// ```ruby
+			// /(?<key>\w+)=(?<value>\d+)/.match("width=10").named_captures
+			// # => { "key": "width", "value": "10" }
+			// ```
+			//
+			// @return [Hash]
+			Name: "named_captures",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					m := receiver.(*MatchDataObject)
+					h := t.vm.initEmptyHashObject()
+
+					for i, name := range m.regexp.regexp.SubexpNames() {
+						if len(name) > 0 {
+							h.set(t, t.vm.initStringObject(name), m.group(t, i))
+						}
+					}
+
+					return h
+				}
+			},
+		},
+		{
+			// Returns the part of the string after the match.
+			//
+			// ```ruby
+			// /\d+/.match("ab12cd").post_match # => "cd"
+			// ```
+			//
+			// @return [String]
+			Name: "post_match",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					m := receiver.(*MatchDataObject)
+					return t.vm.initStringObject(m.str[m.indexes[1]:])
+				}
+			},
+		},
+		{
+			// Returns the part of the string before the match.
+			//
+			// ```ruby
+			// /\d+/.match("ab12cd").pre_match # => "ab"
+			// ```
+			//
+			// @return [String]
+			Name: "pre_match",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					m := receiver.(*MatchDataObject)
+					return t.vm.initStringObject(m.str[:m.indexes[0]])
+				}
+			},
+		},
+		{
+			// Returns the strings of all groups, including the whole match.
+			//
+			// ```ruby
+			// /(\d+)-(\d+)/.match("12-34").to_a # => ["12-34", "12", "34"]
+			// ```
+			//
+			// @return [Array]
+			Name: "to_a",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initArrayObject(receiver.(*MatchDataObject).groups(t))
+				}
+			},
+		},
+		{
+			// Returns the whole matched string.
+			//
+			// ```ruby
+			// /\d+/.match("ab12cd").to_s # => "12"
+			// ```
+			//
+			// @return [String]
+			Name: "to_s",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initStringObject(receiver.toString())
+				}
+			},
+		},
+	}
+}
+
+// Internal functions ===================================================
+
+// Functions for initialization -----------------------------------------
+
+// initMatchDataObject returns the MatchData of the regexp's first match in the string, or nil if there's no match
+func (vm *VM) initMatchDataObject(r *RegexpObject, s string) Object {
+	indexes := r.regexp.FindStringSubmatchIndex(s)
+
+	if indexes == nil {
+		return NULL
+	}
+
+	return &MatchDataObject{
+		baseObj: &baseObj{class: vm.topLevelClass(classes.MatchDataClass)},
+		regexp:  r,
+		str:     s,
+		indexes: indexes,
+	}
+}
+
+func (vm *VM) initMatchDataClass() *RClass {
+	mc := vm.initializeClass(classes.MatchDataClass, false)
+	mc.setBuiltinMethods(builtinMatchDataInstanceMethods(), false)
+	mc.setBuiltinMethods(builtinMatchDataClassMethods(), true)
+	return mc
+}
+
+// Polymorphic helper functions -----------------------------------------
+
+// Value returns the matched strings of all groups
+func (m *MatchDataObject) Value() interface{} {
+	values := []string{}
+
+	for i := 0; i < len(m.indexes)/2; i++ {
+		if m.indexes[2*i] >= 0 {
+			values = append(values, m.str[m.indexes[2*i]:m.indexes[2*i+1]])
+		} else {
+			values = append(values, "")
+		}
+	}
+
+	return values
+}
+
+// toString returns the whole matched string
+func (m *MatchDataObject) toString() string {
+	return m.str[m.indexes[0]:m.indexes[1]]
+}
+
+// toJSON returns the whole matched string as a JSON string
+func (m *MatchDataObject) toJSON() string {
+	return fmt.Sprintf("%q", m.toString())
+}
+
+// group returns the string of the group, or nil if the group didn't match
+func (m *MatchDataObject) group(t *thread, i int) Object {
+	if m.indexes[2*i] < 0 {
+		return NULL
+	}
+
+	return t.vm.initStringObject(m.str[m.indexes[2*i]:m.indexes[2*i+1]])
+}
+
+// groups returns the strings of all groups
+func (m *MatchDataObject) groups(t *thread) []Object {
+	groups := []Object{}
+
+	for i := 0; i < len(m.indexes)/2; i++ {
+		groups = append(groups, m.group(t, i))
+	}
+
+	return groups
+}
+
+// groupIndex returns the index of the group given by an Integer or a name, or -1 if there's no such group
+func (m *MatchDataObject) groupIndex(t *thread, group Object) (int, *Error) {
+	if i, ok := group.(*IntegerObject); ok {
+		if i.value < 0 || i.value >= len(m.indexes)/2 {
+			return -1, nil
+		}
+
+		return i.value, nil
+	}
+
+	name, ok := toName(group)
+	if !ok {
+		return 0, t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, "Integer or String", group.Class().Name)
+	}
+
+	for i, n := range m.regexp.regexp.SubexpNames() {
+		if i > 0 && n == name {
+			return i, nil
+		}
+	}
+
+	return 0, t.vm.initErrorObject(errors.ArgumentError, "Undefined group name: %s", name)
+}
+
+// offset returns the character index where the group begins (side 0) or ends (side 1)
+func (m *MatchDataObject) offset(t *thread, args []Object, side int) Object {
+	if len(args) != 1 {
+		return t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, 1, len(args))
+	}
+
+	i, err := m.groupIndex(t, args[0])
+	if err != nil {
+		return err
+	}
+
+	if i < 0 {
+		return t.vm.initErrorObject(errors.ArgumentError, "Index %d out of matches", args[0].(*IntegerObject).value)
+	}
+
+	pos := m.indexes[2*i+side]
+	if pos < 0 {
+		return NULL
+	}
+
+	return t.vm.initIntegerObject(utf8.RuneCountInString(m.str[:pos]))
+}
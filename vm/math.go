@@ -0,0 +1,199 @@
+package vm
+
+import (
+	"math"
+
+	"github.com/goby-lang/goby/vm/classes"
+	"github.com/goby-lang/goby/vm/errors"
+)
+
+// Math module provides basic trigonometric and transcendental functions, which are backed by Go's `math` package.
+// All functions accept Integer and Float, and return Float.
+//
+// ```ruby
+// Math.sqrt(16)         # => 4.0
+// Math.hypot(3, 4)      # => 5.0
+// Math.sin(Math::PI / 2) # => 1.0
+// Math.log(8, 2)        # => 3.0
+// ```
+//
+// Functions with one argument are `acos`, `asin`, `atan`, `cbrt`, `cos`, `exp`, `log2`, `log10`, `sin`, `sqrt` and `tan`.
+// Arguments out of the function's domain raise an ArgumentError, like `Math.sqrt(-1)`.
+//
+// Constants:
+// - `Math::PI`
+// - `Math::E`
+
+// mathFunction is a function of the Math module, domain checks if the argument is valid for the function
+type mathFunction struct {
+	name   string
+	fn     func(float64) float64
+	domain func(float64) bool
+}
+
+var mathFunctions = []mathFunction{
+	{name: "acos", fn: math.Acos, domain: func(x float64) bool { return x >= -1 && x <= 1 }},
+	{name: "asin", fn: math.Asin, domain: func(x float64) bool { return x >= -1 && x <= 1 }},
+	{name: "atan", fn: math.Atan},
+	{name: "cbrt", fn: math.Cbrt},
+	{name: "cos", fn: math.Cos},
+	{name: "exp", fn: math.Exp},
+	{name: "log2", fn: math.Log2, domain: func(x float64) bool { return x >= 0 }},
+	{name: "log10", fn: math.Log10, domain: func(x float64) bool { return x >= 0 }},
+	{name: "sin", fn: math.Sin},
+	{name: "sqrt", fn: math.Sqrt, domain: func(x float64) bool { return x >= 0 }},
+	{name: "tan", fn: math.Tan},
+}
+
+// Module methods --------------------------------------------------------
+func builtinMathModuleMethods() []*BuiltinMethodObject {
+	methods := []*BuiltinMethodObject{
+		{
+			// Returns the arc tangent of y/x in radians, the signs of both arguments are used to determine the quadrant.
+			//
+			// ```ruby
+			// Math.atan2(1, 1) # => 0.7853981633974483
+			// ```
+			//
+			// @param y [Numeric], x [Numeric]
+			// @return [Float]
+			Name: "atan2",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					values, err := t.floatArgs(args, 2)
+
+					if err != nil {
+						return err
+					}
+
+					return t.vm.initFloatObject(math.Atan2(values[0], values[1]))
+				}
+			},
+		},
+		{
+			// Returns sqrt(x**2 + y**2), the hypotenuse of a right-angle triangle.
+			//
+			// ```ruby
+			// Math.hypot(3, 4) # => 5.0
+			// ```
+			//
+			// @param x [Numeric], y [Numeric]
+			// @return [Float]
+			Name: "hypot",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					values, err := t.floatArgs(args, 2)
+
+					if err != nil {
+						return err
+					}
+
+					return t.vm.initFloatObject(math.Hypot(values[0], values[1]))
+				}
+			},
+		},
+		{
+			// Returns the natural logarithm of the number, or the logarithm in the given base.
+			//
+			// ```ruby
+			// Math.log(Math::E) # => 1.0
+			// Math.log(8, 2)    # => 3.0
+			// ```
+			//
+			// @param number [Numeric], base [Numeric]
+			// @return [Float]
+			Name: "log",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) < 1 || len(args) > 2 {
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect 1 or 2 arguments. got: %d", len(args))
+					}
+
+					values, err := t.floatArgs(args, len(args))
+
+					if err != nil {
+						return err
+					}
+
+					for _, v := range values {
+						if v < 0 {
+							return t.vm.initErrorObject(errors.ArgumentError, "Numerical argument is out of domain - log")
+						}
+					}
+
+					result := math.Log(values[0])
+
+					if len(values) == 2 {
+						result /= math.Log(values[1])
+					}
+
+					return t.vm.initFloatObject(result)
+				}
+			},
+		},
+	}
+
+	for _, f := range mathFunctions {
+		methods = append(methods, f.builtinMethod())
+	}
+
+	return methods
+}
+
+// Internal functions ===================================================
+
+// Functions for initialization -----------------------------------------
+
+// initMathModule needs Float class to initialize its constants
+func (vm *VM) initMathModule() *RClass {
+	mm := vm.initializeClass(classes.MathModule, true)
+	mm.setBuiltinMethods(builtinMathModuleMethods(), true)
+	mm.constants["PI"] = &Pointer{Target: vm.initFloatObject(math.Pi)}
+	mm.constants["E"] = &Pointer{Target: vm.initFloatObject(math.E)}
+	return mm
+}
+
+// Other helper functions ----------------------------------------------
+
+// builtinMethod returns the method which calls the function with the only Numeric argument
+func (f mathFunction) builtinMethod() *BuiltinMethodObject {
+	return &BuiltinMethodObject{
+		Name: f.name,
+		Fn: func(receiver Object) builtinMethodBody {
+			return func(t *thread, args []Object, blockFrame *callFrame) Object {
+				x, err := t.numericArg(args)
+
+				if err != nil {
+					return err
+				}
+
+				if f.domain != nil && !f.domain(x) {
+					return t.vm.initErrorObject(errors.ArgumentError, "Numerical argument is out of domain - %s", f.name)
+				}
+
+				return t.vm.initFloatObject(f.fn(x))
+			}
+		},
+	}
+}
+
+// floatArgs returns the values of the given number of Numeric arguments
+func (t *thread) floatArgs(args []Object, count int) ([]float64, *Error) {
+	if len(args) != count {
+		return nil, t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, count, len(args))
+	}
+
+	values := []float64{}
+
+	for _, arg := range args {
+		v, ok := toFloat(arg)
+
+		if !ok {
+			return nil, t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, "Numeric", arg.Class().Name)
+		}
+
+		values = append(values, v)
+	}
+
+	return values, nil
+}
@@ -0,0 +1,62 @@
+package vm
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMathModule(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`Math.name`, "Math"},
+		{`Math::PI`, math.Pi},
+		{`Math::E`, math.E},
+		{`Math.sqrt(16)`, 4.0},
+		{`Math.sqrt(2.25)`, 1.5},
+		{`Math.cbrt(27)`, 3.0},
+		{`Math.sin(0)`, 0.0},
+		{`Math.cos(0)`, 1.0},
+		{`Math.tan(0)`, 0.0},
+		{`Math.asin(1)`, math.Pi / 2},
+		{`Math.acos(1)`, 0.0},
+		{`Math.atan(1)`, math.Pi / 4},
+		{`Math.atan2(1, 1)`, math.Pi / 4},
+		{`Math.exp(0)`, 1.0},
+		{`Math.log(Math::E)`, 1.0},
+		{`Math.log(8, 2)`, 3.0},
+		{`Math.log2(8)`, 3.0},
+		{`Math.log10(1000)`, 3.0},
+		{`Math.hypot(3, 4)`, 5.0},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestMathModuleFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`Math.sqrt(-1)`, "ArgumentError: Numerical argument is out of domain - sqrt", 1},
+		{`Math.asin(2)`, "ArgumentError: Numerical argument is out of domain - asin", 1},
+		{`Math.log(-1)`, "ArgumentError: Numerical argument is out of domain - log", 1},
+		{`Math.log(1, 2, 3)`, "ArgumentError: Expect 1 or 2 arguments. got: 3", 1},
+		{`Math.sqrt("4")`, "TypeError: Expect argument to be Numeric. got: String", 1},
+		{`Math.sin`, "ArgumentError: Expect 1 arguments. got: 0", 1},
+		{`Math.hypot(3)`, "ArgumentError: Expect 2 arguments. got: 1", 1},
+		{`Math.atan2(1, "1")`, "TypeError: Expect argument to be Numeric. got: String", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
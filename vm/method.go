@@ -7,12 +7,22 @@ import (
 	"github.com/goby-lang/goby/vm/classes"
 )
 
+// Method visibilities, methods are public unless they're defined after `private` or `protected`
+const (
+	publicMethod = iota
+	privateMethod
+	protectedMethod
+)
+
+var visibilityNames = []string{"Public", "Private", "Protected"}
+
 // MethodObject represents methods defined using goby.
 type MethodObject struct {
 	*baseObj
 	Name           string
 	instructionSet *instructionSet
 	argc           int
+	visibility     int
 }
 
 // Internal functions ===================================================
@@ -71,8 +81,9 @@ func (m *MethodObject) isKeywordArgIncluded() bool {
 // BuiltinMethodObject represents methods defined in go.
 type BuiltinMethodObject struct {
 	*baseObj
-	Name string
-	Fn   func(receiver Object) builtinMethodBody
+	Name       string
+	Fn         func(receiver Object) builtinMethodBody
+	visibility int
 }
 
 type builtinMethodBody func(*thread, []Object, *callFrame) Object
@@ -93,3 +104,34 @@ func (bim *BuiltinMethodObject) toJSON() string {
 func (bim *BuiltinMethodObject) Value() interface{} {
 	return bim.Fn
 }
+
+// Other helper functions ----------------------------------------------
+
+// methodVisibility returns the visibility of a MethodObject or a BuiltinMethodObject
+func methodVisibility(method Object) int {
+	switch m := method.(type) {
+	case *MethodObject:
+		return m.visibility
+	case *BuiltinMethodObject:
+		return m.visibility
+	}
+
+	return publicMethod
+}
+
+// withVisibility returns a copy of the method with the given visibility,
+// so the method in the superclass keeps its own visibility
+func withVisibility(method Object, visibility int) Object {
+	switch m := method.(type) {
+	case *MethodObject:
+		copied := *m
+		copied.visibility = visibility
+		return &copied
+	case *BuiltinMethodObject:
+		copied := *m
+		copied.visibility = visibility
+		return &copied
+	}
+
+	return method
+}
@@ -0,0 +1,97 @@
+package vm
+
+import (
+	"sync/atomic"
+)
+
+// methodStateVersion is bumped whenever a method is defined or the inheritance tree is changed,
+// like defining methods, including or extending modules. All inline caches are invalidated by the bump.
+var methodStateVersion uint64
+
+// polymorphicCacheSize is the maximum number of receiver classes a send instruction's cache keeps.
+// The call site is considered megamorphic when there are more, and the cache stops growing.
+const polymorphicCacheSize = 4
+
+// methodCache is the inline cache of a send instruction, which remembers the methods found for the receivers' classes.
+// It starts monomorphic with only one class, and becomes polymorphic when the receivers have different classes.
+// The cache is shared by all threads, so entries are replaced as a whole instead of being modified.
+type methodCache struct {
+	entries atomic.Value
+}
+
+// methodCacheEntries are the cached methods, they're valid only when the version is the current methodStateVersion
+type methodCacheEntries struct {
+	version uint64
+	classes []*RClass
+	methods []Object
+}
+
+// bumpMethodState invalidates all inline caches
+func bumpMethodState() {
+	atomic.AddUint64(&methodStateVersion, 1)
+}
+
+// lookup returns the receiver's method from the cache, or finds the method and caches it.
+// Undefined methods are not cached, so `method_missing` is looked up every time.
+func (mc *methodCache) lookup(receiver Object, methodName string) Object {
+	class := methodCacheClass(receiver)
+
+	if class == nil {
+		return receiver.findMethod(methodName)
+	}
+
+	version := atomic.LoadUint64(&methodStateVersion)
+	cached, _ := mc.entries.Load().(*methodCacheEntries)
+
+	if cached != nil && cached.version != version {
+		cached = nil
+	}
+
+	if cached != nil {
+		for i, c := range cached.classes {
+			if c == class {
+				return cached.methods[i]
+			}
+		}
+	}
+
+	method := receiver.findMethod(methodName)
+
+	if method == nil {
+		return nil
+	}
+
+	entries := &methodCacheEntries{version: version, classes: []*RClass{class}, methods: []Object{method}}
+
+	if cached != nil {
+		if len(cached.classes) >= polymorphicCacheSize {
+			return method
+		}
+
+		entries.classes = append(entries.classes, cached.classes...)
+		entries.methods = append(entries.methods, cached.methods...)
+	}
+
+	mc.entries.Store(entries)
+
+	return method
+}
+
+// methodCacheClass returns the class where the method lookup of the receiver starts,
+// or nil if the receiver's methods shouldn't be cached
+func methodCacheClass(receiver Object) *RClass {
+	if c, ok := receiver.(*RClass); ok {
+		// Singleton classes look up methods from their superclasses instead
+		if c.isSingleton {
+			return nil
+		}
+
+		return c.SingletonClass()
+	}
+
+	if receiver.SingletonClass() != nil {
+		return receiver.SingletonClass()
+	}
+
+	return receiver.Class()
+}
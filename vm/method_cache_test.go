@@ -0,0 +1,190 @@
+package vm
+
+import (
+	"testing"
+)
+
+func TestMethodCacheInvalidation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		// Redefining the method
+		{`
+		class Foo
+		  def bar
+		    1
+		  end
+		end
+
+		def call_bar(f)
+		  f.bar
+		end
+
+		f = Foo.new
+		a = call_bar(f)
+
+		class Foo
+		  def bar
+		    2
+		  end
+		end
+
+		a + call_bar(f) * 10
+		`, 21},
+		// Including a module which overrides the inherited method
+		{`
+		class Base
+		  def bar
+		    1
+		  end
+		end
+
+		class Foo < Base; end
+
+		module Bar
+		  def bar
+		    2
+		  end
+		end
+
+		def call_bar(f)
+		  f.bar
+		end
+
+		f = Foo.new
+		a = call_bar(f)
+		Foo.include(Bar)
+		a + call_bar(f) * 10
+		`, 21},
+		// Extending the class
+		{`
+		class Foo
+		  def self.bar
+		    1
+		  end
+		end
+
+		module Bar
+		  def bar
+		    2
+		  end
+		end
+
+		def call_bar(f)
+		  f.bar
+		end
+
+		a = call_bar(Foo)
+		Foo.extend(Bar)
+		a + call_bar(Foo) * 10
+		`, 11},
+		// Singleton methods
+		{`
+		class Foo
+		  def bar
+		    1
+		  end
+		end
+
+		def call_bar(f)
+		  f.bar
+		end
+
+		f = Foo.new
+		a = call_bar(f)
+
+		def f.bar
+		  2
+		end
+
+		a + call_bar(f) * 10
+		`, 21},
+		// Polymorphic call site
+		{`
+		class A
+		  def name
+		    "a"
+		  end
+		end
+
+		class B
+		  def name
+		    "b"
+		  end
+		end
+
+		[A.new, B.new, 1, A.new, "s", :sym, B.new].map do |o|
+		  if o.respond_to?(:name)
+		    o.name
+		  else
+		    o.to_s
+		  end
+		end.join
+		`, "ab1assymb"},
+		// Class methods and instance methods with the same name
+		{`
+		class Foo
+		  def self.bar
+		    1
+		  end
+
+		  def bar
+		    2
+		  end
+		end
+
+		[Foo, Foo.new, Foo].map do |o|
+		  o.bar
+		end.to_s
+		`, "[1, 2, 1]"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestMethodCacheLookup(t *testing.T) {
+	v := initTestVM()
+	mc := &methodCache{}
+	i := v.initIntegerObject(1)
+	s := v.initStringObject("a")
+
+	if mc.lookup(i, "to_s") != i.findMethod("to_s") {
+		t.Fatalf("Expect the cache to return Integer#to_s")
+	}
+
+	entries := mc.entries.Load().(*methodCacheEntries)
+	if len(entries.classes) != 1 {
+		t.Fatalf("Expect the cache to be monomorphic. got: %d classes", len(entries.classes))
+	}
+
+	if mc.lookup(s, "to_s") != s.findMethod("to_s") {
+		t.Fatalf("Expect the cache to return String#to_s")
+	}
+
+	entries = mc.entries.Load().(*methodCacheEntries)
+	if len(entries.classes) != 2 {
+		t.Fatalf("Expect the cache to be polymorphic. got: %d classes", len(entries.classes))
+	}
+
+	if (&methodCache{}).lookup(i, "undefined_method") != nil {
+		t.Fatalf("Expect undefined method to be nil")
+	}
+
+	bumpMethodState()
+
+	if mc.lookup(i, "to_s") != i.findMethod("to_s") {
+		t.Fatalf("Expect the cache to return Integer#to_s")
+	}
+
+	entries = mc.entries.Load().(*methodCacheEntries)
+	if len(entries.classes) != 1 {
+		t.Fatalf("Expect the cache to be reset after the method state changes. got: %d classes", len(entries.classes))
+	}
+}
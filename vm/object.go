@@ -17,6 +17,7 @@ type Object interface {
 	id() int
 	instanceVariableGet(string) (Object, bool)
 	instanceVariableSet(string, Object) Object
+	instanceVariables() *environment
 }
 
 // baseObj ==============================================================
@@ -63,6 +64,11 @@ func (b *baseObj) instanceVariableSet(name string, value Object) Object {
 	return value
 }
 
+// instanceVariables returns the object's instance variables, it can be nil if the object never has any
+func (b *baseObj) instanceVariables() *environment {
+	return b.InstanceVariables
+}
+
 func (b *baseObj) findMethod(methodName string) (method Object) {
 	if b.SingletonClass() != nil {
 		method = b.SingletonClass().lookupMethod(methodName)
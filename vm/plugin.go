@@ -209,18 +209,18 @@ func setPluginContext(context Object) *pluginContext {
 
 	for _, f := range fs.Elements {
 		fInfos := f.(*HashObject)
-		prefix := fInfos.Pairs["prefix"].(*StringObject).value
-		name := fInfos.Pairs["name"].(*StringObject).value
+		prefix, _ := fInfos.getByName("prefix")
+		name, _ := fInfos.getByName("name")
 
-		pc.addFunc(prefix, name)
+		pc.addFunc(prefix.(*StringObject).value, name.(*StringObject).value)
 	}
 
 	for _, p := range ps.Elements {
 		pInfos := p.(*HashObject)
-		prefix := pInfos.Pairs["prefix"].(*StringObject).value
-		name := pInfos.Pairs["name"].(*StringObject).value
+		prefix, _ := pInfos.getByName("prefix")
+		name, _ := pInfos.getByName("name")
 
-		pc.importPkg(prefix, name)
+		pc.importPkg(prefix.(*StringObject).value, name.(*StringObject).value)
 	}
 
 	return pc
@@ -18,7 +18,7 @@ func TestPluginInitialization(t *testing.T) {
 		end
 
 		c = p.context
-		c.packages.first[:name]
+		c.packages.first["name"]
 	`, "database/sql"},
 		{`
 		require "plugin"
@@ -29,7 +29,7 @@ func TestPluginInitialization(t *testing.T) {
 		end
 
 		c = p.context
-		c.functions.first[:prefix]
+		c.functions.first["prefix"]
 	`, "sql"},
 		{`
 		require "plugin"
@@ -40,7 +40,7 @@ func TestPluginInitialization(t *testing.T) {
 		end
 
 		c = p.context
-		c.functions.first[:name]
+		c.functions.first["name"]
 	`, "Open"},
 	}
 
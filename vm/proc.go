@@ -0,0 +1,281 @@
+package vm
+
+import (
+	"fmt"
+
+	"github.com/goby-lang/goby/compiler/bytecode"
+	"github.com/goby-lang/goby/vm/classes"
+	"github.com/goby-lang/goby/vm/errors"
+)
+
+// ProcObject represents a block that has been captured as an object,
+// so it can be stored in a variable, passed to other methods or called later.
+// A Proc shares the local variables of the context it's created in.
+//
+// ```ruby
+// count = 0
+// add = Proc.new do |n|
+//   count = count + n
+// end
+//
+// add.call(10)
+// add.call(20)
+// count # => 30
+// ```
+//
+// Lambdas are Procs that check the number of arguments strictly.
+//
+// ```ruby
+// double = ->(x) { x * 2 }
+// double.call(2)    # => 4
+// double.call(2, 3) # => ArgumentError
+// ```
+//
+// A method can take its block as a Proc with a `&` parameter, and a Proc can be passed as block with `&` as well.
+//
+// ```ruby
+// def capture(&block)
+//   block
+// end
+//
+// def run
+//   yield(10)
+// end
+//
+// p = capture do |x|
+//   x + 1
+// end
+//
+// run(&p) # => 11
+// ```
+//
+type ProcObject struct {
+	*baseObj
+	blockFrame *callFrame
+	isLambda   bool
+}
+
+// Class methods --------------------------------------------------------
+func builtinProcClassMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			// Creates a Proc object with the given block.
+			//
+			// ```ruby
+			// p = Proc.new do |x|
+			//   x * 2
+			// end
+			//
+			// p.call(5) # => 10
+			// ```
+			//
+			// @param block literal
+			// @return [Proc]
+			Name: "new",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if blockFrame == nil {
+						return t.vm.initErrorObject(errors.ArgumentError, "Can't create Proc object without a block")
+					}
+
+					// The block is called later, so we need to pop its frame from the stack manually
+					t.callFrameStack.pop()
+
+					return t.vm.initProcObject(blockFrame, false)
+				}
+			},
+		},
+	}
+}
+
+// Instance methods -----------------------------------------------------
+func builtinProcInstanceMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			// Returns the number of parameters the Proc takes.
+			// If it has optional or splat parameters, it returns -n-1 where n is the number of the required parameters.
+			//
+			// ```ruby
+			// p = Proc.new do |a, b|
+			//   a + b
+			// end
+			//
+			// p.arity                  # => 2
+			// ->() { 10 }.arity        # => 0
+			// ->(a, b = 1) { a }.arity # => -2
+			// ->(*a) { a }.arity       # => -1
+			// ```
+			//
+			// @return [Integer]
+			Name: "arity",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					p := receiver.(*ProcObject)
+					return t.vm.initIntegerObject(p.arity())
+				}
+			},
+		},
+		{
+			// Calls the Proc with the given arguments and returns the block's last value.
+			// A lambda checks the number of arguments by its parameters,
+			// while a Proc ignores extra arguments and treats missing ones as nil.
+			// The block given to `call` is passed to the lambda's `&` parameter.
+			//
+			// ```ruby
+			// add = ->(a, b) { a + b }
+			// add.call(1, 2) # => 3
+			//
+			// add = ->(a, b = 2, *rest) { a + b + rest.length }
+			// add.call(1)       # => 3
+			// add.call(1, 3, 5) # => 5
+			//
+			// apply = ->(x, &f) { f.call(x) }
+			// apply.call(3) do |x|
+			//   x * 2
+			// end                # => 6
+			//
+			// p = Proc.new do |a, b|
+			//   b
+			// end
+			// p.call(1)       # => nil
+			// p.call(1, 2, 3) # => 2
+			// ```
+			//
+			// @param args [Object]
+			// @return [Object]
+			Name: "call",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					p := receiver.(*ProcObject)
+					required, max := p.paramRange()
+
+					// The given block isn't yielded directly, so we need to pop its frame from the stack manually
+					if blockFrame != nil {
+						t.callFrameStack.pop()
+					}
+
+					if p.isLambda {
+						if len(args) < required || (max >= 0 && len(args) > max) {
+							switch {
+							case max == required:
+								return t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, required, len(args))
+							case max < 0:
+								return t.vm.initErrorObject(errors.ArgumentError, "Expect at least %d arguments. got: %d", required, len(args))
+							default:
+								return t.vm.initErrorObject(errors.ArgumentError, "Expect %d to %d arguments. got: %d", required, max, len(args))
+							}
+						}
+
+						// Lambda takes the arguments as they are, so a single Array argument isn't expanded
+						return t.callBlock(p.blockFrame, blockFrame, args).Target
+					}
+
+					if max >= 0 && len(args) > max {
+						args = args[:max]
+					}
+
+					return t.builtinMethodYield(p.blockFrame, args...).Target
+				}
+			},
+		},
+		{
+			// Returns true if the Proc is a lambda.
+			//
+			// ```ruby
+			// p = Proc.new do |x|
+			//   x
+			// end
+			//
+			// p.lambda?            # => false
+			// ->(x) { x }.lambda? # => true
+			// ```
+			//
+			// @return [Boolean]
+			Name: "lambda?",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return toBooleanObject(receiver.(*ProcObject).isLambda)
+				}
+			},
+		},
+	}
+}
+
+// Internal functions ===================================================
+
+// Functions for initialization -----------------------------------------
+
+func (vm *VM) initProcObject(blockFrame *callFrame, isLambda bool) *ProcObject {
+	return &ProcObject{
+		baseObj:    &baseObj{class: vm.topLevelClass(classes.ProcClass)},
+		blockFrame: blockFrame,
+		isLambda:   isLambda,
+	}
+}
+
+func (vm *VM) initProcClass() *RClass {
+	pc := vm.initializeClass(classes.ProcClass, false)
+	pc.setBuiltinMethods(builtinProcInstanceMethods(), false)
+	pc.setBuiltinMethods(builtinProcClassMethods(), true)
+	return pc
+}
+
+// Polymorphic helper functions -----------------------------------------
+
+// Value returns the object
+func (p *ProcObject) Value() interface{} {
+	return p.blockFrame
+}
+
+// toString returns the object's name as the string format
+func (p *ProcObject) toString() string {
+	if p.isLambda {
+		return fmt.Sprintf("<Proc: %p (lambda)>", p)
+	}
+
+	return fmt.Sprintf("<Proc: %p>", p)
+}
+
+// toJSON just delegates to toString
+func (p *ProcObject) toJSON() string {
+	return p.toString()
+}
+
+func (p *ProcObject) arity() int {
+	required, max := p.paramRange()
+
+	if max != required {
+		return -required - 1
+	}
+
+	return required
+}
+
+// paramRange returns the minimum and maximum number of arguments the Proc takes, the maximum is -1 if it has a splat parameter
+func (p *ProcObject) paramRange() (required, max int) {
+	params := p.blockFrame.instructionSet.paramTypes
+
+	if params == nil {
+		return 0, 0
+	}
+
+	optional := 0
+	splat := false
+
+	for _, paramType := range params.Types() {
+		switch paramType {
+		case bytecode.NormalArg:
+			required++
+		case bytecode.OptionedArg:
+			optional++
+		case bytecode.SplatArg:
+			splat = true
+		}
+	}
+
+	if splat {
+		return required, -1
+	}
+
+	return required, required + optional
+}
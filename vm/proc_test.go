@@ -0,0 +1,290 @@
+package vm
+
+import (
+	"testing"
+)
+
+func TestProcClassSuperclass(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`Proc.class.name`, "Class"},
+		{`Proc.superclass.name`, "Object"},
+		{`->(x) { x }.class.name`, "Proc"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestProcCall(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		count = 0
+		add = Proc.new do |n|
+		  count = count + n
+		end
+
+		add.call(10)
+		add.call(20)
+		count
+		`, 30},
+		{`
+		p = Proc.new do |a, b|
+		  b
+		end
+
+		p.call(1)
+		`, nil},
+		{`
+		p = Proc.new do |a, b|
+		  a + b
+		end
+
+		p.call(1, 2, 3)
+		`, 3},
+		{`
+		double = ->(x) { x * 2 }
+		double.call(4)
+		`, 8},
+		{`
+		add = ->(a, b) do
+		  a + b
+		end
+
+		add.call(1, 2)
+		`, 3},
+		{`
+		l = lambda do
+		  10
+		end
+
+		l.call
+		`, 10},
+		{`
+		def counter
+		  n = 0
+		  ->() { n = n + 1 }
+		end
+
+		c = counter
+		c.call
+		c.call
+		`, 2},
+		{`
+		def apply(f, v)
+		  f.call(v)
+		end
+
+		apply(->(x) { x * 10 }, 2)
+		`, 20},
+		{`
+		class Foo
+		  def initialize
+		    @bar = 100
+		  end
+
+		  def bar_getter
+		    ->() { @bar }
+		  end
+		end
+
+		Foo.new.bar_getter.call
+		`, 100},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestProcArityAndLambda(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`->(a, b) { a }.arity`, 2},
+		{`->{ 1 }.arity`, 0},
+		{`
+		p = Proc.new do |x|
+		  x
+		end
+
+		p.arity
+		`, 1},
+		{`->(a, b = 1) { a }.arity`, -2},
+		{`->(*a) { a }.arity`, -1},
+		{`->(a, &b) { a }.arity`, 1},
+		{`->(x) { x }.lambda?`, true},
+		{`
+		p = Proc.new do |x|
+		  x
+		end
+
+		p.lambda?
+		`, false},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestLambdaParameters(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`->(a, b = 2) { a + b }.call(1)`, 3},
+		{`->(a, b = 2) { a + b }.call(1, 5)`, 6},
+		{`->(a, b = a * 10) { b }.call(4)`, 40},
+		{`
+		b = 100
+		l = ->(b = 1) { b }
+		l.call.to_s + " " + b.to_s
+		`, "1 100"},
+		{`->(a, *b) { b.to_s }.call(1, 2, 3)`, "[2, 3]"},
+		{`->(a, *b) { b.to_s }.call(1)`, "[]"},
+		{`->(a, b = 2, *c) { a + b + c.length }.call(1, 3, 5, 7)`, 6},
+		{`
+		apply = ->(x, &f) { f.call(x) }
+		r = apply.call(3) do |x|
+		  x * 2
+		end
+		r
+		`, 6},
+		{`->(&f) { f.nil? }.call`, true},
+		{`->(a, b = 2) { a.to_s }.call([1, 2])`, "[1, 2]"},
+		{`
+		def run
+		  yield(1)
+		end
+
+		run(&->(a, b = 7) { a + b })
+		`, 8},
+		{`
+		def run
+		  yield(1, 2, 3)
+		end
+
+		run(&->(a, *b) { b.length })
+		`, 2},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestBlockParameterAndProcArgument(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		def capture(&block)
+		  block
+		end
+
+		p = capture do |x|
+		  x + 1
+		end
+
+		p.call(10)
+		`, 11},
+		{`
+		def capture(&block)
+		  block
+		end
+
+		capture.nil?
+		`, true},
+		{`
+		def run
+		  yield(10)
+		end
+
+		p = ->(x) { x * 3 }
+		run(&p)
+		`, 30},
+		{`
+		def run(&block)
+		  block.call(5)
+		end
+
+		def pass(&block)
+		  run(&block)
+		end
+
+		r = pass do |x|
+		  x * 2
+		end
+
+		r
+		`, 10},
+		{`
+		double = ->(x) { x * 2 }
+		[1, 2, 3].map(&double)[2]
+		`, 6},
+		{`
+		def run
+		  if block_given?
+		    yield
+		  else
+		    "no block"
+		  end
+		end
+
+		run(&nil)
+		`, "no block"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestProcMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`Proc.new`, "ArgumentError: Can't create Proc object without a block", 1},
+		{`lambda`, "ArgumentError: Can't create lambda without a block", 1},
+		{`->(x) { x }.call(1, 2)`, "ArgumentError: Expect 1 arguments. got: 2", 1},
+		{`->(a, b = 2) { a }.call`, "ArgumentError: Expect 1 to 2 arguments. got: 0", 1},
+		{`->(a, b = 2) { a }.call(1, 2, 3)`, "ArgumentError: Expect 1 to 2 arguments. got: 3", 1},
+		{`->(a, *b) { a }.call`, "ArgumentError: Expect at least 1 arguments. got: 0", 1},
+		{`[1].each(&1)`, "TypeError: Expect argument to be Proc. got: Integer", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
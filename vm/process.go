@@ -0,0 +1,538 @@
+package vm
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/goby-lang/goby/vm/classes"
+	"github.com/goby-lang/goby/vm/errors"
+)
+
+// Process module runs external commands and handles the current process's signals.
+// `Process.run` and `Process.stream` are defined in lib/process.gb, they accept the options `env:`, `dir:` and `stdin:`.
+//
+// ```ruby
+// result = Process.run("git", ["status", "--short"], dir: "/home/goby/project")
+// result.exit_status # => 0
+// result.stdout      # => " M README.md\n"
+//
+// Process.stream("make", ["test"], env: { CI: "true" }) do |line, source|
+//   puts(source + ": " + line)
+// end
+//
+// Process.trap("INT") do |signal|
+//   puts("Stopped by " + signal)
+//   exit(1)
+// end
+// ```
+//
+// Signal names are `HUP`, `INT`, `QUIT`, `TERM`, `USR1` and `USR2`, they can also be prefixed by `SIG`.
+
+// ProcessResultObject holds the exit status and the outputs of a command which is run by `Process.run`.
+//
+// ```ruby
+// result = Process.run("ls", ["/none"])
+// result.success?    # => false
+// result.exit_status # => 2
+// result.stderr      # => "ls: /none: No such file or directory\n"
+// ```
+//
+type ProcessResultObject struct {
+	*baseObj
+	exitStatus int
+	stdout     string
+	stderr     string
+}
+
+// processResultClassName is the name of the class of `Process.run`'s result, which is defined under Process
+const processResultClassName = "Result"
+
+var signalTable = map[string]syscall.Signal{
+	"HUP":  syscall.SIGHUP,
+	"INT":  syscall.SIGINT,
+	"QUIT": syscall.SIGQUIT,
+	"TERM": syscall.SIGTERM,
+	"USR1": syscall.SIGUSR1,
+	"USR2": syscall.SIGUSR2,
+}
+
+// signalTrap keeps the block which handles a signal, the block can be replaced by trapping the signal again
+type signalTrap struct {
+	ch         chan os.Signal
+	blockFrame *callFrame
+}
+
+// processOutput is a line of a command's output, source is either "stdout" or "stderr"
+type processOutput struct {
+	line   string
+	source string
+}
+
+// Module methods --------------------------------------------------------
+func builtinProcessModuleMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			// Returns the process ID of the current process.
+			//
+			// ```ruby
+			// Process.pid # => 12345
+			// ```
+			//
+			// @return [Integer]
+			Name: "pid",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 0 {
+						return t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, 0, len(args))
+					}
+
+					return t.vm.initIntegerObject(os.Getpid())
+				}
+			},
+		},
+		{
+			// Runs the command and waits for it, the outputs are collected into the returned `Process::Result`.
+			// It's used by `Process.run`, which should be used instead.
+			//
+			// @param command [String], args [Array], env [Hash], dir [String], stdin [String, File, Null]
+			// @return [Process::Result]
+			Name: "run_command",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					cmd, err := t.processCommand(args)
+
+					if err != nil {
+						return err
+					}
+
+					var stdout, stderr bytes.Buffer
+					cmd.Stdout = &stdout
+					cmd.Stderr = &stderr
+
+					status, err := t.waitProcess(cmd, cmd.Run())
+
+					if err != nil {
+						return err
+					}
+
+					return &ProcessResultObject{
+						baseObj:    &baseObj{class: receiver.(*RClass).getClassConstant(processResultClassName)},
+						exitStatus: status,
+						stdout:     stdout.String(),
+						stderr:     stderr.String(),
+					}
+				}
+			},
+		},
+		{
+			// Runs the command and yields each line of its outputs as soon as it's written, and returns the exit status.
+			// It's used by `Process.stream`, which should be used instead.
+			//
+			// @param command [String], args [Array], env [Hash], dir [String], stdin [String, File, Null]
+			// @return [Integer]
+			Name: "stream_command",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if blockFrame == nil {
+						return t.vm.initErrorObject(errors.InternalError, errors.CantYieldWithoutBlockFormat)
+					}
+
+					cmd, err := t.processCommand(args)
+
+					if err != nil {
+						return err
+					}
+
+					stdout, e := cmd.StdoutPipe()
+
+					if e != nil {
+						return t.vm.initErrorObject(errors.InternalError, "%s", e.Error())
+					}
+
+					stderr, e := cmd.StderrPipe()
+
+					if e != nil {
+						return t.vm.initErrorObject(errors.InternalError, "%s", e.Error())
+					}
+
+					if e := cmd.Start(); e != nil {
+						return t.vm.initErrorObject(errors.InternalError, "%s", e.Error())
+					}
+
+					// Outputs are read by goroutines, but the block is only called on the current thread
+					outputs := make(chan processOutput)
+					var wg sync.WaitGroup
+					wg.Add(2)
+					go readProcessOutput(stdout, "stdout", outputs, &wg)
+					go readProcessOutput(stderr, "stderr", outputs, &wg)
+
+					go func() {
+						wg.Wait()
+						close(outputs)
+					}()
+
+					yielded := false
+
+					for output := range outputs {
+						yielded = true
+						result := t.builtinMethodYield(blockFrame, t.vm.initStringObject(output.line), t.vm.initStringObject(output.source))
+
+						if err, ok := result.Target.(*Error); ok && err.raised {
+							cmd.Process.Kill()
+
+							// Drain the outputs so the reading goroutines can finish
+							for range outputs {
+							}
+
+							cmd.Wait()
+							return err
+						}
+					}
+
+					// The block's frame is left on the stack if it's never yielded
+					if !yielded {
+						t.callFrameStack.pop()
+					}
+
+					status, err := t.waitProcess(cmd, cmd.Wait())
+
+					if err != nil {
+						return err
+					}
+
+					return t.vm.initIntegerObject(status)
+				}
+			},
+		},
+		{
+			// Calls the block in a new thread whenever the process receives the signal, the block receives the signal's name.
+			// Trapping the signal again replaces the block, and trapping it without a block restores its default behavior.
+			//
+			// ```ruby
+			// Process.trap("TERM") do |signal|
+			//   puts("Received " + signal) # => Received TERM
+			//   exit(0)
+			// end
+			//
+			// Process.trap("TERM") # The process is terminated by TERM again
+			// ```
+			//
+			// @param signal [String]
+			// @return [Null]
+			Name: "trap",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					name, err := t.stringArg(args)
+
+					if err != nil {
+						return err
+					}
+
+					name = strings.TrimPrefix(strings.ToUpper(name), "SIG")
+					sig, ok := signalTable[name]
+
+					if !ok {
+						return t.vm.initErrorObject(errors.ArgumentError, "Unsupported signal: %s", args[0].toString())
+					}
+
+					t.vm.trapSignal(name, sig, blockFrame)
+
+					if blockFrame != nil {
+						// The block is called on other threads later
+						t.callFrameStack.pop()
+					}
+
+					return NULL
+				}
+			},
+		},
+	}
+}
+
+// Instance methods -----------------------------------------------------
+func builtinProcessResultInstanceMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			// Returns the exit status of the command. It's -1 if the command is terminated by a signal.
+			//
+			// ```ruby
+			// Process.run("true").exit_status  # => 0
+			// Process.run("false").exit_status # => 1
+			// ```
+			//
+			// @return [Integer]
+			Name: "exit_status",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initIntegerObject(receiver.(*ProcessResultObject).exitStatus)
+				}
+			},
+		},
+		{
+			// Returns what the command wrote to the standard error.
+			//
+			// ```ruby
+			// Process.run("ls", ["/none"]).stderr # => "ls: /none: No such file or directory\n"
+			// ```
+			//
+			// @return [String]
+			Name: "stderr",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initStringObject(receiver.(*ProcessResultObject).stderr)
+				}
+			},
+		},
+		{
+			// Returns what the command wrote to the standard output.
+			//
+			// ```ruby
+			// Process.run("echo", ["Goby"]).stdout # => "Goby\n"
+			// ```
+			//
+			// @return [String]
+			Name: "stdout",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initStringObject(receiver.(*ProcessResultObject).stdout)
+				}
+			},
+		},
+		{
+			// Returns true if the command exits with 0.
+			//
+			// ```ruby
+			// Process.run("true").success? # => true
+			// ```
+			//
+			// @return [Boolean]
+			Name: "success?",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return toBooleanObject(receiver.(*ProcessResultObject).exitStatus == 0)
+				}
+			},
+		},
+	}
+}
+
+// Internal functions ===================================================
+
+// Functions for initialization -----------------------------------------
+
+func (vm *VM) initProcessModule() *RClass {
+	pm := vm.initializeClass(classes.ProcessModule, true)
+	pm.setBuiltinMethods(builtinProcessModuleMethods(), true)
+
+	result := vm.initializeClass(processResultClassName, false)
+	result.setBuiltinMethods(builtinProcessResultInstanceMethods(), false)
+	pm.setClassConstant(result)
+
+	vm.libFiles = append(vm.libFiles, "process.gb")
+
+	return pm
+}
+
+// Polymorphic helper functions -----------------------------------------
+
+// Value returns the exit status of the command
+func (r *ProcessResultObject) Value() interface{} {
+	return r.exitStatus
+}
+
+// toString returns the object's name and exit status as the string format
+func (r *ProcessResultObject) toString() string {
+	return fmt.Sprintf("#<Process::Result exit_status: %d>", r.exitStatus)
+}
+
+// toJSON just delegates to toString
+func (r *ProcessResultObject) toJSON() string {
+	return r.toString()
+}
+
+// Other helper functions ----------------------------------------------
+
+// processCommand builds the command from the arguments of `Process.run_command` and `Process.stream_command`
+func (t *thread) processCommand(args []Object) (*exec.Cmd, *Error) {
+	if len(args) != 5 {
+		return nil, t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, 5, len(args))
+	}
+
+	name, ok := args[0].(*StringObject)
+
+	if !ok {
+		return nil, t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.StringClass, args[0].Class().Name)
+	}
+
+	cmdArgs, ok := args[1].(*ArrayObject)
+
+	if !ok {
+		return nil, t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.ArrayClass, args[1].Class().Name)
+	}
+
+	env, ok := args[2].(*HashObject)
+
+	if !ok {
+		return nil, t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.HashClass, args[2].Class().Name)
+	}
+
+	dir, ok := args[3].(*StringObject)
+
+	if !ok {
+		return nil, t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.StringClass, args[3].Class().Name)
+	}
+
+	values := []string{}
+
+	for _, arg := range cmdArgs.Elements {
+		values = append(values, arg.toString())
+	}
+
+	cmd := exec.Command(name.value, values...)
+	cmd.Dir = dir.value
+
+	// The variables are added to the current process's environment
+	if env.length() > 0 {
+		cmd.Env = os.Environ()
+
+		for _, pair := range env.pairs {
+			cmd.Env = append(cmd.Env, pair.key.toString()+"="+pair.value.toString())
+		}
+	}
+
+	switch stdin := args[4].(type) {
+	case *StringObject:
+		cmd.Stdin = strings.NewReader(stdin.value)
+	case *FileObject:
+		cmd.Stdin = stdin.bufferedReader()
+	case *NullObject:
+	default:
+		return nil, t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, "String, File or Null", args[4].Class().Name)
+	}
+
+	return cmd, nil
+}
+
+// waitProcess returns the exit status of the finished command, a non-zero exit status isn't treated as an error
+func (t *thread) waitProcess(cmd *exec.Cmd, err error) (int, *Error) {
+	if _, ok := err.(*exec.ExitError); err != nil && !ok {
+		return 0, t.vm.initErrorObject(errors.InternalError, "%s", err.Error())
+	}
+
+	return exitStatus(cmd.ProcessState), nil
+}
+
+// exitStatus returns the exit status of the process, it's -1 if the process was killed by a signal
+func exitStatus(state *os.ProcessState) int {
+	if status, ok := state.Sys().(syscall.WaitStatus); ok {
+		return status.ExitStatus()
+	}
+
+	if state.Success() {
+		return 0
+	}
+
+	return 1
+}
+
+// readProcessOutput sends each line of the output to the channel until the output is closed
+func readProcessOutput(r io.Reader, source string, outputs chan<- processOutput, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	reader := bufio.NewReader(r)
+
+	for {
+		line, err := reader.ReadString('\n')
+
+		if len(line) > 0 {
+			outputs <- processOutput{line: line, source: source}
+		}
+
+		if err != nil {
+			return
+		}
+	}
+}
+
+// trapSignal replaces the signal's handler with the block, or stops trapping the signal if the block is nil
+func (vm *VM) trapSignal(name string, sig os.Signal, blockFrame *callFrame) {
+	vm.Lock()
+	defer vm.Unlock()
+
+	if vm.signalTraps == nil {
+		vm.signalTraps = map[os.Signal]*signalTrap{}
+	}
+
+	trap, ok := vm.signalTraps[sig]
+
+	if blockFrame == nil {
+		if ok {
+			signal.Stop(trap.ch)
+			close(trap.ch)
+			delete(vm.signalTraps, sig)
+		}
+
+		return
+	}
+
+	if ok {
+		trap.blockFrame = blockFrame
+		return
+	}
+
+	trap = &signalTrap{ch: make(chan os.Signal, 1), blockFrame: blockFrame}
+	vm.signalTraps[sig] = trap
+	signal.Notify(trap.ch, sig)
+
+	go func() {
+		for range trap.ch {
+			vm.Lock()
+			blockFrame := trap.blockFrame
+			vm.Unlock()
+
+			vm.newThread().runBlock(blockFrame, vm.initStringObject(name))
+		}
+	}()
+}
+
+// RunAtExitHooks calls the blocks registered by `at_exit` in the reverse order, each block is only called once.
+func (vm *VM) RunAtExitHooks() {
+	for {
+		vm.Lock()
+		n := len(vm.atExitHooks)
+
+		if n == 0 {
+			vm.Unlock()
+			return
+		}
+
+		hook := vm.atExitHooks[n-1]
+		vm.atExitHooks = vm.atExitHooks[:n-1]
+		vm.Unlock()
+
+		result := vm.newThread().runBlock(hook)
+
+		// Errors raised in hooks are printed, and the rest hooks are still called
+		if err, ok := result.Target.(*Error); ok && err.raised {
+			fmt.Println(err.Message)
+
+			for _, l := range err.backtrace {
+				fmt.Printf("\tfrom %s\n", l)
+			}
+		}
+	}
+}
+
+// exit calls the hooks registered by `at_exit` and terminates the process with the status
+func (vm *VM) exit(status int) {
+	vm.RunAtExitHooks()
+	os.Exit(status)
+}
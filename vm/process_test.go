@@ -0,0 +1,199 @@
+package vm
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strconv"
+	"testing"
+)
+
+func TestProcessRunMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`Process.run("echo", ["Goby", 1]).stdout`, "Goby 1\n"},
+		{`Process.run("true").success?`, true},
+		{`
+		r = Process.run("sh", ["-c", "echo out; echo err >&2; exit 3"])
+		r.exit_status.to_s + " " + r.stdout + r.stderr + r.success?.to_s
+		`, "3 out\nerr\nfalse"},
+		{`Process.run("grep", ["goby"], stdin: "ruby\ngoby\n").stdout`, "goby\n"},
+		{`Process.run("pwd", dir: "/").stdout`, "/\n"},
+		{`Process.run("sh", ["-c", "echo $GOBY_PROCESS_TEST"], env: { GOBY_PROCESS_TEST: "foo" }).stdout`, "foo\n"},
+		{`
+		r = nil
+		File.open("../test_fixtures/file_test/size.gb") do |f|
+		  f.gets
+		  r = Process.run("cat", stdin: f)
+		end
+		r.stdout
+		`, "22"},
+		{`Process.run("false").to_s`, "#<Process::Result exit_status: 1>"},
+		{`Process.pid`, os.Getpid()},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestProcessStreamMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		lines = []
+		status = Process.stream("sh", ["-c", "echo a; echo b; exit 2"]) do |line, source|
+		  lines.push(source + " " + line)
+		end
+		lines.join + status.to_s
+		`, "stdout a\nstdout b\n2"},
+		{`
+		lines = []
+		Process.stream("sh", ["-c", "echo a >&2"]) do |line, source|
+		  lines.push(source + " " + line)
+		end
+		lines.join
+		`, "stderr a\n"},
+		{`
+		Process.stream("true") do |line, source|
+		  puts(line)
+		end
+		`, 0},
+		// The command is killed when the block raises an error
+		{`
+		msg = ""
+		begin
+		  Process.stream("sh", ["-c", "echo a; sleep 10"]) do |line, source|
+		    raise(ArgumentError, "stop")
+		  end
+		rescue ArgumentError => e
+		  msg = e.message
+		end
+		msg
+		`, "stop"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestProcessTrapMethod(t *testing.T) {
+	input := `
+	c = Channel.new
+	Process.trap("SIGUSR1") do |signal|
+	  c.deliver(signal)
+	end
+	Process.run("kill", ["-USR1", Process.pid.to_s])
+	signal = c.receive
+	Process.trap("USR1")
+	signal
+	`
+
+	v := initTestVM()
+	evaluated := v.testEval(t, input, getFilename())
+	checkExpected(t, 0, evaluated, "USR1")
+	v.checkCFP(t, 0, 0)
+	v.checkSP(t, 0, 1)
+}
+
+func TestAtExitMethod(t *testing.T) {
+	input := `
+	at_exit do
+	  File.open("/tmp/goby_at_exit.txt", "a") do |f|
+	    f.write("world")
+	  end
+	end
+	at_exit do
+	  File.open("/tmp/goby_at_exit.txt", "w") do |f|
+	    f.write("hello ")
+	  end
+	end
+	`
+
+	v := initTestVM()
+	v.testEval(t, input, getFilename())
+	v.checkCFP(t, 0, 0)
+	v.RunAtExitHooks()
+
+	content, err := ioutil.ReadFile("/tmp/goby_at_exit.txt")
+
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if string(content) != "hello world" {
+		t.Errorf("Expect at_exit hooks to be called in reverse order. got: %s", string(content))
+	}
+}
+
+func TestExitMethod(t *testing.T) {
+	// The program exits in the subprocess, which is this test itself
+	if status := os.Getenv("GOBY_TEST_EXIT"); status != "" {
+		v := initTestVM()
+		v.testEval(t, `
+		at_exit do
+		  puts("bye")
+		end
+		exit(`+status+`)
+		puts("unreachable")
+		`, getFilename())
+		return
+	}
+
+	for _, status := range []int{0, 3} {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestExitMethod$")
+		cmd.Env = append(os.Environ(), "GOBY_TEST_EXIT="+strconv.Itoa(status))
+		out, err := cmd.Output()
+
+		code := 0
+
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			code = exitStatus(exitErr.ProcessState)
+		} else if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		if code != status {
+			t.Errorf("Expect exit status to be %d. got: %d", status, code)
+		}
+
+		if string(out) != "bye\n" {
+			t.Errorf("Expect at_exit hook to be called before exiting. got: %s", string(out))
+		}
+	}
+}
+
+func TestProcessMethodsFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`Process.run_command(1, [], {}, "", nil)`, "TypeError: Expect argument to be String. got: Integer", 1},
+		{`Process.run_command("ls", "-l", {}, "", nil)`, "TypeError: Expect argument to be Array. got: String", 1},
+		{`Process.run_command("ls", [], {}, "", 1)`, "TypeError: Expect argument to be String, File or Null. got: Integer", 1},
+		{`Process.run_command("goby_none_command", [], {}, "", nil)`, "InternalError: exec: \"goby_none_command\": executable file not found in $PATH", 1},
+		{`Process.stream_command("ls", [], {}, "", nil)`, "InternalError: Can't yield without a block", 1},
+		{`Process.pid(1)`, "ArgumentError: Expect 0 arguments. got: 1", 1},
+		{`Process.trap("FOO")`, "ArgumentError: Unsupported signal: FOO", 1},
+		{`at_exit`, "InternalError: Can't yield without a block", 1},
+		{`exit("1")`, "TypeError: Expect argument to be Integer. got: String", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
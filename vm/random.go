@@ -0,0 +1,231 @@
+package vm
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/goby-lang/goby/vm/classes"
+	"github.com/goby-lang/goby/vm/errors"
+)
+
+// RandomObject is a pseudo-random number generator, which is backed by Go's `math/rand`.
+// Generators created with the same seed return the same sequence of numbers, so they're useful for deterministic tests.
+// `Random.rand` uses the default generator which is seeded by the current time.
+//
+// ```ruby
+// r = Random.new(42)
+// r.rand        # => a Float in 0...1
+// r.rand(6)     # => an Integer in 0...6
+// r.rand(1..6)  # => an Integer in 1..6
+// r.rand(1.5)   # => a Float in 0...1.5
+//
+// [1, 2, 3].shuffle(Random.new(42)) == [1, 2, 3].shuffle(Random.new(42)) # => true
+// ```
+//
+type RandomObject struct {
+	*baseObj
+	*randomGenerator
+}
+
+// randomGenerator can be used by multiple threads
+type randomGenerator struct {
+	sync.Mutex
+	seed int64
+	rand *rand.Rand
+}
+
+// defaultRandomGenerator is used by `Random.rand`, `Array#sample` and `Array#shuffle` when a generator isn't given
+var defaultRandomGenerator = newRandomGenerator(time.Now().UnixNano())
+
+// Class methods --------------------------------------------------------
+func builtinRandomClassMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			// Returns a new generator with the given Integer seed. The seed is generated from the current time if it's omitted.
+			//
+			// ```ruby
+			// Random.new(42).seed # => 42
+			// ```
+			//
+			// @param seed [Integer]
+			// @return [Random]
+			Name: "new",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					seed := time.Now().UnixNano()
+
+					if len(args) > 0 {
+						values, err := t.integerArgs(args, 1)
+
+						if err != nil {
+							return err
+						}
+
+						seed = int64(values[0])
+					}
+
+					return &RandomObject{baseObj: &baseObj{class: receiver.(*RClass)}, randomGenerator: newRandomGenerator(seed)}
+				}
+			},
+		},
+		{
+			// Returns a random number from the default generator, see `Random#rand`.
+			//
+			// ```ruby
+			// Random.rand(10) # => an Integer in 0...10
+			// ```
+			//
+			// @param max [Integer, Float, Range]
+			// @return [Integer, Float]
+			Name: "rand",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return defaultRandomGenerator.randObject(t, args)
+				}
+			},
+		},
+	}
+}
+
+// Instance methods -----------------------------------------------------
+func builtinRandomInstanceMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			// Returns a random number. Without the argument, it's a Float in 0...1.
+			// With an Integer or a Float, it's a number of the same class from 0 up to but not including the argument.
+			// With a Range, it's an Integer in the range.
+			//
+			// ```ruby
+			// r = Random.new(1)
+			// r.rand       # => 0.6046602879796196
+			// r.rand(100)  # => an Integer in 0...100
+			// r.rand(2.5)  # => a Float in 0...2.5
+			// r.rand(1..6) # => an Integer in 1..6
+			// ```
+			//
+			// @param max [Integer, Float, Range]
+			// @return [Integer, Float]
+			Name: "rand",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return receiver.(*RandomObject).randObject(t, args)
+				}
+			},
+		},
+		{
+			// Returns the seed of the generator.
+			//
+			// ```ruby
+			// Random.new(42).seed # => 42
+			// ```
+			//
+			// @return [Integer]
+			Name: "seed",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initIntegerObject(int(receiver.(*RandomObject).seed))
+				}
+			},
+		},
+	}
+}
+
+// Internal functions ===================================================
+
+// Functions for initialization -----------------------------------------
+
+func (vm *VM) initRandomClass() *RClass {
+	rc := vm.initializeClass(classes.RandomClass, false)
+	// Class methods are also set as instance methods, so they're set first to not override `Random#rand`
+	rc.setBuiltinMethods(builtinRandomClassMethods(), true)
+	rc.setBuiltinMethods(builtinRandomInstanceMethods(), false)
+	return rc
+}
+
+func newRandomGenerator(seed int64) *randomGenerator {
+	return &randomGenerator{seed: seed, rand: rand.New(rand.NewSource(seed))}
+}
+
+// Polymorphic helper functions -----------------------------------------
+
+// Value returns the seed of the generator
+func (r *RandomObject) Value() interface{} {
+	return r.seed
+}
+
+// toString returns the object's name and seed as the string format
+func (r *RandomObject) toString() string {
+	return fmt.Sprintf("#<Random seed: %d>", r.seed)
+}
+
+// toJSON just delegates to toString
+func (r *RandomObject) toJSON() string {
+	return r.toString()
+}
+
+// Other helper functions ----------------------------------------------
+
+// randObject returns a random number which is decided by the optional argument, see `Random#rand`
+func (g *randomGenerator) randObject(t *thread, args []Object) Object {
+	if len(args) > 1 {
+		return t.vm.initErrorObject(errors.ArgumentError, "Expect 0 or 1 argument. got: %d", len(args))
+	}
+
+	if len(args) == 0 {
+		return t.vm.initFloatObject(g.float64())
+	}
+
+	switch max := args[0].(type) {
+	case *IntegerObject:
+		if max.value <= 0 {
+			return t.vm.initErrorObject(errors.ArgumentError, "Invalid max value: %d", max.value)
+		}
+
+		return t.vm.initIntegerObject(g.intn(max.value))
+	case *FloatObject:
+		if max.value <= 0 {
+			return t.vm.initErrorObject(errors.ArgumentError, "Invalid max value: %s", max.toString())
+		}
+
+		return t.vm.initFloatObject(g.float64() * max.value)
+	case *RangeObject:
+		if max.Start > max.End {
+			return t.vm.initErrorObject(errors.ArgumentError, "Invalid range: %s", max.toString())
+		}
+
+		return t.vm.initIntegerObject(max.Start + g.intn(max.End-max.Start+1))
+	default:
+		return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, "Integer, Float or Range", args[0].Class().Name)
+	}
+}
+
+// randomGeneratorArg returns the generator if it's the last argument, otherwise the default generator. The rest arguments are also returned
+func randomGeneratorArg(args []Object) (*randomGenerator, []Object) {
+	if len(args) > 0 {
+		if r, ok := args[len(args)-1].(*RandomObject); ok {
+			return r.randomGenerator, args[:len(args)-1]
+		}
+	}
+
+	return defaultRandomGenerator, args
+}
+
+func (g *randomGenerator) float64() float64 {
+	g.Lock()
+	defer g.Unlock()
+	return g.rand.Float64()
+}
+
+func (g *randomGenerator) intn(n int) int {
+	g.Lock()
+	defer g.Unlock()
+	return g.rand.Intn(n)
+}
+
+func (g *randomGenerator) perm(n int) []int {
+	g.Lock()
+	defer g.Unlock()
+	return g.rand.Perm(n)
+}
@@ -0,0 +1,78 @@
+package vm
+
+import (
+	"testing"
+)
+
+func TestRandomMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`Random.new(42).seed`, 42},
+		{`Random.new.class.name`, "Random"},
+		{`Random.new(42).to_s`, "#<Random seed: 42>"},
+		{`Random.new(1).rand`, 0.6046602879796196},
+		{`
+		a = Random.new(42)
+		b = Random.new(42)
+		result = true
+
+		100.times do
+		  result = result && a.rand(1000) == b.rand(1000)
+		end
+		result
+		`, true},
+		{`
+		r = Random.new(42)
+		result = true
+
+		100.times do
+		  n = r.rand(1..6)
+		  result = result && n >= 1 && n <= 6
+		end
+		result
+		`, true},
+		{`
+		r = Random.new(42)
+		result = true
+
+		100.times do
+		  n = r.rand(2.5)
+		  result = result && n >= 0 && n < 2.5
+		end
+		result
+		`, true},
+		{`Random.new(42).rand(3..3)`, 3},
+		{`Random.new(42).rand(1)`, 0},
+		{`Random.rand(10).class.name`, "Integer"},
+		{`Random.rand < 1`, true},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestRandomMethodsFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`Random.new("a")`, "TypeError: Expect argument to be Integer. got: String", 1},
+		{`Random.new(1).rand(0)`, "ArgumentError: Invalid max value: 0", 1},
+		{`Random.new(1).rand(-1.5)`, "ArgumentError: Invalid max value: -1.5", 1},
+		{`Random.new(1).rand(3..1)`, "ArgumentError: Invalid range: (3..1)", 1},
+		{`Random.new(1).rand("a")`, "TypeError: Expect argument to be Integer, Float or Range. got: String", 1},
+		{`Random.rand(1, 2)`, "ArgumentError: Expect 0 or 1 argument. got: 2", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
@@ -486,6 +486,7 @@ func (vm *VM) initRangeClass() *RClass {
 	rc := vm.initializeClass(classes.RangeClass, false)
 	rc.setBuiltinMethods(builtinRangeInstanceMethods(), false)
 	rc.setBuiltinMethods(builtinRangeClassMethods(), true)
+	rc.includeModule(vm.topLevelClass(classes.EnumerableModule))
 	return rc
 }
 
@@ -0,0 +1,372 @@
+package vm
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/goby-lang/goby/vm/classes"
+	"github.com/goby-lang/goby/vm/errors"
+)
+
+// RegexpObject represents a regular expression, which is backed by Go's `regexp` package,
+// so the syntax is RE2's syntax.
+// Regexps can be created with literals like `/ab+c/i` or with `Regexp.new`.
+//
+// Supported flags:
+//
+// - `i`: case insensitive
+// - `m`: `.` matches newlines as well
+//
+// ```ruby
+// /b+/ =~ "abbc"                  # => 1
+// /(?<year>\d+)-(\d+)/.match("2017-10")[:year] # => "2017"
+// "Goby Lang".gsub(/[aeiou]/, "*") # => "G*by L*ng"
+// "a1b22c".split(/\d+/)             # => ["a", "b", "c"]
+// ```
+//
+type RegexpObject struct {
+	*baseObj
+	regexp *regexp.Regexp
+	source string
+	flags  string
+}
+
+// Class methods --------------------------------------------------------
+func builtinRegexpClassMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			// Returns a string that escapes all regexp metacharacters in the given string.
+			//
+			// ```ruby
+			// Regexp.escape("1.5+2") # => "1\\.5\\+2"
+			// ```
+			//
+			// @param string [String]
+			// @return [String]
+			Name: "escape",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					s, ok := args[0].(*StringObject)
+					if !ok {
+						return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.StringClass, args[0].Class().Name)
+					}
+
+					return t.vm.initStringObject(regexp.QuoteMeta(s.value))
+				}
+			},
+		},
+		{
+			// Creates a Regexp from the pattern string and the optional flags string.
+			//
+			// ```ruby
+			// r = Regexp.new("go+", "i")
+			// r =~ "GOOD" # => 0
+			// ```
+			//
+			// @param pattern [String], flags [String]
+			// @return [Regexp]
+			Name: "new",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) < 1 || len(args) > 2 {
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect 1 or 2 arguments. got: %d", len(args))
+					}
+
+					var source, flags string
+
+					switch p := args[0].(type) {
+					case *StringObject:
+						source = p.value
+					case *RegexpObject:
+						source, flags = p.source, p.flags
+					default:
+						return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.StringClass, p.Class().Name)
+					}
+
+					if len(args) == 2 {
+						f, ok := args[1].(*StringObject)
+						if !ok {
+							return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.StringClass, args[1].Class().Name)
+						}
+
+						flags = f.value
+					}
+
+					return t.vm.initRegexpObject(source, flags)
+				}
+			},
+		},
+	}
+}
+
+// Instance methods -----------------------------------------------------
+func builtinRegexpInstanceMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			// Returns true if both regexps have the same source and flags.
+			//
+			// ```ruby
+			// /abc/ == /abc/  # => true
+			// /abc/ == /abc/i # => false
+			// ```
+			//
+			// @param object [Object]
+			// @return [Boolean]
+			Name: "==",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					r := receiver.(*RegexpObject)
+					other, ok := args[0].(*RegexpObject)
+
+					return toBooleanObject(ok && r.source == other.source && r.flags == other.flags)
+				}
+			},
+		},
+		{
+			// Returns the index of the first match in the string, or nil if there's no match.
+			//
+			// ```ruby
+			// /b+/ =~ "abbc" # => 1
+			// /d/ =~ "abbc"  # => nil
+			// ```
+			//
+			// @param string [String]
+			// @return [Integer]
+			Name: "=~",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					switch s := args[0].(type) {
+					case *StringObject:
+						return t.vm.matchIndex(receiver.(*RegexpObject), s.value)
+					case *NullObject:
+						return NULL
+					default:
+						return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.StringClass, s.Class().Name)
+					}
+				}
+			},
+		},
+		{
+			// Returns a MatchData of the first match in the string, or nil if there's no match.
+			//
+			// ```ruby
+			// m = /(\d+)-(\d+)/.match("tel: 12-34")
+			// m[0] # => "12-34"
+			// m[2] # => "34"
+			// /x/.match("abc") # => nil
+			// ```
+			//
+			// @param string [String]
+			// @return [MatchData]
+			Name: "match",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					s, ok := args[0].(*StringObject)
+					if !ok {
+						return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.StringClass, args[0].Class().Name)
+					}
+
+					return t.vm.initMatchDataObject(receiver.(*RegexpObject), s.value)
+				}
+			},
+		},
+		{
+			// Returns true if the regexp matches the string.
+			//
+			// ```ruby
+			// /b+/.match?("abbc") # => true
+			// ```
+			//
+			// @param string [String]
+			// @return [Boolean]
+			Name: "match?",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					s, ok := args[0].(*StringObject)
+					if !ok {
+						return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.StringClass, args[0].Class().Name)
+					}
+
+					return toBooleanObject(receiver.(*RegexpObject).regexp.MatchString(s.value))
+				}
+			},
+		},
+		{
+			// Returns the pattern of the regexp, without slashes and flags.
+			//
+			// ```ruby
+			// /ab+c/i.source # => "ab+c"
+			// ```
+			//
+			// @return [String]
+			Name: "source",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initStringObject(receiver.(*RegexpObject).source)
+				}
+			},
+		},
+		{
+			// Returns the regexp in its literal form.
+			//
+			// ```ruby
+			// /ab+c/i.to_s # => "/ab+c/i"
+			// ```
+			//
+			// @return [String]
+			Name: "to_s",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initStringObject(receiver.toString())
+				}
+			},
+		},
+	}
+}
+
+// Internal functions ===================================================
+
+// Functions for initialization -----------------------------------------
+
+// initRegexpObject compiles the pattern with the flags, and returns a RegexpError if it's invalid
+func (vm *VM) initRegexpObject(source, flags string) Object {
+	prefix := ""
+
+	for _, f := range flags {
+		switch f {
+		case 'i':
+			prefix += "i"
+		case 'm':
+			prefix += "s"
+		default:
+			return vm.initErrorObject(errors.RegexpError, "Unsupported regexp flag: %c", f)
+		}
+	}
+
+	pattern := source
+
+	if len(prefix) > 0 {
+		pattern = "(?" + prefix + ")" + pattern
+	}
+
+	r, err := regexp.Compile(pattern)
+	if err != nil {
+		return vm.initErrorObject(errors.RegexpError, "Invalid regexp /%s/: %s", source, err.Error())
+	}
+
+	return &RegexpObject{
+		baseObj: &baseObj{class: vm.topLevelClass(classes.RegexpClass)},
+		regexp:  r,
+		source:  source,
+		flags:   flags,
+	}
+}
+
+func (vm *VM) initRegexpClass() *RClass {
+	rc := vm.initializeClass(classes.RegexpClass, false)
+	rc.setBuiltinMethods(builtinRegexpInstanceMethods(), false)
+	rc.setBuiltinMethods(builtinRegexpClassMethods(), true)
+	return rc
+}
+
+// Polymorphic helper functions -----------------------------------------
+
+// Value returns the Go regexp
+func (r *RegexpObject) Value() interface{} {
+	return r.regexp
+}
+
+// toString returns the regexp in its literal form
+func (r *RegexpObject) toString() string {
+	return fmt.Sprintf("/%s/%s", r.source, r.flags)
+}
+
+// toJSON returns the regexp's literal form as a JSON string
+func (r *RegexpObject) toJSON() string {
+	return fmt.Sprintf("%q", r.toString())
+}
+
+// Other helper functions ----------------------------------------------
+
+// matchIndex returns the character index of the first match in the string, or nil if there's no match
+func (vm *VM) matchIndex(r *RegexpObject, s string) Object {
+	loc := r.regexp.FindStringIndex(s)
+
+	if loc == nil {
+		return NULL
+	}
+
+	return vm.initIntegerObject(utf8.RuneCountInString(s[:loc[0]]))
+}
+
+// toRegexp converts a String pattern into a Regexp which matches the string literally
+func (vm *VM) toRegexp(pattern Object) (*RegexpObject, bool) {
+	switch p := pattern.(type) {
+	case *RegexpObject:
+		return p, true
+	case *StringObject:
+		return vm.initRegexpObject(regexp.QuoteMeta(p.value), "").(*RegexpObject), true
+	}
+
+	return nil, false
+}
+
+// expandReplacement converts references to groups in the replacement, like `\1` or `\k<name>`,
+// into the `${1}` or `${name}` form of Go's `regexp` package
+func expandReplacement(replacement string) string {
+	var out bytes.Buffer
+	runes := []rune(replacement)
+
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+
+		switch {
+		case c == '$':
+			out.WriteString("$$")
+		case c == '\\' && i+1 < len(runes) && '0' <= runes[i+1] && runes[i+1] <= '9':
+			out.WriteString("${" + string(runes[i+1]) + "}")
+			i++
+		case c == '\\' && i+1 < len(runes) && runes[i+1] == 'k' && i+2 < len(runes) && runes[i+2] == '<':
+			end := strings.IndexRune(string(runes[i+3:]), '>')
+
+			if end < 0 {
+				out.WriteRune(c)
+				continue
+			}
+
+			name := string(runes[i+3:])[:end]
+			out.WriteString("${" + name + "}")
+			i += 3 + utf8.RuneCountInString(name)
+		case c == '\\' && i+1 < len(runes) && runes[i+1] == '\\':
+			out.WriteRune('\\')
+			i++
+		default:
+			out.WriteRune(c)
+		}
+	}
+
+	return out.String()
+}
@@ -0,0 +1,134 @@
+package vm
+
+import (
+	"testing"
+)
+
+func TestRegexpClassSuperclass(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`Regexp.class.name`, "Class"},
+		{`Regexp.superclass.name`, "Object"},
+		{`/a/.class.name`, "Regexp"},
+		{`/(a)/.match("a").class.name`, "MatchData"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestRegexpMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`/b+/ =~ "abbc"`, 1},
+		{`/d/ =~ "abbc"`, nil},
+		{`/b/ =~ nil`, nil},
+		{`/😊/ =~ "Hi😊"`, 2},
+		{`/ABC/i =~ "xabc"`, 1},
+		{`/a.b/m =~ "a\nb"`, 0},
+		{`/a.b/ =~ "a\nb"`, nil},
+		{`/b+/.match?("abbc")`, true},
+		{`/x/.match?("abbc")`, false},
+		{`/x/.match("abc")`, nil},
+		{`/ab+c/i.source`, "ab+c"},
+		{`/ab+c/i.to_s`, "/ab+c/i"},
+		{`/a\/b/ =~ "xa/b"`, 1},
+		{`/a/ == /a/`, true},
+		{`/a/ == /a/i`, false},
+		{`/a/ == "a"`, false},
+		{`Regexp.new("go+", "i") =~ "GOOD"`, 0},
+		{`Regexp.new(/a/i).to_s`, "/a/i"},
+		{`Regexp.escape("1.5+2")`, `1\.5\+2`},
+		{`Regexp.new(Regexp.escape("1.5")) =~ "x1.5"`, 1},
+		{`
+		x = 10
+		y = 2
+		x / y + x/y
+		`, 10},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestRegexpMethodsFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`/a(/`, "RegexpError: Invalid regexp /a(/: error parsing regexp: missing closing ): `a(`", 1},
+		{`/a/x`, "RegexpError: Unsupported regexp flag: x", 1},
+		{`Regexp.new("a", "z")`, "RegexpError: Unsupported regexp flag: z", 1},
+		{`Regexp.new(1)`, "TypeError: Expect argument to be String. got: Integer", 1},
+		{`Regexp.new`, "ArgumentError: Expect 1 or 2 arguments. got: 0", 1},
+		{`/a/ =~ 1`, "TypeError: Expect argument to be String. got: Integer", 1},
+		{`/a/.match(1)`, "TypeError: Expect argument to be String. got: Integer", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestMatchDataMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`/(\d+)-(\d+)/.match("tel: 12-34!")[0]`, "12-34"},
+		{`/(\d+)-(\d+)/.match("tel: 12-34!")[2]`, "34"},
+		{`/(\d+)-(\d+)/.match("tel: 12-34!")[3]`, nil},
+		{`/(a)|(b)/.match("b")[1]`, nil},
+		{`/(?<key>\w+)=(\d+)/.match("width=10")[:key]`, "width"},
+		{`/(?<key>\w+)=(\d+)/.match("width=10")["key"]`, "width"},
+		{`/(\d+)-(\d+)/.match("12-34").captures.to_s`, `["12", "34"]`},
+		{`/(\d+)-(\d+)/.match("12-34").to_a.to_s`, `["12-34", "12", "34"]`},
+		{`/(\d+)-(\d+)/.match("12-34").length`, 3},
+		{`/\d+/.match("ab12cd").pre_match`, "ab"},
+		{`/\d+/.match("ab12cd").post_match`, "cd"},
+		{`/\d+/.match("ab12cd").to_s`, "12"},
+		{`/(\d+)/.match("😊b12c").begin(1)`, 2},
+		{`/(\d+)/.match("😊b12c").end(0)`, 4},
+		{`/(?<key>\w+)=(?<value>\d+)/.match("width=10").named_captures["value"]`, "10"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestMatchDataMethodsFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`MatchData.new`, "UnsupportedMethodError: Unsupported Method #new for MatchData", 1},
+		{`/a/.match("a")[:name]`, "ArgumentError: Undefined group name: name", 1},
+		{`/a/.match("a")[1.5]`, "TypeError: Expect argument to be Integer or String. got: Float", 1},
+		{`/a/.match("a").begin(2)`, "ArgumentError: Index 2 out of matches", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
@@ -3,13 +3,9 @@ package vm
 import "github.com/goby-lang/goby/compiler/bytecode"
 
 // InitForREPL does following things:
-// - Initialize instruction sets' index tables
 // - Set vm to REPL mode
 // - Create and push main object frame
 func (vm *VM) InitForREPL() {
-	vm.SetClassISIndexTable("")
-	vm.SetMethodISIndexTable("")
-
 	// REPL should maintain a base call frame so that the whole program won't exit
 	cf := newCallFrame(&instructionSet{name: "REPL base"})
 	cf.self = vm.mainObj
@@ -23,14 +19,6 @@ func (vm *VM) REPLExec(sets []*bytecode.InstructionSet) {
 	p.vm = vm
 	p.transferInstructionSets(sets)
 
-	for setType, table := range p.setTable {
-		for name, is := range table {
-			vm.isTables[setType][name] = is
-		}
-	}
-
-	vm.blockTables[p.filename] = p.blockTable
-
 	oldFrame := vm.mainThread.callFrameStack.pop()
 	cf := newCallFrame(p.program)
 	cf.self = vm.mainObj
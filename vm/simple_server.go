@@ -87,7 +87,7 @@ func builtinSimpleServerInstanceMethods() []*BuiltinMethodObject {
 					go func() {
 						for range c {
 							log.Println("SimpleServer gracefully stopped")
-							os.Exit(0)
+							t.vm.exit(0)
 						}
 					}()
 
@@ -143,7 +143,7 @@ func newHandler(t *thread, blockFrame *callFrame) func(http.ResponseWriter, *htt
 		res := httpResponseClass.initializeInstance()
 
 		req := initRequest(t, w, r)
-		result := thread.builtinMethodYield(blockFrame, req, res)
+		result := thread.runBlock(blockFrame, req, res)
 
 		if err, ok := result.Target.(*Error); ok {
 			log.Printf("Error: %s", err.Message)
@@ -216,8 +216,8 @@ func setupResponse(w http.ResponseWriter, req *http.Request, res *RObject) {
 	h, ok := res.instanceVariableGet("@headers")
 
 	if headers, isHashObject := h.(*HashObject); ok && isHashObject {
-		for k, v := range headers.Pairs {
-			w.Header().Set(k, v.(*StringObject).value)
+		for _, p := range headers.pairs {
+			w.Header().Set(p.key.toString(), p.value.(*StringObject).value)
 		}
 	} else {
 		r.contentType = "text/plain; charset=utf-8"
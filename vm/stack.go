@@ -1,8 +1,6 @@
 package vm
 
 import (
-	"fmt"
-	"os"
 	"sync"
 )
 
@@ -16,30 +14,17 @@ type stack struct {
 }
 
 func (s *stack) set(index int, pointer *Pointer) {
-	t := s.thread
-
-	if err, ok := pointer.Target.(*Error); ok {
-		cf := t.callFrameStack.top()
-		cf.pc = len(cf.instructionSet.instructions)
-
-		if t.vm.mode == NormalMode {
-			if t.isMainThread() {
-				fmt.Println(err.Message)
-				os.Exit(1)
-			}
-		}
-	}
-
 	s.Lock()
-
-	defer s.Unlock()
-
 	s.Data[index] = pointer
+	s.Unlock()
+
+	if err, ok := pointer.Target.(*Error); ok && err.raised {
+		s.thread.handleError(err)
+	}
 }
 
 func (s *stack) push(v *Pointer) {
 	s.Lock()
-	defer s.Unlock()
 
 	if len(s.Data) <= s.thread.sp {
 		s.Data = append(s.Data, v)
@@ -47,20 +32,12 @@ func (s *stack) push(v *Pointer) {
 		s.Data[s.thread.sp] = v
 	}
 
-	if err, ok := v.Target.(*Error); ok {
-		t := s.thread
-		cf := t.callFrameStack.top()
-		cf.pc = len(cf.instructionSet.instructions)
-
-		if t.vm.mode == NormalMode {
-			if t.isMainThread() {
-				fmt.Println(err.Message)
-				os.Exit(1)
-			}
-		}
-	}
-
 	s.thread.sp++
+	s.Unlock()
+
+	if err, ok := v.Target.(*Error); ok && err.raised {
+		s.thread.handleError(err)
+	}
 }
 
 func (s *stack) pop() *Pointer {
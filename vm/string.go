@@ -1,6 +1,7 @@
 package vm
 
 import (
+	"bytes"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -245,6 +246,32 @@ func builtinStringInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Returns the character index of the first match of the regexp, or nil if there's no match.
+			//
+			// ```ruby
+			// "abbc" =~ /b+/ # => 1
+			// "abbc" =~ /d/  # => nil
+			// ```
+			//
+			// @param regexp [Regexp]
+			// @return [Integer]
+			Name: "=~",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect 1 argument. got=%v", strconv.Itoa(len(args)))
+					}
+
+					r, ok := args[0].(*RegexpObject)
+					if !ok {
+						return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.RegexpClass, args[0].Class().Name)
+					}
+
+					return t.vm.matchIndex(r, receiver.(*StringObject).value)
+				}
+			},
+		},
 		{
 			// Returns a Integer. If first string is less than second string returns -1, if equal to returns 0, if greater returns 1
 			//
@@ -775,53 +802,40 @@ func builtinStringInstanceMethods() []*BuiltinMethodObject {
 			},
 		},
 		{
-			// TODO: Implement String#gsub When RegexObject Implemented
 			// Returns a copy of str with the all occurrences of pattern substituted for the second argument.
-			// The pattern is typically a String or Regexp (Not implemented yet); if given as a String, any
-			// regular expression metacharacters it contains will be interpreted literally, e.g. '\\d' will
-			// match a backslash followed by ‘d’, instead of a digit.
+			// The pattern can be a String or a Regexp. If it's a String, any regular expression metacharacters
+			// it contains will be interpreted literally, e.g. '\\d' will match a backslash followed by ‘d’, instead of a digit.
+			// If it's a Regexp, the replacement can refer to the groups with `\\1` or `\\k<name>`.
 			//
-			// Currently only support string version of String#gsub.
+			// If a block is given instead of the replacement, each match is replaced with the block's result.
 			//
 			// ```ruby
 			// "Ruby Lang".gsub("Ru", "Go")                # => "Goby Lang"
 			// "Hello 😊 Hello 😊 Hello".gsub("😊", "🐟") # => "Hello 🐟 Hello 🐟 Hello"
+			// "Goby Lang".gsub(/[aeiou]/, "*")            # => "G*by L*ng"
+			// "2017-10".gsub(/(\d+)-(\d+)/, "\\2/\\1")     # => "10/2017"
+			// "goby lang".gsub(/\w+/) do |word|
+			//   word.upcase
+			// end
+			// # => "GOBY LANG"
 			// ```
 			//
+			// @param pattern [String/Regexp], replacement [String]
 			// @return [String]
 			Name: "gsub",
 			Fn: func(receiver Object) builtinMethodBody {
 				return func(t *thread, args []Object, blockFrame *callFrame) Object {
-					if len(args) != 2 {
-						return t.vm.initErrorObject(errors.ArgumentError, "Expect 2 arguments. got=%v", len(args))
-					}
-
-					str := receiver.(*StringObject).value
-
-					p := args[0]
-					pattern, ok := p.(*StringObject)
-
-					if !ok {
-						return t.vm.initErrorObject(errors.TypeError, "Expect pattern to be String. got: %s", p.Class().Name)
-					}
-
-					r := args[1]
-					replacement, ok := r.(*StringObject)
-
-					if !ok {
-						return t.vm.initErrorObject(errors.TypeError, "Expect replacement to be String. got: %s", r.Class().Name)
-					}
-
-					return t.vm.initStringObject(strings.Replace(str, pattern.value, replacement.value, -1))
+					return t.substitute(receiver.(*StringObject).value, args, blockFrame, -1)
 				}
 			},
 		},
 		{
-			// Checks if the specified string is included in the receiver
+			// Checks if the specified string is included in the receiver, or the specified regexp matches the receiver
 			//
 			// ```ruby
 			// "Hello\nWorld".include?("\n")   # => true
 			// "Hello 😊 Hello".include?("😊") # => true
+			// "Hello".include?(/l+o/)         # => true
 			// ```
 			//
 			// @return [Bool]
@@ -834,10 +848,15 @@ func builtinStringInstanceMethods() []*BuiltinMethodObject {
 
 					str := receiver.(*StringObject).value
 					i := args[0]
+
+					if r, ok := i.(*RegexpObject); ok {
+						return toBooleanObject(r.regexp.MatchString(str))
+					}
+
 					includeStr, ok := i.(*StringObject)
 
 					if !ok {
-						return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.StringClass, i.Class().Name)
+						return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, "String or Regexp", i.Class().Name)
 					}
 
 					if strings.Contains(str, includeStr.value) {
@@ -992,6 +1011,47 @@ func builtinStringInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Returns a MatchData of the first match of the pattern, or nil if there's no match.
+			// A String pattern is compiled into a Regexp.
+			//
+			// ```ruby
+			// m = "tel: 12-34".match(/(\d+)-(\d+)/)
+			// m[1]                 # => "12"
+			// "abc".match("b.")[0] # => "bc"
+			// "abc".match(/x/)     # => nil
+			// ```
+			//
+			// @param pattern [String/Regexp]
+			// @return [MatchData]
+			Name: "match",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect 1 argument. got=%v", strconv.Itoa(len(args)))
+					}
+
+					var r *RegexpObject
+
+					switch p := args[0].(type) {
+					case *RegexpObject:
+						r = p
+					case *StringObject:
+						compiled := t.vm.initRegexpObject(p.value, "")
+
+						if err, ok := compiled.(*Error); ok {
+							return err
+						}
+
+						r = compiled.(*RegexpObject)
+					default:
+						return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, "String or Regexp", p.Class().Name)
+					}
+
+					return t.vm.initMatchDataObject(r, receiver.(*StringObject).value)
+				}
+			},
+		},
 		{
 			// Return a string replaced by the input string
 			//
@@ -1114,6 +1174,52 @@ func builtinStringInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Returns an array of all matches of the pattern.
+			// If the regexp contains groups, each match is an array of the groups' strings.
+			//
+			// ```ruby
+			// "a1b22c333".scan(/\d+/)          # => ["1", "22", "333"]
+			// "a=1, b=2".scan(/(\w)=(\d)/)     # => [["a", "1"], ["b", "2"]]
+			// "banana".scan("an")              # => ["an", "an"]
+			// ```
+			//
+			// @param pattern [String/Regexp]
+			// @return [Array]
+			Name: "scan",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect 1 argument. got=%v", strconv.Itoa(len(args)))
+					}
+
+					r, ok := t.vm.toRegexp(args[0])
+					if !ok {
+						return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, "String or Regexp", args[0].Class().Name)
+					}
+
+					str := receiver.(*StringObject).value
+					matches := []Object{}
+
+					for _, m := range r.regexp.FindAllStringSubmatch(str, -1) {
+						if len(m) == 1 {
+							matches = append(matches, t.vm.initStringObject(m[0]))
+							continue
+						}
+
+						groups := []Object{}
+
+						for _, g := range m[1:] {
+							groups = append(groups, t.vm.initStringObject(g))
+						}
+
+						matches = append(matches, t.vm.initArrayObject(groups))
+					}
+
+					return t.vm.initArrayObject(matches)
+				}
+			},
+		},
 		{
 			// Returns the character length of self
 			// **Note:** the length is currently byte-based, instead of charcode-based.
@@ -1243,9 +1349,10 @@ func builtinStringInstanceMethods() []*BuiltinMethodObject {
 			},
 		},
 		{
-			// Returns an array of strings separated by the given separator
+			// Returns an array of strings separated by the given separator, which can be a String or a Regexp
 			//
 			// ```ruby
+			// "a1b22c".split(/\d+/)    # => ["a", "b", "c"]
 			// "Hello World".split("o") # => ["Hell", " W", "rld"]
 			// "Goby".split("")         # => ["G", "o", "b", "y"]
 			// "Hello\nWorld\nGoby".split("o") # => ["Hello", "World", "Goby"]
@@ -1261,15 +1368,18 @@ func builtinStringInstanceMethods() []*BuiltinMethodObject {
 					}
 
 					s := args[0]
-					seperator, ok := s.(*StringObject)
+					str := receiver.(*StringObject).value
+					var arr []string
 
-					if !ok {
-						return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.StringClass, s.Class().Name)
+					switch seperator := s.(type) {
+					case *StringObject:
+						arr = strings.Split(str, seperator.value)
+					case *RegexpObject:
+						arr = seperator.regexp.Split(str, -1)
+					default:
+						return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, "String or Regexp", s.Class().Name)
 					}
 
-					str := receiver.(*StringObject).value
-					arr := strings.Split(str, seperator.value)
-
 					var elements []Object
 					for i := 0; i < len(arr); i++ {
 						elements = append(elements, t.vm.initStringObject(arr[i]))
@@ -1354,6 +1464,25 @@ func builtinStringInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Returns a copy of str with the first occurrence of pattern substituted for the second argument.
+			// It works like `gsub` except only the first match is replaced.
+			//
+			// ```ruby
+			// "Goby Goby".sub("Goby", "Ruby")       # => "Ruby Goby"
+			// "a1b2".sub(/\d/, "#")                 # => "a#b2"
+			// "2017-10".sub(/(\d+)-(\d+)/, "\\2/\\1") # => "10/2017"
+			// ```
+			//
+			// @param pattern [String/Regexp], replacement [String]
+			// @return [String]
+			Name: "sub",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.substitute(receiver.(*StringObject).value, args, blockFrame, 1)
+				}
+			},
+		},
 		{
 			// Returns an array of characters converted from a string
 			//
@@ -1475,6 +1604,21 @@ func builtinStringInstanceMethods() []*BuiltinMethodObject {
 				}
 			},
 		},
+		{
+			// Returns the Symbol with the name of the String
+			//
+			// ```ruby
+			// "foo".to_sym # => :foo
+			// ```
+			//
+			// @return [Symbol]
+			Name: "to_sym",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initSymbolObject(receiver.(*StringObject).value)
+				}
+			},
+		},
 		{
 			// Returns a new String with all characters is upcase
 			//
@@ -1520,6 +1664,7 @@ func (vm *VM) initStringClass() *RClass {
 	sc := vm.initializeClass(classes.StringClass, false)
 	sc.setBuiltinMethods(builtinStringInstanceMethods(), false)
 	sc.setBuiltinMethods(builtinStringClassMethods(), true)
+	sc.includeModule(vm.topLevelClass(classes.ComparableModule))
 	return sc
 }
 
@@ -1544,3 +1689,72 @@ func (s *StringObject) toJSON() string {
 func (s *StringObject) equal(e *StringObject) bool {
 	return s.value == e.value
 }
+
+// Other helper functions ----------------------------------------------
+
+// substitute replaces at most n matches of the pattern in the string, all matches are replaced if n is -1.
+// The matches are replaced with the replacement argument, or the block's results if the block is given.
+func (t *thread) substitute(str string, args []Object, blockFrame *callFrame, n int) Object {
+	if blockFrame != nil && len(args) != 1 {
+		return t.vm.initErrorObject(errors.ArgumentError, "Expect 1 argument. got=%v", len(args))
+	}
+
+	if blockFrame == nil && len(args) != 2 {
+		return t.vm.initErrorObject(errors.ArgumentError, "Expect 2 arguments. got=%v", len(args))
+	}
+
+	r, ok := t.vm.toRegexp(args[0])
+	if !ok {
+		return t.vm.initErrorObject(errors.TypeError, "Expect pattern to be String or Regexp. got: %s", args[0].Class().Name)
+	}
+
+	if blockFrame != nil {
+		var out bytes.Buffer
+		last := 0
+		matches := r.regexp.FindAllStringIndex(str, n)
+
+		// The block is never yielded, so we need to pop its frame manually
+		if len(matches) == 0 {
+			t.callFrameStack.pop()
+		}
+
+		for _, loc := range matches {
+			result := t.builtinMethodYield(blockFrame, t.vm.initStringObject(str[loc[0]:loc[1]])).Target
+
+			if err, ok := result.(*Error); ok {
+				return err
+			}
+
+			out.WriteString(str[last:loc[0]])
+			out.WriteString(result.toString())
+			last = loc[1]
+		}
+
+		out.WriteString(str[last:])
+
+		return t.vm.initStringObject(out.String())
+	}
+
+	replacement, ok := args[1].(*StringObject)
+	if !ok {
+		return t.vm.initErrorObject(errors.TypeError, "Expect replacement to be String. got: %s", args[1].Class().Name)
+	}
+
+	// A String pattern and its replacement are both literal
+	if pattern, ok := args[0].(*StringObject); ok {
+		return t.vm.initStringObject(strings.Replace(str, pattern.value, replacement.value, n))
+	}
+
+	if n < 0 {
+		return t.vm.initStringObject(r.regexp.ReplaceAllString(str, expandReplacement(replacement.value)))
+	}
+
+	loc := r.regexp.FindStringSubmatchIndex(str)
+	if loc == nil {
+		return t.vm.initStringObject(str)
+	}
+
+	replaced := r.regexp.ExpandString(nil, expandReplacement(replacement.value), str, loc)
+
+	return t.vm.initStringObject(str[:loc[0]] + string(replaced) + str[loc[1]:])
+}
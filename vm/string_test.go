@@ -35,6 +35,33 @@ func TestEvalStringExpression(t *testing.T) {
 		{`'\'Alexius\''`, "'Alexius'"},
 		{`"Maxwell\nAlexius"`, "Maxwell\nAlexius"},
 		{`'Maxwell\nAlexius'`, "Maxwell\\nAlexius"},
+		{`"true"`, "true"},
+		{`"false"`, "false"},
+		{`"nil"`, "nil"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestStringInterpolation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`name = "Goby"; "Hello #{name}!"`, "Hello Goby!"},
+		{`"#{1 + 2}"`, "3"},
+		{`"#{1.5} #{nil} #{[1, 2]}"`, "1.5  [1, 2]"},
+		{`name = "Goby"; "a #{"b #{name.length} c"} d"`, "a b 4 c d"},
+		{`"#{ {a: 1}["a"] }"`, "1"},
+		{`"empty #{}!"`, "empty !"},
+		{`name = "Goby"; 'Hello #{name}!'`, "Hello #{name}!"},
+		{`name = "Goby"; "Hello \#{name}!"`, "Hello #{name}!"},
 	}
 
 	for i, tt := range tests {
@@ -705,7 +732,7 @@ func TestStringGlobalSubstituteMethodFail(t *testing.T) {
 	testsFail := []errorTestCase{
 		{`"Ruby".gsub()`, "ArgumentError: Expect 2 arguments. got=0", 1},
 		{`"Ruby".gsub("Ru")`, "ArgumentError: Expect 2 arguments. got=1", 1},
-		{`"Ruby".gsub(123, "Go")`, "TypeError: Expect pattern to be String. got: Integer", 1},
+		{`"Ruby".gsub(123, "Go")`, "TypeError: Expect pattern to be String or Regexp. got: Integer", 1},
 		{`"Ruby".gsub("Ru", 456)`, "TypeError: Expect replacement to be String. got: Integer", 1},
 	}
 
@@ -745,9 +772,9 @@ func TestStringIncludeMethodFail(t *testing.T) {
 	testsFail := []errorTestCase{
 		{`"Goby".include?`, "ArgumentError: Expect 1 argument. got=0", 1},
 		{`"Goby".include?("Ruby", "Lang")`, "ArgumentError: Expect 1 argument. got=2", 1},
-		{`"Goby".include?(2)`, "TypeError: Expect argument to be String. got: Integer", 1},
-		{`"Goby".include?(true)`, "TypeError: Expect argument to be String. got: Boolean", 1},
-		{`"Goby".include?(nil)`, "TypeError: Expect argument to be String. got: Null", 1},
+		{`"Goby".include?(2)`, "TypeError: Expect argument to be String or Regexp. got: Integer", 1},
+		{`"Goby".include?(true)`, "TypeError: Expect argument to be String or Regexp. got: Boolean", 1},
+		{`"Goby".include?(nil)`, "TypeError: Expect argument to be String or Regexp. got: Null", 1},
 	}
 
 	for i, tt := range testsFail {
@@ -1127,9 +1154,9 @@ func TestStringSplitMethod(t *testing.T) {
 func TestStringSplitMethodFail(t *testing.T) {
 	testsFail := []errorTestCase{
 		{`"Hello World".split`, "ArgumentError: Expect 1 argument. got=0", 1},
-		{`"Hello World".split(true)`, "TypeError: Expect argument to be String. got: Boolean", 1},
-		{`"Hello World".split(123)`, "TypeError: Expect argument to be String. got: Integer", 1},
-		{`"Hello World".split(1..2)`, "TypeError: Expect argument to be String. got: Range", 1},
+		{`"Hello World".split(true)`, "TypeError: Expect argument to be String or Regexp. got: Boolean", 1},
+		{`"Hello World".split(123)`, "TypeError: Expect argument to be String or Regexp. got: Integer", 1},
+		{`"Hello World".split(1..2)`, "TypeError: Expect argument to be String or Regexp. got: Range", 1},
 	}
 
 	for i, tt := range testsFail {
@@ -1256,3 +1283,78 @@ func TestFormattedString(t *testing.T) {
 		v.checkSP(t, i, 1)
 	}
 }
+
+func TestStringRegexpMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`"abbc" =~ /b+/`, 1},
+		{`"abbc" =~ /d/`, nil},
+		{`"Goby Lang".gsub(/[aeiou]/, "*")`, "G*by L*ng"},
+		{`"2017-10".gsub(/(\d+)-(\d+)/, "\\2/\\1")`, "10/2017"},
+		{`"width=10".gsub(/(?<key>\w+)=/, "\\k<key>: ")`, "width: 10"},
+		{`"a.b".gsub(".", "$")`, "a$b"},
+		{`"a1b2".gsub(/\d/, "$")`, "a$b$"},
+		{`
+		r = "goby lang".gsub(/\w+/) do |word|
+		  word.upcase
+		end
+		r
+		`, "GOBY LANG"},
+		{`
+		r = "goby".gsub(/x/) do |word|
+		  word.upcase
+		end
+		r
+		`, "goby"},
+		{`"a1b2".sub(/\d/, "#")`, "a#b2"},
+		{`"Goby Goby".sub("Goby", "Ruby")`, "Ruby Goby"},
+		{`"2017-10 2018-11".sub(/(\d+)-(\d+)/, "\\2/\\1")`, "10/2017 2018-11"},
+		{`"abc".sub(/x/, "y")`, "abc"},
+		{`
+		r = "a1b2".sub(/\d/) do |d|
+		  d.to_i + 1
+		end
+		r
+		`, "a2b2"},
+		{`"a1b22c333".scan(/\d+/).to_s`, `["1", "22", "333"]`},
+		{`"a=1, b=2".scan(/(\w)=(\d)/).to_s`, `[["a", "1"], ["b", "2"]]`},
+		{`"banana".scan("an").to_s`, `["an", "an"]`},
+		{`"abc".scan(/x/).to_s`, `[]`},
+		{`"tel: 12-34".match(/(\d+)-(\d+)/)[1]`, "12"},
+		{`"abc".match("b.")[0]`, "bc"},
+		{`"abc".match(/x/)`, nil},
+		{`"a1b22c".split(/\d+/).to_s`, `["a", "b", "c"]`},
+		{`"a, b,c".split(/,\s*/).to_s`, `["a", "b", "c"]`},
+		{`"Hello".include?(/l+o/)`, true},
+		{`"Hello".include?(/x/)`, false},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestStringRegexpMethodsFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`"a" =~ "a"`, "TypeError: Expect argument to be Regexp. got: String", 1},
+		{`"a".sub(/a/)`, "ArgumentError: Expect 2 arguments. got=1", 1},
+		{`"a".sub(/a/, 1)`, "TypeError: Expect replacement to be String. got: Integer", 1},
+		{`"a".scan(1)`, "TypeError: Expect argument to be String or Regexp. got: Integer", 1},
+		{`"a".match(1)`, "TypeError: Expect argument to be String or Regexp. got: Integer", 1},
+		{`"a".match("(")`, "RegexpError: Invalid regexp /(/: error parsing regexp: missing closing ): `(`", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
@@ -0,0 +1,208 @@
+package vm
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/goby-lang/goby/compiler/bytecode"
+	"github.com/goby-lang/goby/vm/classes"
+	"github.com/goby-lang/goby/vm/errors"
+)
+
+// SymbolObject represents a symbol like `:name`.
+// Symbols are interned, so symbols with the same name are always the same object.
+// They're usually used as names, like method names or attribute names.
+//
+// ```ruby
+// :foo.class       # => Symbol
+// :foo.to_s        # => "foo"
+// "foo".to_sym     # => :foo
+//
+// class User
+//   attr_reader :name
+// end
+//
+// 1.send(:+, 2)              # => 3
+// ["a", "b"].map(&:upcase)   # => ["A", "B"]
+// ```
+//
+type SymbolObject struct {
+	*baseObj
+	value string
+}
+
+// Class methods --------------------------------------------------------
+func builtinSymbolClassMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			Name: "new",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.unsupportedMethodError("#new", receiver)
+				}
+			},
+		},
+	}
+}
+
+// Instance methods -----------------------------------------------------
+func builtinSymbolInstanceMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			// Compares the names of two Symbols, returns -1, 0 or 1 if the receiver is less than, equal to or greater than the argument.
+			// Returns nil if the argument isn't a Symbol.
+			//
+			// ```ruby
+			// :a <=> :b       # => -1
+			// [:b, :a].sort   # => [:a, :b]
+			// ```
+			//
+			// @param symbol [Symbol]
+			// @return [Integer]
+			Name: "<=>",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					s, ok := args[0].(*SymbolObject)
+
+					if !ok {
+						return NULL
+					}
+
+					return t.vm.initIntegerObject(strings.Compare(receiver.(*SymbolObject).value, s.value))
+				}
+			},
+		},
+		{
+			// Returns a lambda that calls the method named by the symbol on its argument.
+			// It's usually used with `&` to pass it as a block.
+			//
+			// ```ruby
+			// :upcase.to_proc.call("foo") # => "FOO"
+			// [1, 2, 3].map(&:to_s)       # => ["1", "2", "3"]
+			// ```
+			//
+			// @return [Proc]
+			Name: "to_proc",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					s := receiver.(*SymbolObject)
+					return t.vm.initProcObject(s.blockFrame(t), true)
+				}
+			},
+		},
+		{
+			// Returns the name of the symbol as a String.
+			//
+			// ```ruby
+			// :foo.to_s # => "foo"
+			// ```
+			//
+			// @return [String]
+			Name: "to_s",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initStringObject(receiver.(*SymbolObject).value)
+				}
+			},
+		},
+		{
+			// Returns the symbol itself.
+			//
+			// ```ruby
+			// :foo.to_sym # => :foo
+			// ```
+			//
+			// @return [Symbol]
+			Name: "to_sym",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return receiver
+				}
+			},
+		},
+	}
+}
+
+// Internal functions ===================================================
+
+// Functions for initialization -----------------------------------------
+
+// initSymbolObject returns the interned symbol of the given name
+func (vm *VM) initSymbolObject(value string) *SymbolObject {
+	if s, ok := vm.symbolTable.Load(value); ok {
+		return s.(*SymbolObject)
+	}
+
+	s := &SymbolObject{
+		baseObj: &baseObj{class: vm.topLevelClass(classes.SymbolClass)},
+		value:   value,
+	}
+	actual, _ := vm.symbolTable.LoadOrStore(value, s)
+
+	return actual.(*SymbolObject)
+}
+
+func (vm *VM) initSymbolClass() *RClass {
+	sc := vm.initializeClass(classes.SymbolClass, false)
+	sc.setBuiltinMethods(builtinSymbolInstanceMethods(), false)
+	sc.setBuiltinMethods(builtinSymbolClassMethods(), true)
+	sc.includeModule(vm.topLevelClass(classes.ComparableModule))
+	return sc
+}
+
+// Polymorphic helper functions -----------------------------------------
+
+// Value returns the name of the symbol
+func (s *SymbolObject) Value() interface{} {
+	return s.value
+}
+
+// toString returns the name of the symbol
+func (s *SymbolObject) toString() string {
+	return s.value
+}
+
+// toJSON returns the name of the symbol as a JSON string
+func (s *SymbolObject) toJSON() string {
+	return strconv.Quote(s.value)
+}
+
+// inspect returns the symbol in its literal form, like `:foo`
+func (s *SymbolObject) inspect() string {
+	return ":" + s.value
+}
+
+// blockFrame returns a block frame which sends the symbol's method to the block's first argument,
+// this is what `|x| x.method_name` would be compiled into.
+func (s *SymbolObject) blockFrame(t *thread) *callFrame {
+	is := &instructionSet{name: "to_proc", isType: bytecode.Block, filename: t.callFrameStack.top().instructionSet.filename}
+	is.define(0, builtinActions[bytecode.GetLocal], 0, 0)
+	is.define(0, builtinActions[bytecode.Send], s.value, 0, (*instructionSet)(nil), false, &bytecode.ArgSet{}, false, &methodCache{})
+	is.define(0, builtinActions[bytecode.Leave])
+	is.paramTypes = bytecode.NewArgSet([]string{"receiver"}, []int{bytecode.NormalArg})
+
+	c := newCallFrame(is)
+	c.isBlock = true
+	c.ep = t.callFrameStack.top()
+	c.self = s
+
+	return c
+}
+
+// Other helper functions ----------------------------------------------
+
+// toName returns the name given by a String or a Symbol, which are both accepted as names by methods like `send`
+func toName(obj Object) (string, bool) {
+	switch o := obj.(type) {
+	case *StringObject:
+		return o.value, true
+	case *SymbolObject:
+		return o.value, true
+	}
+
+	return "", false
+}
@@ -0,0 +1,155 @@
+package vm
+
+import (
+	"testing"
+)
+
+func TestSymbolClassSuperclass(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`Symbol.class.name`, "Class"},
+		{`Symbol.superclass.name`, "Object"},
+		{`:foo.class.name`, "Symbol"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestSymbolEvaluation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`:foo.to_s`, "foo"},
+		{`:empty?.to_s`, "empty?"},
+		{`:<=>.to_s`, "<=>"},
+		{`:foo == :foo`, true},
+		{`:foo == :bar`, false},
+		{`:foo == "foo"`, false},
+		{`"foo".to_sym == :foo`, true},
+		{`:foo.to_sym == :foo`, true},
+		{`[:a, "b", 1].to_s`, `[:a, "b", 1]`},
+		{`{ a: :b }.to_s`, `{ a: :b }`},
+		{`{ a: :b }.to_json`, `{"a":"b"}`},
+		{`:a <=> :b`, -1},
+		{`:b <=> :b`, 0},
+		{`:b <=> :a`, 1},
+		{`:a <=> "a"`, nil},
+		{`[:c, :a, :b].sort.to_s`, `[:a, :b, :c]`},
+		{`:b.between?(:a, :c)`, true},
+		{`:a > :b`, false},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestSymbolInterning(t *testing.T) {
+	v := initTestVM()
+	evaluated := v.testEval(t, `[:foo, "foo".to_sym]`, getFilename())
+	arr := evaluated.(*ArrayObject)
+
+	if arr.Elements[0] != arr.Elements[1] {
+		t.Fatalf("Expect symbols with the same name to be the same object")
+	}
+}
+
+func TestSymbolAsName(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`1.send(:+, 2)`, 3},
+		{`
+		class Foo
+		  attr_accessor :bar
+		  attr_reader :baz
+
+		  def initialize
+		    @baz = 10
+		  end
+		end
+
+		f = Foo.new
+		f.bar = 5
+		f.bar + f.baz + f.instance_variable_get(:@baz)
+		`, 25},
+		{`
+		h = { a: 1 }
+		h[:a] = 2
+		h[:a] + h["a"]
+		`, 3},
+		{`{ a: { b: 1 } }.dig("a", "b")`, 1},
+		{`{ a: 1 }.has_key?(:a)`, false},
+		{`{ a: 1 }.has_key?("a")`, true},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestSymbolToProc(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`:upcase.to_proc.call("foo")`, "FOO"},
+		{`:upcase.to_proc.lambda?`, true},
+		{`:upcase.to_proc.arity`, 1},
+		{`["a", "b"].map(&:upcase).to_s`, `["A", "B"]`},
+		{`[1, 2, 3].map(&:to_s)[2]`, "3"},
+		{`
+		class Foo
+		  def initialize(n)
+		    @n = n
+		  end
+
+		  def n
+		    @n
+		  end
+		end
+
+		[Foo.new(1), Foo.new(2)].map(&:n).to_s
+		`, "[1, 2]"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestSymbolMethodFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`Symbol.new`, "UnsupportedMethodError: Unsupported Method #new for Symbol", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
@@ -0,0 +1,810 @@
+package vm
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/goby-lang/goby/vm/classes"
+	"github.com/goby-lang/goby/vm/errors"
+)
+
+// The classes defined under the Sync module
+const (
+	mutexClassName         = "Mutex"
+	rwMutexClassName       = "RWMutex"
+	waitGroupClassName     = "WaitGroup"
+	onceClassName          = "Once"
+	atomicIntegerClassName = "AtomicInteger"
+)
+
+// MutexObject is a mutual exclusion lock, it's unlocked when created.
+//
+// ```ruby
+// m = Sync::Mutex.new
+// count = 0
+//
+// threads = []
+// 10.times do
+//   t = thread do
+//     m.synchronize do
+//       count += 1
+//     end
+//   end
+//   threads.push(t)
+// end
+//
+// threads.each do |t|
+//   t.join
+// end
+// count # => 10
+// ```
+//
+type MutexObject struct {
+	*baseObj
+	// sem is a semaphore with one slot, the mutex is locked by filling the slot.
+	// Unlike sync.Mutex, it can be acquired without blocking
+	sem chan struct{}
+	// locked is 1 when the mutex is locked, it prevents unlocking an unlocked mutex, which can't be recovered in Go
+	locked int32
+}
+
+// RWMutexObject is a reader/writer lock, which can be held by many readers or a single writer.
+//
+// ```ruby
+// m = Sync::RWMutex.new
+// m.read_synchronize do
+//   # read shared state
+// end
+// m.synchronize do
+//   # write shared state
+// end
+// ```
+//
+type RWMutexObject struct {
+	*baseObj
+	mutex   sync.RWMutex
+	locked  int32
+	readers int32
+}
+
+// WaitGroupObject waits for a collection of threads to finish.
+//
+// ```ruby
+// wg = Sync::WaitGroup.new
+// 3.times do
+//   wg.add
+//   thread do
+//     # do some work
+//     wg.done
+//   end
+// end
+// wg.wait
+// ```
+//
+type WaitGroupObject struct {
+	*baseObj
+	wg    sync.WaitGroup
+	count int64
+}
+
+// OnceObject runs a block only once, no matter how many threads call it.
+//
+// ```ruby
+// once = Sync::Once.new
+// 3.times do
+//   once.run do
+//     puts("Only once")
+//   end
+// end
+// ```
+//
+type OnceObject struct {
+	*baseObj
+	once sync.Once
+}
+
+// AtomicIntegerObject is an integer which can be updated by many threads safely.
+//
+// ```ruby
+// i = Sync::AtomicInteger.new(10)
+// i.increment    # => 11
+// i.decrement(2) # => 9
+// i.value        # => 9
+// ```
+//
+type AtomicIntegerObject struct {
+	*baseObj
+	value int64
+}
+
+// Class methods --------------------------------------------------------
+
+// builtinSyncClassMethods returns the class methods of a Sync class, which creates its instances with the given function
+func builtinSyncClassMethods(initialize func(t *thread, base *baseObj, args []Object) Object) []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			// Creates a primitive of Sync classes, like `Sync::Mutex.new`.
+			// `Sync::AtomicInteger.new` takes an optional initial value, which is 0 by default.
+			//
+			// ```ruby
+			// Sync::Mutex.new
+			// Sync::AtomicInteger.new(10)
+			// ```
+			//
+			// @return [Object]
+			Name: "new",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return initialize(t, &baseObj{class: receiver.(*RClass)}, args)
+				}
+			},
+		},
+	}
+}
+
+// Instance methods -----------------------------------------------------
+func builtinMutexInstanceMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			// Acquires the lock, it blocks until the lock is available.
+			//
+			// @return [Mutex]
+			Name: "lock",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					receiver.(*MutexObject).lock()
+
+					return receiver
+				}
+			},
+		},
+		{
+			// Returns true if the lock is held by any thread.
+			//
+			// @return [Boolean]
+			Name: "locked?",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return toBooleanObject(atomic.LoadInt32(&receiver.(*MutexObject).locked) == 1)
+				}
+			},
+		},
+		{
+			// Acquires the lock, yields the block and releases the lock, even if the block raises an error.
+			// Returns the result of the block.
+			//
+			// ```ruby
+			// m = Sync::Mutex.new
+			// m.synchronize do
+			//   1 + 1
+			// end # => 2
+			// ```
+			//
+			// @return [Object]
+			Name: "synchronize",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if blockFrame == nil {
+						return t.vm.initErrorObject(errors.InternalError, errors.CantYieldWithoutBlockFormat)
+					}
+
+					m := receiver.(*MutexObject)
+					m.lock()
+					defer m.unlock()
+
+					return t.builtinMethodYield(blockFrame).Target
+				}
+			},
+		},
+		{
+			// Acquires the lock if it's available without blocking, and returns true if the lock is acquired.
+			//
+			// @return [Boolean]
+			Name: "try_lock",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					m := receiver.(*MutexObject)
+
+					return toBooleanObject(m.tryLock())
+				}
+			},
+		},
+		{
+			// Releases the lock, it raises an error if the mutex isn't locked.
+			//
+			// @return [Mutex]
+			Name: "unlock",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if !receiver.(*MutexObject).unlock() {
+						return t.vm.initErrorObject(errors.InternalError, "Can't unlock an unlocked %s", receiver.Class().Name)
+					}
+
+					return receiver
+				}
+			},
+		},
+	}
+}
+
+// Instance methods -----------------------------------------------------
+func builtinRWMutexInstanceMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			// Acquires the lock for writing, it blocks until there's no reader or writer.
+			//
+			// @return [RWMutex]
+			Name: "lock",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					receiver.(*RWMutexObject).lock()
+
+					return receiver
+				}
+			},
+		},
+		{
+			// Acquires the lock for reading, it blocks while a writer holds the lock.
+			//
+			// @return [RWMutex]
+			Name: "read_lock",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					receiver.(*RWMutexObject).readLock()
+
+					return receiver
+				}
+			},
+		},
+		{
+			// Acquires the lock for reading, yields the block and releases the lock. Returns the result of the block.
+			//
+			// ```ruby
+			// m = Sync::RWMutex.new
+			// m.read_synchronize do
+			//   10
+			// end # => 10
+			// ```
+			//
+			// @return [Object]
+			Name: "read_synchronize",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if blockFrame == nil {
+						return t.vm.initErrorObject(errors.InternalError, errors.CantYieldWithoutBlockFormat)
+					}
+
+					m := receiver.(*RWMutexObject)
+					m.readLock()
+					defer m.readUnlock()
+
+					return t.builtinMethodYield(blockFrame).Target
+				}
+			},
+		},
+		{
+			// Releases the lock for reading, it raises an error if the mutex isn't locked for reading.
+			//
+			// @return [RWMutex]
+			Name: "read_unlock",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if !receiver.(*RWMutexObject).readUnlock() {
+						return t.vm.initErrorObject(errors.InternalError, "Can't unlock an unlocked %s", receiver.Class().Name)
+					}
+
+					return receiver
+				}
+			},
+		},
+		{
+			// Acquires the lock for writing, yields the block and releases the lock. Returns the result of the block.
+			//
+			// ```ruby
+			// m = Sync::RWMutex.new
+			// m.synchronize do
+			//   10
+			// end # => 10
+			// ```
+			//
+			// @return [Object]
+			Name: "synchronize",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if blockFrame == nil {
+						return t.vm.initErrorObject(errors.InternalError, errors.CantYieldWithoutBlockFormat)
+					}
+
+					m := receiver.(*RWMutexObject)
+					m.lock()
+					defer m.unlock()
+
+					return t.builtinMethodYield(blockFrame).Target
+				}
+			},
+		},
+		{
+			// Releases the lock for writing, it raises an error if the mutex isn't locked for writing.
+			//
+			// @return [RWMutex]
+			Name: "unlock",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if !receiver.(*RWMutexObject).unlock() {
+						return t.vm.initErrorObject(errors.InternalError, "Can't unlock an unlocked %s", receiver.Class().Name)
+					}
+
+					return receiver
+				}
+			},
+		},
+	}
+}
+
+// Instance methods -----------------------------------------------------
+func builtinWaitGroupInstanceMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			// Adds the number of threads to wait for, it's 1 by default.
+			//
+			// @param n [Integer]
+			// @return [WaitGroup]
+			Name: "add",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					n := 1
+
+					switch len(args) {
+					case 0:
+					case 1:
+						i, ok := args[0].(*IntegerObject)
+
+						if !ok {
+							return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.IntegerClass, args[0].Class().Name)
+						}
+
+						n = i.value
+					default:
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect 0 or 1 argument. got: %d", len(args))
+					}
+
+					if !receiver.(*WaitGroupObject).add(n) {
+						return t.vm.initErrorObject(errors.ArgumentError, "WaitGroup counter can't be negative")
+					}
+
+					return receiver
+				}
+			},
+		},
+		{
+			// Marks one of the threads as finished.
+			//
+			// @return [WaitGroup]
+			Name: "done",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if !receiver.(*WaitGroupObject).add(-1) {
+						return t.vm.initErrorObject(errors.ArgumentError, "WaitGroup counter can't be negative")
+					}
+
+					return receiver
+				}
+			},
+		},
+		{
+			// Blocks until all threads are finished.
+			//
+			// @return [WaitGroup]
+			Name: "wait",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					receiver.(*WaitGroupObject).wg.Wait()
+
+					return receiver
+				}
+			},
+		},
+	}
+}
+
+// Instance methods -----------------------------------------------------
+func builtinOnceInstanceMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			// Yields the block if it's the first time `run` is called, and returns the result of the block.
+			// Otherwise it returns nil without yielding. Other threads calling `run` wait until the first block finishes.
+			//
+			// ```ruby
+			// once = Sync::Once.new
+			// once.run do
+			//   1
+			// end # => 1
+			// once.run do
+			//   2
+			// end # => nil
+			// ```
+			//
+			// @return [Object]
+			Name: "run",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if blockFrame == nil {
+						return t.vm.initErrorObject(errors.InternalError, errors.CantYieldWithoutBlockFormat)
+					}
+
+					var result Object
+					receiver.(*OnceObject).once.Do(func() {
+						result = t.builtinMethodYield(blockFrame).Target
+					})
+
+					if result == nil {
+						// The block isn't yielded, so we need to pop its frame
+						t.callFrameStack.pop()
+						return NULL
+					}
+
+					return result
+				}
+			},
+		},
+	}
+}
+
+// Instance methods -----------------------------------------------------
+func builtinAtomicIntegerInstanceMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			// Sets the value to the new one if the current value is the expected one, and returns true if it's set.
+			//
+			// ```ruby
+			// i = Sync::AtomicInteger.new(1)
+			// i.compare_and_set(1, 2) # => true
+			// i.compare_and_set(1, 3) # => false
+			// i.value                 # => 2
+			// ```
+			//
+			// @param expected [Integer]
+			// @param new [Integer]
+			// @return [Boolean]
+			Name: "compare_and_set",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					values, err := t.integerArgs(args, 2)
+
+					if err != nil {
+						return err
+					}
+
+					i := receiver.(*AtomicIntegerObject)
+
+					return toBooleanObject(atomic.CompareAndSwapInt64(&i.value, int64(values[0]), int64(values[1])))
+				}
+			},
+		},
+		{
+			// Subtracts the number from the value, which is 1 by default, and returns the new value.
+			//
+			// @param n [Integer]
+			// @return [Integer]
+			Name: "decrement",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return receiver.(*AtomicIntegerObject).add(t, args, -1)
+				}
+			},
+		},
+		{
+			// Adds the number to the value, which is 1 by default, and returns the new value.
+			//
+			// @param n [Integer]
+			// @return [Integer]
+			Name: "increment",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return receiver.(*AtomicIntegerObject).add(t, args, 1)
+				}
+			},
+		},
+		{
+			// Sets the value, and returns the old value.
+			//
+			// ```ruby
+			// i = Sync::AtomicInteger.new(1)
+			// i.set(5) # => 1
+			// i.value  # => 5
+			// ```
+			//
+			// @param value [Integer]
+			// @return [Integer]
+			Name: "set",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					values, err := t.integerArgs(args, 1)
+
+					if err != nil {
+						return err
+					}
+
+					old := atomic.SwapInt64(&receiver.(*AtomicIntegerObject).value, int64(values[0]))
+
+					return t.vm.initIntegerObject(int(old))
+				}
+			},
+		},
+		{
+			// Returns the current value.
+			//
+			// @return [Integer]
+			Name: "value",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initIntegerObject(int(atomic.LoadInt64(&receiver.(*AtomicIntegerObject).value)))
+				}
+			},
+		},
+	}
+}
+
+// Internal functions ===================================================
+
+// Functions for initialization -----------------------------------------
+
+func (vm *VM) initSyncModule() *RClass {
+	module := vm.initializeClass(classes.SyncModule, true)
+	withoutArgs := func(initialize func(base *baseObj) Object) func(t *thread, base *baseObj, args []Object) Object {
+		return func(t *thread, base *baseObj, args []Object) Object {
+			if len(args) != 0 {
+				return t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, 0, len(args))
+			}
+
+			return initialize(base)
+		}
+	}
+	syncClasses := []struct {
+		name            string
+		instanceMethods []*BuiltinMethodObject
+		initialize      func(t *thread, base *baseObj, args []Object) Object
+	}{
+		{mutexClassName, builtinMutexInstanceMethods(), withoutArgs(func(base *baseObj) Object {
+			return &MutexObject{baseObj: base, sem: make(chan struct{}, 1)}
+		})},
+		{rwMutexClassName, builtinRWMutexInstanceMethods(), withoutArgs(func(base *baseObj) Object {
+			return &RWMutexObject{baseObj: base}
+		})},
+		{waitGroupClassName, builtinWaitGroupInstanceMethods(), withoutArgs(func(base *baseObj) Object {
+			return &WaitGroupObject{baseObj: base}
+		})},
+		{onceClassName, builtinOnceInstanceMethods(), withoutArgs(func(base *baseObj) Object {
+			return &OnceObject{baseObj: base}
+		})},
+		{atomicIntegerClassName, builtinAtomicIntegerInstanceMethods(), initAtomicIntegerObject},
+	}
+
+	for _, sc := range syncClasses {
+		c := vm.initializeClass(sc.name, false)
+		c.setBuiltinMethods(sc.instanceMethods, false)
+		c.setBuiltinMethods(builtinSyncClassMethods(sc.initialize), true)
+		module.setClassConstant(c)
+	}
+
+	return module
+}
+
+// initAtomicIntegerObject creates an AtomicInteger with the optional initial value
+func initAtomicIntegerObject(t *thread, base *baseObj, args []Object) Object {
+	i := &AtomicIntegerObject{baseObj: base}
+
+	switch len(args) {
+	case 0:
+	case 1:
+		value, ok := args[0].(*IntegerObject)
+
+		if !ok {
+			return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.IntegerClass, args[0].Class().Name)
+		}
+
+		i.value = int64(value.value)
+	default:
+		return t.vm.initErrorObject(errors.ArgumentError, "Expect 0 or 1 argument. got: %d", len(args))
+	}
+
+	return i
+}
+
+// Polymorphic helper functions -----------------------------------------
+
+// Value returns the semaphore channel of the mutex
+func (m *MutexObject) Value() interface{} {
+	return m.sem
+}
+
+// toString returns the object's name as the string format
+func (m *MutexObject) toString() string {
+	return fmt.Sprintf("<Sync::Mutex: %p>", m)
+}
+
+// toJSON just delegates to toString
+func (m *MutexObject) toJSON() string {
+	return m.toString()
+}
+
+func (m *MutexObject) lock() {
+	m.sem <- struct{}{}
+	atomic.StoreInt32(&m.locked, 1)
+}
+
+// tryLock acquires the lock without blocking, it returns false if the mutex is already locked
+func (m *MutexObject) tryLock() bool {
+	select {
+	case m.sem <- struct{}{}:
+		atomic.StoreInt32(&m.locked, 1)
+		return true
+	default:
+		return false
+	}
+}
+
+// unlock releases the lock, it returns false if the mutex isn't locked
+func (m *MutexObject) unlock() bool {
+	if !atomic.CompareAndSwapInt32(&m.locked, 1, 0) {
+		return false
+	}
+
+	<-m.sem
+
+	return true
+}
+
+// Value returns the mutex
+func (m *RWMutexObject) Value() interface{} {
+	return &m.mutex
+}
+
+// toString returns the object's name as the string format
+func (m *RWMutexObject) toString() string {
+	return fmt.Sprintf("<Sync::RWMutex: %p>", m)
+}
+
+// toJSON just delegates to toString
+func (m *RWMutexObject) toJSON() string {
+	return m.toString()
+}
+
+func (m *RWMutexObject) lock() {
+	m.mutex.Lock()
+	atomic.StoreInt32(&m.locked, 1)
+}
+
+// unlock releases the lock for writing, it returns false if the mutex isn't locked for writing
+func (m *RWMutexObject) unlock() bool {
+	if !atomic.CompareAndSwapInt32(&m.locked, 1, 0) {
+		return false
+	}
+
+	m.mutex.Unlock()
+
+	return true
+}
+
+func (m *RWMutexObject) readLock() {
+	m.mutex.RLock()
+	atomic.AddInt32(&m.readers, 1)
+}
+
+// readUnlock releases the lock for reading, it returns false if the mutex isn't locked for reading
+func (m *RWMutexObject) readUnlock() bool {
+	for {
+		readers := atomic.LoadInt32(&m.readers)
+
+		if readers == 0 {
+			return false
+		}
+
+		if atomic.CompareAndSwapInt32(&m.readers, readers, readers-1) {
+			m.mutex.RUnlock()
+			return true
+		}
+	}
+}
+
+// Value returns the number of threads to wait for
+func (wg *WaitGroupObject) Value() interface{} {
+	return atomic.LoadInt64(&wg.count)
+}
+
+// toString returns the object's name as the string format
+func (wg *WaitGroupObject) toString() string {
+	return fmt.Sprintf("<Sync::WaitGroup: %p>", wg)
+}
+
+// toJSON just delegates to toString
+func (wg *WaitGroupObject) toJSON() string {
+	return wg.toString()
+}
+
+// add changes the counter, it returns false if the counter would be negative, which makes Go panic
+func (wg *WaitGroupObject) add(n int) bool {
+	for {
+		count := atomic.LoadInt64(&wg.count)
+
+		if count+int64(n) < 0 {
+			return false
+		}
+
+		if atomic.CompareAndSwapInt64(&wg.count, count, count+int64(n)) {
+			wg.wg.Add(n)
+			return true
+		}
+	}
+}
+
+// Value returns the once
+func (o *OnceObject) Value() interface{} {
+	return &o.once
+}
+
+// toString returns the object's name as the string format
+func (o *OnceObject) toString() string {
+	return fmt.Sprintf("<Sync::Once: %p>", o)
+}
+
+// toJSON just delegates to toString
+func (o *OnceObject) toJSON() string {
+	return o.toString()
+}
+
+// Value returns the current value
+func (i *AtomicIntegerObject) Value() interface{} {
+	return int(atomic.LoadInt64(&i.value))
+}
+
+// toString returns the current value as the string format
+func (i *AtomicIntegerObject) toString() string {
+	return fmt.Sprint(atomic.LoadInt64(&i.value))
+}
+
+// toJSON returns the current value
+func (i *AtomicIntegerObject) toJSON() string {
+	return i.toString()
+}
+
+// add adds the optional argument, or the default delta to the value, and returns the new value
+func (i *AtomicIntegerObject) add(t *thread, args []Object, delta int) Object {
+	switch len(args) {
+	case 0:
+	case 1:
+		n, ok := args[0].(*IntegerObject)
+
+		if !ok {
+			return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.IntegerClass, args[0].Class().Name)
+		}
+
+		delta *= n.value
+	default:
+		return t.vm.initErrorObject(errors.ArgumentError, "Expect 0 or 1 argument. got: %d", len(args))
+	}
+
+	return t.vm.initIntegerObject(int(atomic.AddInt64(&i.value, int64(delta))))
+}
+
+// integerArgs returns the values of the Integer arguments, or an error if the arguments aren't the given number of Integers
+func (t *thread) integerArgs(args []Object, count int) ([]int, *Error) {
+	if len(args) != count {
+		return nil, t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, count, len(args))
+	}
+
+	values := []int{}
+
+	for _, arg := range args {
+		i, ok := arg.(*IntegerObject)
+
+		if !ok {
+			return nil, t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.IntegerClass, arg.Class().Name)
+		}
+
+		values = append(values, i.value)
+	}
+
+	return values, nil
+}
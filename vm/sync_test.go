@@ -0,0 +1,274 @@
+package vm
+
+import (
+	"testing"
+)
+
+func TestSyncModule(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`Sync.name`, "Sync"},
+		{`Sync::Mutex.new.class.name`, "Mutex"},
+		{`Sync::AtomicInteger.superclass.name`, "Object"},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestMutexMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		m = Sync::Mutex.new
+		count = 0
+		threads = []
+
+		10.times do
+		  t = thread do
+		    100.times do
+		      m.synchronize do
+		        count += 1
+		      end
+		    end
+		  end
+		  threads.push(t)
+		end
+
+		threads.each do |t|
+		  t.join
+		end
+		count
+		`, 1000},
+		{`
+		m = Sync::Mutex.new
+		m.synchronize do
+		  10
+		end
+		`, 10},
+		{`
+		m = Sync::Mutex.new
+		a = m.locked?
+		m.lock
+		b = m.locked?
+		c = m.try_lock
+		m.unlock
+		d = m.try_lock
+		m.unlock
+		[a, b, c, d, m.locked?].to_s
+		`, "[false, true, false, true, false]"},
+		// The lock is released when the block raises an error
+		{`
+		m = Sync::Mutex.new
+		begin
+		  m.synchronize do
+		    raise("foo")
+		  end
+		rescue => e
+		end
+		m.locked?
+		`, false},
+		{`
+		m = Sync::RWMutex.new
+		count = 0
+		threads = []
+
+		5.times do
+		  t = thread do
+		    m.synchronize do
+		      count += 1
+		    end
+		  end
+		  threads.push(t)
+		  t = thread do
+		    m.read_synchronize do
+		      count
+		    end
+		  end
+		  threads.push(t)
+		end
+
+		threads.each do |t|
+		  t.join
+		end
+		count
+		`, 5},
+		{`
+		m = Sync::RWMutex.new
+		m.read_lock
+		m.read_lock
+		m.read_unlock
+		m.read_unlock
+		m.lock
+		m.unlock
+		m.read_synchronize do
+		  1
+		end
+		`, 1},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestWaitGroupAndOnceMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		wg = Sync::WaitGroup.new
+		count = Sync::AtomicInteger.new
+
+		10.times do
+		  wg.add
+		  thread do
+		    count.increment
+		    wg.done
+		  end
+		end
+
+		wg.wait
+		count.value
+		`, 10},
+		{`
+		wg = Sync::WaitGroup.new
+		wg.add(3)
+		c = Channel.new(3)
+
+		3.times do |i|
+		  thread(i) do |n|
+		    c.deliver(n)
+		    wg.done
+		  end
+		end
+
+		wg.wait
+		c.receive + c.receive + c.receive
+		`, 3},
+		{`
+		once = Sync::Once.new
+		results = []
+
+		3.times do |i|
+		  results.push(once.run do
+		    i + 10
+		  end)
+		end
+		results.to_s
+		`, "[10, nil, nil]"},
+		{`
+		once = Sync::Once.new
+		count = Sync::AtomicInteger.new
+		wg = Sync::WaitGroup.new
+		wg.add(5)
+
+		5.times do
+		  thread do
+		    once.run do
+		      count.increment
+		    end
+		    wg.done
+		  end
+		end
+
+		wg.wait
+		count.value
+		`, 1},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestAtomicIntegerMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`Sync::AtomicInteger.new.value`, 0},
+		{`Sync::AtomicInteger.new(10).value`, 10},
+		{`Sync::AtomicInteger.new(10).increment`, 11},
+		{`Sync::AtomicInteger.new(10).increment(5)`, 15},
+		{`Sync::AtomicInteger.new(10).decrement`, 9},
+		{`Sync::AtomicInteger.new(10).decrement(5)`, 5},
+		{`Sync::AtomicInteger.new(10).to_s`, "10"},
+		{`
+		i = Sync::AtomicInteger.new(1)
+		[i.set(5), i.value].to_s
+		`, "[1, 5]"},
+		{`
+		i = Sync::AtomicInteger.new(1)
+		[i.compare_and_set(1, 2), i.compare_and_set(1, 3), i.value].to_s
+		`, "[true, false, 2]"},
+		{`
+		i = Sync::AtomicInteger.new
+		threads = []
+		10.times do
+		  t = thread do
+		    100.times do
+		      i.increment
+		    end
+		  end
+		  threads.push(t)
+		end
+		threads.each do |t|
+		  t.join
+		end
+		i.value
+		`, 1000},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestSyncMethodsFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`Sync::Mutex.new(1)`, "ArgumentError: Expect 0 arguments. got: 1", 1},
+		{`Sync::Mutex.new.unlock`, "InternalError: Can't unlock an unlocked Mutex", 1},
+		{`Sync::Mutex.new.synchronize`, "InternalError: Can't yield without a block", 1},
+		{`Sync::RWMutex.new.unlock`, "InternalError: Can't unlock an unlocked RWMutex", 1},
+		{`Sync::RWMutex.new.read_unlock`, "InternalError: Can't unlock an unlocked RWMutex", 1},
+		{`Sync::WaitGroup.new.done`, "ArgumentError: WaitGroup counter can't be negative", 1},
+		{`Sync::WaitGroup.new.add(-1)`, "ArgumentError: WaitGroup counter can't be negative", 1},
+		{`Sync::WaitGroup.new.add("a")`, "TypeError: Expect argument to be Integer. got: String", 1},
+		{`Sync::Once.new.run`, "InternalError: Can't yield without a block", 1},
+		{`Sync::AtomicInteger.new("a")`, "TypeError: Expect argument to be Integer. got: String", 1},
+		{`Sync::AtomicInteger.new.increment("a")`, "TypeError: Expect argument to be Integer. got: String", 1},
+		{`Sync::AtomicInteger.new.set`, "ArgumentError: Expect 1 arguments. got: 0", 1},
+		{`Sync::AtomicInteger.new.compare_and_set(1, "a")`, "TypeError: Expect argument to be Integer. got: String", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
@@ -1,9 +1,10 @@
 package vm
 
 import (
+	"fmt"
+
 	"github.com/goby-lang/goby/compiler/bytecode"
 	"github.com/goby-lang/goby/vm/errors"
-	"strings"
 )
 
 type thread struct {
@@ -23,18 +24,6 @@ func (t *thread) isMainThread() bool {
 	return t == t.vm.mainThread
 }
 
-func (t *thread) getBlock(name string, filename filename) *instructionSet {
-	return t.vm.getBlock(name, filename)
-}
-
-func (t *thread) getMethodIS(name string, filename filename) (*instructionSet, bool) {
-	return t.vm.getMethodIS(name, filename)
-}
-
-func (t *thread) getClassIS(name string, filename filename) *instructionSet {
-	return t.vm.getClassIS(name, filename)
-}
-
 func (t *thread) startFromTopFrame() {
 	cf := t.callFrameStack.top()
 	t.evalCallFrame(cf)
@@ -43,7 +32,13 @@ func (t *thread) startFromTopFrame() {
 func (t *thread) evalCallFrame(cf *callFrame) {
 	for cf.pc < len(cf.instructionSet.instructions) {
 		i := cf.instructionSet.instructions[cf.pc]
-		t.execInstruction(cf, i)
+
+		if len(cf.handlers) > 0 {
+			t.execProtectedInstruction(cf, i)
+		} else {
+			t.execInstruction(cf, i)
+		}
+
 		if _, yes := t.hasError(); yes {
 			return
 		}
@@ -54,7 +49,7 @@ func (t *thread) hasError() (string, bool) {
 	var hasError bool
 	var msg string
 	if t.stack.top() != nil {
-		if err, ok := t.stack.top().Target.(*Error); ok {
+		if err, ok := t.stack.top().Target.(*Error); ok && err.raised {
 			hasError = true
 			msg = err.Message
 		}
@@ -62,6 +57,127 @@ func (t *thread) hasError() (string, bool) {
 	return msg, hasError
 }
 
+// handleError is called when a raised error is put on the stack, it captures the error's backtrace if it's raised at the first time.
+// If any call frame has an error handler, we unwind the call frames to it by panicking with the error.
+// Otherwise current frame stops and the program exits in normal mode.
+func (t *thread) handleError(err *Error) {
+	if err.backtrace == nil {
+		// Remove the block frame that was prepared for the method call but never got executed
+		if cf := t.callFrameStack.top(); cf != nil && cf.isBlock && cf.pc == 0 {
+			t.callFrameStack.pop()
+		}
+
+		t.locateError(err)
+	}
+
+	for _, cf := range t.callFrameStack.callFrames[:t.cfp] {
+		if len(cf.handlers) > 0 {
+			panic(err)
+		}
+	}
+
+	// Other threads are stopped as a whole, even if the error is raised in a block called by a built-in method
+	if !t.isMainThread() {
+		panic(&threadStop{err: err})
+	}
+
+	cf := t.callFrameStack.top()
+	cf.pc = len(cf.instructionSet.instructions)
+
+	if t.vm.mode == NormalMode {
+		fmt.Println(t.uncaughtErrorMessage(err))
+
+		for _, l := range err.backtrace {
+			fmt.Printf("\tfrom %s\n", l)
+		}
+
+		t.vm.exit(1)
+	}
+}
+
+// threadStop stops a thread other than the main thread with the error that isn't rescued
+type threadStop struct {
+	err *Error
+}
+
+// runBlock calls the block as the first frame of a thread other than the main thread.
+// If the thread is stopped by an error, the error is returned as the result.
+func (t *thread) runBlock(blockFrame *callFrame, args ...Object) (result *Pointer) {
+	defer func() {
+		if r := recover(); r != nil {
+			stop, ok := r.(*threadStop)
+
+			if !ok {
+				panic(r)
+			}
+
+			result = &Pointer{Target: stop.err}
+		}
+	}()
+
+	return t.builtinMethodYield(blockFrame, args...)
+}
+
+// execProtectedInstruction executes the instruction and rescues the error raised during its execution
+func (t *thread) execProtectedInstruction(cf *callFrame, i *instruction) {
+	defer func() {
+		if r := recover(); r != nil {
+			err, ok := r.(*Error)
+
+			if !ok {
+				panic(r)
+			}
+
+			t.rescueError(cf, err)
+		}
+	}()
+
+	t.execInstruction(cf, i)
+}
+
+// rescueError removes the frames above the given one, restores the stack and jumps to the innermost handler
+func (t *thread) rescueError(cf *callFrame, err *Error) {
+	h := cf.handlers[len(cf.handlers)-1]
+	cf.handlers = cf.handlers[:len(cf.handlers)-1]
+
+	for t.callFrameStack.top() != cf {
+		t.callFrameStack.pop()
+	}
+
+	for t.sp > h.sp {
+		t.stack.pop()
+	}
+
+	// The clauses end where the jump right before them goes, which skips them when nothing is raised
+	end := len(cf.instructionSet.instructions)
+
+	if jump := cf.instructionSet.instructions[h.pc-1]; jump.action.name == bytecode.Jump {
+		end = jump.Params[0].(int)
+	}
+
+	cf.addRescuedError(&rescuedError{err: err, pc: h.pc, end: end})
+
+	err.raised = false
+	t.stack.push(&Pointer{Target: err})
+	cf.pc = h.pc
+}
+
+// rescuedError returns the error being rescued by the current method or block.
+// A block is in the rescue clauses where it's defined.
+func (t *thread) rescuedError() *Error {
+	for cf := t.callFrameStack.top(); cf != nil; cf = cf.ep {
+		if err := cf.rescuedErrorAt(cf.pc); err != nil {
+			return err
+		}
+
+		if cf.instructionSet.isType != bytecode.Block {
+			break
+		}
+	}
+
+	return nil
+}
+
 func (t *thread) execInstruction(cf *callFrame, i *instruction) {
 	cf.pc++
 
@@ -69,13 +185,32 @@ func (t *thread) execInstruction(cf *callFrame, i *instruction) {
 }
 
 func (t *thread) builtinMethodYield(blockFrame *callFrame, args ...Object) *Pointer {
+	// A block with more than one parameter takes the elements of a single array argument, like hash pairs
+	if params := blockFrame.instructionSet.paramTypes; len(args) == 1 && params != nil && len(params.Types()) > 1 {
+		if arr, ok := args[0].(*ArrayObject); ok {
+			args = arr.Elements
+		}
+	}
+
+	return t.callBlock(blockFrame, nil, args)
+}
+
+// callBlock calls the block with the arguments as they are, the given block is passed to the block's `&` parameter if it has one
+func (t *thread) callBlock(blockFrame, block *callFrame, args []Object) *Pointer {
 	c := newCallFrame(blockFrame.instructionSet)
 	c.blockFrame = blockFrame
 	c.ep = blockFrame.ep
 	c.self = blockFrame.self
 
+	if params := blockFrame.instructionSet.paramTypes; hasSpecialBlockParams(params) {
+		args = t.blockParamArgs(params, args, block)
+	}
+
 	for i := 0; i < len(args); i++ {
-		c.insertLCL(i, 0, args[i])
+		// Optional parameters without arguments are set by the block itself
+		if args[i] != nil {
+			c.insertLCL(i, 0, args[i])
+		}
 	}
 
 	t.callFrameStack.push(c)
@@ -84,31 +219,81 @@ func (t *thread) builtinMethodYield(blockFrame *callFrame, args ...Object) *Poin
 	return t.stack.top()
 }
 
-func (t *thread) retrieveBlock(cf *callFrame, args []interface{}) (blockFrame *callFrame) {
-	var blockName string
-	var hasBlock bool
-
-	blockFlag := args[2].(string)
+// hasSpecialBlockParams returns true if the block has optional, splat or block parameters, which only lambda literals can have
+func hasSpecialBlockParams(params *bytecode.ArgSet) bool {
+	if params == nil {
+		return false
+	}
 
-	if len(blockFlag) != 0 {
-		hasBlock = true
-		blockName = strings.Split(blockFlag, ":")[1]
+	for _, paramType := range params.Types() {
+		if paramType != bytecode.NormalArg {
+			return true
+		}
 	}
 
-	if hasBlock {
-		block := t.getBlock(blockName, cf.instructionSet.filename)
+	return false
+}
+
+// blockParamArgs arranges the arguments by the block's parameters. Optional parameters without arguments are left nil,
+// a splat parameter takes the rest arguments as an Array, and a block parameter takes the given block as a Proc.
+func (t *thread) blockParamArgs(params *bytecode.ArgSet, args []Object, block *callFrame) []Object {
+	locals := make([]Object, len(params.Types()))
+	argIndex := 0
 
-		c := newCallFrame(block)
-		c.isBlock = true
-		c.ep = cf
-		c.self = cf.self
+	for i, paramType := range params.Types() {
+		switch paramType {
+		case bytecode.SplatArg:
+			rest := []Object{}
 
-		t.callFrameStack.push(c)
+			if argIndex < len(args) {
+				rest = append(rest, args[argIndex:]...)
+				argIndex = len(args)
+			}
 
-		blockFrame = c
+			locals[i] = t.vm.initArrayObject(rest)
+		case bytecode.BlockArg:
+			if block == nil {
+				locals[i] = NULL
+			} else {
+				locals[i] = t.vm.initProcObject(block, false)
+			}
+		default:
+			if argIndex < len(args) {
+				locals[i] = args[argIndex]
+				argIndex++
+			}
+		}
 	}
 
-	return
+	return locals
+}
+
+// retrieveBlock pushes a block frame for the literal block given to the send instruction, or returns nil if there's no block
+func (t *thread) retrieveBlock(cf *callFrame, block *instructionSet) (blockFrame *callFrame) {
+	if block == nil {
+		return
+	}
+
+	c := newCallFrame(block)
+	c.isBlock = true
+	c.ep = cf
+	c.self = cf.self
+
+	t.callFrameStack.push(c)
+
+	return c
+}
+
+// retrieveProcBlock pushes a block frame for the given Proc, just like retrieveBlock does for literal blocks
+func (t *thread) retrieveProcBlock(p *ProcObject) *callFrame {
+	c := newCallFrame(p.blockFrame.instructionSet)
+	c.isBlock = true
+	c.ep = p.blockFrame.ep
+	c.self = p.blockFrame.self
+
+	t.callFrameStack.push(c)
+
+	return c
 }
 
 func (t *thread) sendMethod(methodName string, argCount int, blockFrame *callFrame) {
@@ -156,6 +341,12 @@ func (t *thread) sendMethod(methodName string, argCount int, blockFrame *callFra
 
 	method = receiver.findMethod(methodName)
 
+	argSet := &bytecode.ArgSet{}
+
+	if method == nil {
+		method, argCount, argSet = t.methodMissing(receiver, methodName, argPr, argCount)
+	}
+
 	if method == nil {
 		err := t.vm.initErrorObject(errors.UndefinedMethodError, "Undefined Method '%+v' for %+v", methodName, receiver.toString())
 		t.stack.set(receiverPr, &Pointer{Target: err})
@@ -165,14 +356,65 @@ func (t *thread) sendMethod(methodName string, argCount int, blockFrame *callFra
 
 	switch m := method.(type) {
 	case *MethodObject:
-		t.evalMethodObject(receiver, m, receiverPr, argCount, &bytecode.ArgSet{}, blockFrame)
+		t.evalMethodObject(receiver, m, receiverPr, argCount, argSet, blockFrame)
 	case *BuiltinMethodObject:
-		t.evalBuiltinMethod(receiver, m, receiverPr, argCount, &bytecode.ArgSet{}, blockFrame)
+		t.evalBuiltinMethod(receiver, m, receiverPr, argCount, argSet, blockFrame)
 	case *Error:
 		t.returnError(errors.InternalError, m.toString())
 	}
 }
 
+// checkMethodVisibility returns an error if the method can't be called from the call frame.
+// Private methods can only be called with self as the receiver, and protected methods can also be called
+// from the objects that have the same method, which means their classes inherit the class defining the method.
+func (t *thread) checkMethodVisibility(cf *callFrame, receiver, method Object, methodName string, selfCall bool) *Error {
+	visibility := methodVisibility(method)
+
+	switch {
+	case visibility == publicMethod || selfCall:
+		return nil
+	case visibility == protectedMethod && cf.self != nil && cf.self.findMethod(methodName) == method:
+		return nil
+	}
+
+	return t.visibilityError(receiver, method, methodName)
+}
+
+// visibilityError returns the error of calling a private or protected method where it can't be called
+func (t *thread) visibilityError(receiver, method Object, methodName string) *Error {
+	return t.vm.initErrorObject(errors.UndefinedMethodError, "%s method '%s' called for %s", visibilityNames[methodVisibility(method)], methodName, receiver.toString())
+}
+
+// methodMissing looks up the receiver's `method_missing` for the undefined method.
+// If it's defined, the method name is inserted before the arguments on the stack as a Symbol,
+// and it returns `method_missing` with the new argument count and argument set. Otherwise it returns nil.
+func (t *thread) methodMissing(receiver Object, methodName string, argPr, argCount int) (Object, int, *bytecode.ArgSet) {
+	method := receiver.findMethod("method_missing")
+
+	if method == nil {
+		return nil, argCount, nil
+	}
+
+	t.stack.push(&Pointer{Target: NULL})
+
+	for i := argCount; i > 0; i-- {
+		t.stack.Data[argPr+i] = t.stack.Data[argPr+i-1]
+	}
+
+	t.stack.Data[argPr] = &Pointer{Target: t.vm.initSymbolObject(methodName)}
+	argCount++
+
+	// All arguments are passed to `method_missing` as normal arguments
+	names := make([]string, argCount)
+	types := make([]int, argCount)
+
+	for i := range types {
+		types[i] = bytecode.NormalArg
+	}
+
+	return method, argCount, bytecode.NewArgSet(names, types)
+}
+
 func (t *thread) evalBuiltinMethod(receiver Object, method *BuiltinMethodObject, receiverPr, argCount int, argSet *bytecode.ArgSet, blockFrame *callFrame) {
 	methodBody := method.Fn(receiver)
 	args := []Object{}
@@ -319,6 +561,33 @@ func (t *thread) evalMethodObject(receiver Object, method *MethodObject, receive
 	t.sp = argPr
 }
 
+// callMethod calls the Goby method on the receiver with the given block frame and arguments, and returns the result.
+// The block frame can be nil if there is no block.
+func (t *thread) callMethod(receiver Object, method *MethodObject, blockFrame *callFrame, args ...Object) Object {
+	receiverPr := t.sp
+	t.stack.push(&Pointer{Target: receiver})
+
+	for _, arg := range args {
+		t.stack.push(&Pointer{Target: arg})
+	}
+
+	t.evalMethodObject(receiver, method, receiverPr, len(args), &bytecode.ArgSet{}, blockFrame)
+
+	return t.stack.pop().Target
+}
+
+// callMethodByName looks up the method on the receiver and calls it, the method can be a Goby method or a built-in method
+func (t *thread) callMethodByName(receiver Object, methodName string, args ...Object) Object {
+	switch m := receiver.findMethod(methodName).(type) {
+	case *MethodObject:
+		return t.callMethod(receiver, m, nil, args...)
+	case *BuiltinMethodObject:
+		return m.Fn(receiver)(t, args, nil)
+	}
+
+	return t.vm.initErrorObject(errors.UndefinedMethodError, "Undefined Method '%+v' for %+v", methodName, receiver.toString())
+}
+
 func (t *thread) returnError(errorType, format string, args ...interface{}) {
 	err := t.vm.initErrorObject(errorType, format, args...)
 	t.stack.push(&Pointer{Target: err})
@@ -0,0 +1,211 @@
+package vm
+
+import (
+	"fmt"
+
+	"github.com/goby-lang/goby/vm/classes"
+)
+
+// ThreadObject represents a Goby thread, which runs a block in a goroutine. It's returned by `Object#thread`.
+// The thread's result can be retrieved by joining it, and the error raised in the thread is raised again in the joining thread.
+//
+// ```ruby
+// t = thread do
+//   1 + 1
+// end
+//
+// t.value # => 2
+// ```
+//
+type ThreadObject struct {
+	*baseObj
+	// done is closed when the thread finishes
+	done  chan struct{}
+	value Object
+	err   *Error
+}
+
+// Class methods --------------------------------------------------------
+func builtinThreadClassMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			Name: "new",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.unsupportedMethodError("#new", receiver)
+				}
+			},
+		},
+	}
+}
+
+// Instance methods -----------------------------------------------------
+func builtinThreadInstanceMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			// Returns true if the thread is still running.
+			//
+			// ```ruby
+			// c = Channel.new
+			// t = thread do
+			//   c.receive
+			// end
+			//
+			// t.alive? # => true
+			// c.deliver(1)
+			// t.join
+			// t.alive? # => false
+			// ```
+			//
+			// @return [Boolean]
+			Name: "alive?",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					select {
+					case <-receiver.(*ThreadObject).done:
+						return FALSE
+					default:
+						return TRUE
+					}
+				}
+			},
+		},
+		{
+			// Waits for the thread to finish and returns the thread.
+			// If the thread is stopped by an error, the error is raised again.
+			//
+			// ```ruby
+			// t = thread do
+			//   raise(ArgumentError, "foo")
+			// end
+			//
+			// begin
+			//   t.join
+			// rescue ArgumentError => e
+			//   e.message # => "foo"
+			// end
+			// ```
+			//
+			// @return [Thread]
+			Name: "join",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					to := receiver.(*ThreadObject)
+
+					if err := to.wait(); err != nil {
+						return err
+					}
+
+					return to
+				}
+			},
+		},
+		{
+			// Waits for the thread to finish and returns the last evaluated value of its block.
+			// If the thread is stopped by an error, the error is raised again.
+			//
+			// ```ruby
+			// t = thread do
+			//   [1, 2, 3].map do |i|
+			//     i * 2
+			//   end
+			// end
+			//
+			// t.value # => [2, 4, 6]
+			// ```
+			//
+			// @return [Object]
+			Name: "value",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					to := receiver.(*ThreadObject)
+
+					if err := to.wait(); err != nil {
+						return err
+					}
+
+					return to.value
+				}
+			},
+		},
+	}
+}
+
+// Internal functions ===================================================
+
+// Functions for initialization -----------------------------------------
+
+// initThreadObject runs the block in a new thread with given arguments, and returns the thread object
+func (vm *VM) initThreadObject(blockFrame *callFrame, args []Object) *ThreadObject {
+	to := &ThreadObject{
+		baseObj: &baseObj{class: vm.topLevelClass(classes.ThreadClass)},
+		done:    make(chan struct{}),
+		value:   NULL,
+	}
+	newT := vm.newThread()
+
+	go func() {
+		defer close(to.done)
+
+		result := newT.runBlock(blockFrame, args...)
+
+		if result == nil {
+			return
+		}
+
+		// Errors raised on non-main threads only stop the thread, so they're kept for the joining thread
+		if err, ok := result.Target.(*Error); ok && err.raised {
+			to.err = err
+			return
+		}
+
+		to.value = result.Target
+	}()
+
+	return to
+}
+
+func (vm *VM) initThreadClass() *RClass {
+	tc := vm.initializeClass(classes.ThreadClass, false)
+	tc.setBuiltinMethods(builtinThreadInstanceMethods(), false)
+	tc.setBuiltinMethods(builtinThreadClassMethods(), true)
+	return tc
+}
+
+// Polymorphic helper functions -----------------------------------------
+
+// Value returns the thread's result, it's nil before the thread finishes
+func (to *ThreadObject) Value() interface{} {
+	select {
+	case <-to.done:
+		return to.value
+	default:
+		return nil
+	}
+}
+
+// toString returns the object's name as the string format
+func (to *ThreadObject) toString() string {
+	return fmt.Sprintf("<Thread: %p>", to)
+}
+
+// toJSON just delegates to toString
+func (to *ThreadObject) toJSON() string {
+	return to.toString()
+}
+
+// wait blocks until the thread finishes, and returns the error which stopped the thread
+func (to *ThreadObject) wait() *Error {
+	<-to.done
+
+	if to.err != nil {
+		// Every join raises its own copy of the error, because the threads joining it
+		// may rescue it, which marks it as not raised, at the same time
+		err := *to.err
+		err.raised = true
+		return &err
+	}
+
+	return nil
+}
+
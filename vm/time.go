@@ -0,0 +1,816 @@
+package vm
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goby-lang/goby/vm/classes"
+	"github.com/goby-lang/goby/vm/errors"
+)
+
+// TimeObject represents an instant in time with nanosecond precision, which is backed by Go's `time.Time`.
+// Timestamps returned from databases are also converted to Time objects.
+//
+// ```ruby
+// t = Time.parse("2017-09-05 14:30:00 +0800")
+// t.year                      # => 2017
+// t.strftime("%Y/%m/%d %H:%M") # => "2017/09/05 14:30"
+// (t + Duration.hours(1)).hour # => 15
+// t.in_zone("UTC").to_s       # => "2017-09-05 06:30:00 +0000"
+// ```
+//
+type TimeObject struct {
+	*baseObj
+	value time.Time
+}
+
+// timeLayout is the format of `Time#to_s`
+const timeLayout = "2006-01-02 15:04:05 -0700"
+
+// timeParseLayouts are tried in order when `Time.parse` is called without a layout
+var timeParseLayouts = []string{
+	time.RFC3339Nano,
+	timeLayout,
+	"2006-01-02 15:04:05.999999999 -0700",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+	time.RFC1123Z,
+	time.RFC1123,
+}
+
+// timeLayoutConstants are set as the constants of the Time class
+var timeLayoutConstants = map[string]string{
+	"ANSIC":       time.ANSIC,
+	"RFC822":      time.RFC822,
+	"RFC1123":     time.RFC1123,
+	"RFC1123Z":    time.RFC1123Z,
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"Kitchen":     time.Kitchen,
+}
+
+// strftimeLayouts maps strftime directives to Go's layout elements
+var strftimeLayouts = map[byte]string{
+	'Y': "2006",
+	'y': "06",
+	'm': "01",
+	'd': "02",
+	'e': "_2",
+	'H': "15",
+	'I': "03",
+	'M': "04",
+	'S': "05",
+	'L': "000",
+	'N': "000000000",
+	'p': "PM",
+	'b': "Jan",
+	'B': "January",
+	'a': "Mon",
+	'A': "Monday",
+	'j': "002",
+	'z': "-0700",
+	'Z': "MST",
+	'F': "2006-01-02",
+	'T': "15:04:05",
+	'%': "%",
+}
+
+// Class methods --------------------------------------------------------
+func builtinTimeClassMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			// Returns the local time of the given seconds since the Unix epoch. A Float can be given for fractional seconds.
+			//
+			// ```ruby
+			// Time.at(0).utc.to_s # => "1970-01-01 00:00:00 +0000"
+			// ```
+			//
+			// @param seconds [Numeric]
+			// @return [Time]
+			Name: "at",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					d, err := t.numericArg(args)
+
+					if err != nil {
+						return err
+					}
+
+					sec := int64(d)
+					return t.vm.initTimeObject(time.Unix(sec, int64((d-float64(sec))*1e9)))
+				}
+			},
+		},
+		{
+			// Returns the local time of the given date and clock. Omitted fields are the first month, day, or zero.
+			// The time zone name can be given as the last argument.
+			//
+			// ```ruby
+			// Time.new(2017, 9, 5).to_s # => "2017-09-05 00:00:00 +0800"
+			// Time.new(2017, 9, 5, 14, 30, 0, "UTC").to_s # => "2017-09-05 14:30:00 +0000"
+			// ```
+			//
+			// @param year [Integer], month [Integer], day [Integer], hour [Integer], minute [Integer], second [Integer], zone [String]
+			// @return [Time]
+			Name: "new",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					loc := time.Local
+
+					if len(args) > 0 {
+						if s, ok := args[len(args)-1].(*StringObject); ok {
+							l, err := t.loadLocation(s.value)
+
+							if err != nil {
+								return err
+							}
+
+							loc = l
+							args = args[:len(args)-1]
+						}
+					}
+
+					if len(args) < 1 || len(args) > 6 {
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect 1 to 6 arguments. got: %d", len(args))
+					}
+
+					fields := []int{0, 1, 1, 0, 0, 0}
+
+					for i, arg := range args {
+						n, ok := arg.(*IntegerObject)
+
+						if !ok {
+							return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.IntegerClass, arg.Class().Name)
+						}
+
+						fields[i] = n.value
+					}
+
+					return t.vm.initTimeObject(time.Date(fields[0], time.Month(fields[1]), fields[2], fields[3], fields[4], fields[5], 0, loc))
+				}
+			},
+		},
+		{
+			// Returns the current local time.
+			//
+			// ```ruby
+			// Time.now.year # => 2017
+			// ```
+			//
+			// @return [Time]
+			Name: "now",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initTimeObject(time.Now())
+				}
+			},
+		},
+		{
+			// Parses the string into a Time. The layout can be strftime-style like "%Y/%m/%d %H:%M",
+			// or Go's layout like `Time::RFC1123`.
+			// Without the layout, RFC 3339, "2006-01-02 15:04:05 -0700", "2006-01-02 15:04:05", "2006-01-02" and RFC 1123 formats are tried.
+			// A time without a zone is parsed as the local time.
+			//
+			// ```ruby
+			// Time.parse("2017-09-05T14:30:00Z").hour                     # => 14
+			// Time.parse("05/09/2017 14:30", "%d/%m/%Y %H:%M").month      # => 9
+			// Time.parse("Tue, 05 Sep 2017 14:30:00 UTC", Time::RFC1123).day # => 5
+			// ```
+			//
+			// @param time [String], layout [String]
+			// @return [Time]
+			Name: "parse",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) < 1 || len(args) > 2 {
+						return t.vm.initErrorObject(errors.ArgumentError, "Expect 1 or 2 arguments. got: %d", len(args))
+					}
+
+					s, ok := args[0].(*StringObject)
+
+					if !ok {
+						return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.StringClass, args[0].Class().Name)
+					}
+
+					layouts := timeParseLayouts
+
+					if len(args) == 2 {
+						l, ok := args[1].(*StringObject)
+
+						if !ok {
+							return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.StringClass, args[1].Class().Name)
+						}
+
+						layout, err := strftimeToLayout(l.value)
+
+						if err != nil {
+							return t.vm.initErrorObject(errors.ArgumentError, "%s", err.Error())
+						}
+
+						layouts = []string{layout}
+					}
+
+					for _, layout := range layouts {
+						if v, err := time.ParseInLocation(layout, s.value, time.Local); err == nil {
+							return t.vm.initTimeObject(v)
+						}
+					}
+
+					return t.vm.initErrorObject(errors.ArgumentError, "Can't parse time: %s", s.value)
+				}
+			},
+		},
+	}
+}
+
+// Instance methods -----------------------------------------------------
+func builtinTimeInstanceMethods() []*BuiltinMethodObject {
+	return []*BuiltinMethodObject{
+		{
+			// Returns the Time after the given Duration. Numbers are treated as seconds.
+			//
+			// ```ruby
+			// t = Time.parse("2017-09-05 14:30:00 +0000")
+			// (t + Duration.minutes(45)).to_s # => "2017-09-05 15:15:00 +0000"
+			// (t + 30).to_s                   # => "2017-09-05 14:30:30 +0000"
+			// ```
+			//
+			// @param duration [Duration, Numeric]
+			// @return [Time]
+			Name: "+",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					d, ok := toDuration(args[0], time.Second)
+
+					if !ok {
+						return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, "Duration or Numeric", args[0].Class().Name)
+					}
+
+					return t.vm.initTimeObject(receiver.(*TimeObject).value.Add(d))
+				}
+			},
+		},
+		{
+			// Returns the Duration between two Times, or the Time before the given Duration. Numbers are treated as seconds.
+			//
+			// ```ruby
+			// t = Time.parse("2017-09-05 14:30:00 +0000")
+			// (t - Time.parse("2017-09-05 12:00:00 +0000")).to_s # => "2h30m0s"
+			// (t - Duration.hours(1)).to_s                       # => "2017-09-05 13:30:00 +0000"
+			// ```
+			//
+			// @param time [Time, Duration, Numeric]
+			// @return [Duration, Time]
+			Name: "-",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					tv := receiver.(*TimeObject).value
+
+					if other, ok := args[0].(*TimeObject); ok {
+						return t.vm.initDurationObject(tv.Sub(other.value))
+					}
+
+					d, ok := toDuration(args[0], time.Second)
+
+					if !ok {
+						return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, "Time, Duration or Numeric", args[0].Class().Name)
+					}
+
+					return t.vm.initTimeObject(tv.Add(-d))
+				}
+			},
+		},
+		{
+			// Compares two Times, returns -1, 0 or 1 if the receiver is before, same as or after the argument.
+			// Returns nil if the argument isn't a Time.
+			//
+			// ```ruby
+			// Time.at(0) <=> Time.at(1) # => -1
+			// ```
+			//
+			// @param time [Time]
+			// @return [Integer]
+			Name: "<=>",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					other, ok := args[0].(*TimeObject)
+
+					if !ok {
+						return NULL
+					}
+
+					tv := receiver.(*TimeObject).value
+
+					switch {
+					case tv.Before(other.value):
+						return t.vm.initIntegerObject(-1)
+					case tv.After(other.value):
+						return t.vm.initIntegerObject(1)
+					}
+
+					return t.vm.initIntegerObject(0)
+				}
+			},
+		},
+		{
+			// Returns true if the argument is a Time of the same instant, even in the different zones.
+			//
+			// ```ruby
+			// t = Time.now
+			// t == t.utc # => true
+			// ```
+			//
+			// @param object [Object]
+			// @return [Boolean]
+			Name: "==",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					other, ok := args[0].(*TimeObject)
+
+					return toBooleanObject(ok && receiver.(*TimeObject).value.Equal(other.value))
+				}
+			},
+		},
+		{
+			// Returns the day of the month.
+			//
+			// ```ruby
+			// Time.new(2017, 9, 5).day # => 5
+			// ```
+			//
+			// @return [Integer]
+			Name: "day",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initIntegerObject(receiver.(*TimeObject).value.Day())
+				}
+			},
+		},
+		{
+			// Returns the hour of the day in 0..23.
+			//
+			// ```ruby
+			// Time.new(2017, 9, 5, 14, 30).hour # => 14
+			// ```
+			//
+			// @return [Integer]
+			Name: "hour",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initIntegerObject(receiver.(*TimeObject).value.Hour())
+				}
+			},
+		},
+		{
+			// Returns the same instant in the given IANA time zone, like "Asia/Taipei" or "UTC".
+			// The zone is loaded from the system's time zone database.
+			//
+			// ```ruby
+			// t = Time.parse("2017-09-05 14:30:00 +0000")
+			// t.in_zone("Asia/Tokyo").to_s # => "2017-09-05 23:30:00 +0900"
+			// ```
+			//
+			// @param zone [String]
+			// @return [Time]
+			Name: "in_zone",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					s, ok := args[0].(*StringObject)
+
+					if !ok {
+						return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.StringClass, args[0].Class().Name)
+					}
+
+					loc, err := t.loadLocation(s.value)
+
+					if err != nil {
+						return err
+					}
+
+					return t.vm.initTimeObject(receiver.(*TimeObject).value.In(loc))
+				}
+			},
+		},
+		{
+			// Returns the same instant in the local time zone.
+			//
+			// ```ruby
+			// Time.now.utc.localtime
+			// ```
+			//
+			// @return [Time]
+			Name: "localtime",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initTimeObject(receiver.(*TimeObject).value.Local())
+				}
+			},
+		},
+		{
+			// Returns the minute of the hour in 0..59.
+			//
+			// ```ruby
+			// Time.new(2017, 9, 5, 14, 30).minute # => 30
+			// ```
+			//
+			// @return [Integer]
+			Name: "minute",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initIntegerObject(receiver.(*TimeObject).value.Minute())
+				}
+			},
+		},
+		{
+			// Returns the month of the year in 1..12.
+			//
+			// ```ruby
+			// Time.new(2017, 9, 5).month # => 9
+			// ```
+			//
+			// @return [Integer]
+			Name: "month",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initIntegerObject(int(receiver.(*TimeObject).value.Month()))
+				}
+			},
+		},
+		{
+			// Returns the nanoseconds within the second.
+			//
+			// ```ruby
+			// Time.at(1.5).nanosecond # => 500000000
+			// ```
+			//
+			// @return [Integer]
+			Name: "nanosecond",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initIntegerObject(receiver.(*TimeObject).value.Nanosecond())
+				}
+			},
+		},
+		{
+			// Returns the second of the minute in 0..59.
+			//
+			// ```ruby
+			// Time.new(2017, 9, 5, 14, 30, 15).second # => 15
+			// ```
+			//
+			// @return [Integer]
+			Name: "second",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initIntegerObject(receiver.(*TimeObject).value.Second())
+				}
+			},
+		},
+		{
+			// Formats the Time with strftime-style directives. Go's layout like `Time::RFC3339` can also be given.
+			//
+			// Supported directives:
+			//   %Y year, %y 2-digit year, %m month, %d day, %e space-padded day, %j day of the year,
+			//   %H hour, %I 12-hour clock hour, %M minute, %S second, %L milliseconds, %N nanoseconds, %p AM/PM,
+			//   %b abbreviated month name, %B month name, %a abbreviated weekday name, %A weekday name,
+			//   %u weekday (Monday is 1), %w weekday (Sunday is 0), %s seconds since the Unix epoch,
+			//   %z zone offset, %Z zone abbreviation, %F "%Y-%m-%d", %T "%H:%M:%S" and %% a literal "%"
+			//
+			// ```ruby
+			// t = Time.parse("2017-09-05 14:30:00 +0000")
+			// t.strftime("%a, %d %b %Y %I:%M %p") # => "Tue, 05 Sep 2017 02:30 PM"
+			// t.strftime(Time::RFC3339)           # => "2017-09-05T14:30:00Z"
+			// ```
+			//
+			// @param format [String]
+			// @return [String]
+			Name: "strftime",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					if len(args) != 1 {
+						return t.vm.initErrorObject(errors.ArgumentError, errors.WrongNumberOfArgumentFormat, 1, len(args))
+					}
+
+					s, ok := args[0].(*StringObject)
+
+					if !ok {
+						return t.vm.initErrorObject(errors.TypeError, errors.WrongArgumentTypeFormat, classes.StringClass, args[0].Class().Name)
+					}
+
+					return t.vm.initStringObject(strftime(receiver.(*TimeObject).value, s.value))
+				}
+			},
+		},
+		{
+			// Returns the seconds since the Unix epoch as a Float.
+			//
+			// ```ruby
+			// Time.at(1.5).to_f # => 1.5
+			// ```
+			//
+			// @return [Float]
+			Name: "to_f",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initFloatObject(float64(receiver.(*TimeObject).value.UnixNano()) / 1e9)
+				}
+			},
+		},
+		{
+			// Returns the seconds since the Unix epoch.
+			//
+			// ```ruby
+			// Time.parse("2017-09-05T00:00:00Z").to_i # => 1504569600
+			// ```
+			//
+			// @return [Integer]
+			Name: "to_i",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initIntegerObject(int(receiver.(*TimeObject).value.Unix()))
+				}
+			},
+		},
+		{
+			// Returns the Time as a JSON string in RFC 3339 format.
+			//
+			// ```ruby
+			// Time.parse("2017-09-05T14:30:00Z").to_json # => "\"2017-09-05T14:30:00Z\""
+			// { at: Time.parse("2017-09-05T14:30:00Z") }.to_json # => "{\"at\":\"2017-09-05T14:30:00Z\"}"
+			// ```
+			//
+			// @return [String]
+			Name: "to_json",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initStringObject(receiver.toJSON())
+				}
+			},
+		},
+		{
+			// Returns the Time in the format like "2017-09-05 14:30:00 +0800".
+			//
+			// ```ruby
+			// Time.new(2017, 9, 5, 14, 30, 0, "Asia/Taipei").to_s # => "2017-09-05 14:30:00 +0800"
+			// ```
+			//
+			// @return [String]
+			Name: "to_s",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initStringObject(receiver.toString())
+				}
+			},
+		},
+		{
+			// Returns the same instant in UTC.
+			//
+			// ```ruby
+			// Time.parse("2017-09-05 14:30:00 +0800").utc.to_s # => "2017-09-05 06:30:00 +0000"
+			// ```
+			//
+			// @return [Time]
+			Name: "utc",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initTimeObject(receiver.(*TimeObject).value.UTC())
+				}
+			},
+		},
+		{
+			// Returns the offset from UTC in seconds.
+			//
+			// ```ruby
+			// Time.parse("2017-09-05 14:30:00 +0800").utc_offset # => 28800
+			// ```
+			//
+			// @return [Integer]
+			Name: "utc_offset",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					_, offset := receiver.(*TimeObject).value.Zone()
+					return t.vm.initIntegerObject(offset)
+				}
+			},
+		},
+		{
+			// Returns the day of the week, Sunday is 0.
+			//
+			// ```ruby
+			// Time.new(2017, 9, 5).wday # => 2
+			// ```
+			//
+			// @return [Integer]
+			Name: "wday",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initIntegerObject(int(receiver.(*TimeObject).value.Weekday()))
+				}
+			},
+		},
+		{
+			// Returns the day of the year in 1..366.
+			//
+			// ```ruby
+			// Time.new(2017, 2, 1).yday # => 32
+			// ```
+			//
+			// @return [Integer]
+			Name: "yday",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initIntegerObject(receiver.(*TimeObject).value.YearDay())
+				}
+			},
+		},
+		{
+			// Returns the year.
+			//
+			// ```ruby
+			// Time.new(2017, 9, 5).year # => 2017
+			// ```
+			//
+			// @return [Integer]
+			Name: "year",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					return t.vm.initIntegerObject(receiver.(*TimeObject).value.Year())
+				}
+			},
+		},
+		{
+			// Returns the abbreviation of the time zone.
+			//
+			// ```ruby
+			// Time.now.utc.zone # => "UTC"
+			// ```
+			//
+			// @return [String]
+			Name: "zone",
+			Fn: func(receiver Object) builtinMethodBody {
+				return func(t *thread, args []Object, blockFrame *callFrame) Object {
+					name, _ := receiver.(*TimeObject).value.Zone()
+					return t.vm.initStringObject(name)
+				}
+			},
+		},
+	}
+}
+
+// Internal functions ===================================================
+
+// Functions for initialization -----------------------------------------
+
+func (vm *VM) initTimeObject(v time.Time) *TimeObject {
+	return &TimeObject{baseObj: &baseObj{class: vm.topLevelClass(classes.TimeClass)}, value: v}
+}
+
+func (vm *VM) initTimeClass() *RClass {
+	tc := vm.initializeClass(classes.TimeClass, false)
+	tc.setBuiltinMethods(builtinTimeInstanceMethods(), false)
+	tc.setBuiltinMethods(builtinTimeClassMethods(), true)
+	tc.includeModule(vm.topLevelClass(classes.ComparableModule))
+	return tc
+}
+
+// initTimeLayouts sets layouts like `Time::RFC3339`, which can only be done after String class is initialized
+func (vm *VM) initTimeLayouts() {
+	tc := vm.topLevelClass(classes.TimeClass)
+
+	for name, layout := range timeLayoutConstants {
+		tc.constants[name] = &Pointer{Target: vm.initStringObject(layout)}
+	}
+}
+
+// Polymorphic helper functions -----------------------------------------
+
+// Value returns the time
+func (to *TimeObject) Value() interface{} {
+	return to.value
+}
+
+// toString returns the time in the format like "2017-09-05 14:30:00 +0800"
+func (to *TimeObject) toString() string {
+	return to.value.Format(timeLayout)
+}
+
+// toJSON returns the time as a JSON string in RFC 3339 format
+func (to *TimeObject) toJSON() string {
+	return strconv.Quote(to.value.Format(time.RFC3339Nano))
+}
+
+// Other helper functions ----------------------------------------------
+
+// loadLocation returns the time zone of the given name
+func (t *thread) loadLocation(name string) (*time.Location, *Error) {
+	loc, err := time.LoadLocation(name)
+
+	if err != nil {
+		return nil, t.vm.initErrorObject(errors.ArgumentError, "Unknown time zone: %s", name)
+	}
+
+	return loc, nil
+}
+
+// strftime formats the time with strftime-style directives, a format without directives is used as Go's layout
+func strftime(v time.Time, format string) string {
+	if !strings.Contains(format, "%") {
+		return v.Format(format)
+	}
+
+	var out bytes.Buffer
+
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' || i == len(format)-1 {
+			out.WriteByte(format[i])
+			continue
+		}
+
+		i++
+
+		switch c := format[i]; c {
+		case 's':
+			out.WriteString(strconv.FormatInt(v.Unix(), 10))
+		case 'u':
+			wday := int(v.Weekday())
+
+			if wday == 0 {
+				wday = 7
+			}
+
+			out.WriteString(strconv.Itoa(wday))
+		case 'w':
+			out.WriteString(strconv.Itoa(int(v.Weekday())))
+		case 'L':
+			fmt.Fprintf(&out, "%03d", v.Nanosecond()/int(time.Millisecond))
+		case 'N':
+			fmt.Fprintf(&out, "%09d", v.Nanosecond())
+		case '%':
+			out.WriteByte('%')
+		default:
+			layout, ok := strftimeLayouts[c]
+
+			if !ok {
+				out.WriteByte('%')
+				out.WriteByte(c)
+				continue
+			}
+
+			out.WriteString(v.Format(layout))
+		}
+	}
+
+	return out.String()
+}
+
+// strftimeToLayout converts strftime-style directives to Go's layout for parsing, a format without directives is returned as is
+func strftimeToLayout(format string) (string, error) {
+	if !strings.Contains(format, "%") {
+		return format, nil
+	}
+
+	var out bytes.Buffer
+
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' || i == len(format)-1 {
+			out.WriteByte(format[i])
+			continue
+		}
+
+		i++
+		layout, ok := strftimeLayouts[format[i]]
+
+		if !ok {
+			return "", fmt.Errorf("Directive %%%c can't be used for parsing", format[i])
+		}
+
+		out.WriteString(layout)
+	}
+
+	return out.String(), nil
+}
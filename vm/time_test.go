@@ -0,0 +1,142 @@
+package vm
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeClassMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`Time.now.class.name`, "Time"},
+		{`Time.at(0).utc.to_s`, "1970-01-01 00:00:00 +0000"},
+		{`Time.at(1.5).nanosecond`, 500000000},
+		{`Time.new(2017, 9, 5, 14, 30, 15, "UTC").to_s`, "2017-09-05 14:30:15 +0000"},
+		{`Time.new(2017, 9, 5, "Asia/Taipei").to_s`, "2017-09-05 00:00:00 +0800"},
+		{`Time.new(2017).in_zone("UTC").month`, 1},
+		{`Time.parse("2017-09-05T14:30:00Z").to_s`, "2017-09-05 14:30:00 +0000"},
+		{`Time.parse("2017-09-05T14:30:00.25+08:00").nanosecond`, 250000000},
+		{`Time.parse("2017-09-05 14:30:00 -0700").utc_offset`, -25200},
+		{`Time.parse("2017-09-05").day`, 5},
+		{`Time.parse("2017-09-05 14:30:00").hour`, 14},
+		{`Time.parse("Tue, 05 Sep 2017 14:30:00 +0000").year`, 2017},
+		{`Time.parse("05/09/2017 14:30:15.250 +0000", "%d/%m/%Y %H:%M:%S.%L %z").to_json`, `"2017-09-05T14:30:15.25Z"`},
+		{`Time.parse("Sep 5 2017", "%b %e %Y").month`, 9},
+		{`Time.parse("Tue, 05 Sep 2017 14:30:00 UTC", Time::RFC1123).minute`, 30},
+		{`Time::RFC3339`, time.RFC3339},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestTimeInstanceMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		t = Time.parse("2017-09-05 14:30:15 +0000")
+		[t.year, t.month, t.day, t.hour, t.minute, t.second, t.wday, t.yday, t.zone].to_s
+		`, `[2017, 9, 5, 14, 30, 15, 2, 248, "UTC"]`},
+		{`
+		t = Time.parse("2017-09-05 14:30:00 +0000")
+		t.strftime("%a, %d %b %Y %I:%M:%S %p")
+		`, "Tue, 05 Sep 2017 02:30:00 PM"},
+		{`
+		t = Time.parse("2017-09-05 09:05:00.123456789 +0000")
+		t.strftime("%A %B %e %y %j %u %w %s %L %N %Z %z %%Y")
+		`, "Tuesday September  5 17 248 2 2 1504602300 123 123456789 UTC +0000 %Y"},
+		{`
+		t = Time.parse("2017-09-05 14:30:00 +0000")
+		t.strftime("%F %T %Q")
+		`, "2017-09-05 14:30:00 %Q"},
+		{`Time.parse("2017-09-05 14:30:00 +0000").strftime(Time::RFC3339)`, "2017-09-05T14:30:00Z"},
+		{`Time.parse("2017-09-05 14:30:00 +0000").to_i`, 1504621800},
+		{`Time.at(1.5).to_f`, 1.5},
+		{`Time.parse("2017-09-05 14:30:00 +0800").utc.to_s`, "2017-09-05 06:30:00 +0000"},
+		{`Time.parse("2017-09-05 14:30:00 +0000").in_zone("Asia/Tokyo").to_s`, "2017-09-05 23:30:00 +0900"},
+		{`Time.parse("2017-09-05 14:30:00 +0000").in_zone("America/New_York").zone`, "EDT"},
+		{`
+		t = Time.parse("2017-09-05 14:30:00 +0000")
+		(t + Duration.minutes(45)).to_s
+		`, "2017-09-05 15:15:00 +0000"},
+		{`(Time.parse("2017-09-05 14:30:00 +0000") + 1.5).nanosecond`, 500000000},
+		{`(Time.parse("2017-09-05 14:30:00 +0000") - 60).to_s`, "2017-09-05 14:29:00 +0000"},
+		{`(Time.parse("2017-09-05 14:30:00 +0000") - Duration.hours(1)).to_s`, "2017-09-05 13:30:00 +0000"},
+		{`
+		t = Time.parse("2017-09-05 14:30:00 +0000")
+		(t - Time.parse("2017-09-05 12:00:00 +0000")).to_s
+		`, "2h30m0s"},
+		{`Time.at(0) <=> Time.at(1)`, -1},
+		{`Time.at(1) <=> Time.at(1)`, 0},
+		{`Time.at(1) <=> 1`, nil},
+		{`Time.at(2) > Time.at(1)`, true},
+		{`Time.at(1).between?(Time.at(0), Time.at(2))`, true},
+		{`
+		t = Time.now
+		t == t.in_zone("Asia/Taipei")
+		`, true},
+		{`Time.at(1) == Time.at(2)`, false},
+		{`Time.at(1) == 1`, false},
+		{`Time.parse("2017-09-05T14:30:00Z").to_json`, `"2017-09-05T14:30:00Z"`},
+		{`{ at: Time.parse("2017-09-05T14:30:00Z") }.to_json`, `{"at":"2017-09-05T14:30:00Z"}`},
+	}
+
+	for i, tt := range tests {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkExpected(t, i, evaluated, tt.expected)
+		v.checkCFP(t, i, 0)
+		v.checkSP(t, i, 1)
+	}
+}
+
+func TestTimeFromGoType(t *testing.T) {
+	v := initTestVM()
+	value := time.Date(2017, 9, 5, 14, 30, 0, 0, time.UTC)
+
+	to, ok := v.initObjectFromGoType(value).(*TimeObject)
+
+	if !ok || !to.value.Equal(value) {
+		t.Fatalf("Expect time.Time to be converted to Time. got: %v", to)
+	}
+
+	do, ok := v.initObjectFromGoType(time.Minute).(*DurationObject)
+
+	if !ok || do.value != time.Minute {
+		t.Fatalf("Expect time.Duration to be converted to Duration. got: %v", do)
+	}
+}
+
+func TestTimeMethodsFail(t *testing.T) {
+	testsFail := []errorTestCase{
+		{`Time.at("a")`, "TypeError: Expect argument to be Numeric. got: String", 1},
+		{`Time.new`, "ArgumentError: Expect 1 to 6 arguments. got: 0", 1},
+		{`Time.new(2017, "9")`, "ArgumentError: Unknown time zone: 9", 1},
+		{`Time.new(2017, 9.0)`, "TypeError: Expect argument to be Integer. got: Float", 1},
+		{`Time.parse("foo")`, "ArgumentError: Can't parse time: foo", 1},
+		{`Time.parse(1)`, "TypeError: Expect argument to be String. got: Integer", 1},
+		{`Time.parse("2017", "%s")`, "ArgumentError: Directive %s can't be used for parsing", 1},
+		{`Time.now.in_zone("Mars/Olympus")`, "ArgumentError: Unknown time zone: Mars/Olympus", 1},
+		{`Time.now + "1"`, "TypeError: Expect argument to be Duration or Numeric. got: String", 1},
+		{`Time.now - "1"`, "TypeError: Expect argument to be Time, Duration or Numeric. got: String", 1},
+		{`Time.now > 1`, "ArgumentError: Comparison of Time with Integer failed", 1},
+		{`Time.now.strftime`, "ArgumentError: Expect 1 arguments. got: 0", 1},
+	}
+
+	for i, tt := range testsFail {
+		v := initTestVM()
+		evaluated := v.testEval(t, tt.input, getFilename())
+		checkError(t, i, evaluated, tt.expected, getFilename(), tt.errorLine)
+		v.checkCFP(t, i, 1)
+		v.checkSP(t, i, 1)
+	}
+}
@@ -128,8 +128,8 @@ func initURIClass(vm *VM) {
 		vm.initStringObject("password"),
 	}
 
-	http.setAttrReader(attrs)
-	http.setAttrWriter(attrs)
+	http.setAttrReader(attrs, publicMethod)
+	http.setAttrWriter(attrs, publicMethod)
 
 	vm.objectClass.setClassConstant(uri)
 }
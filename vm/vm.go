@@ -1,6 +1,7 @@
 package vm
 
 import (
+	"crypto/sha1"
 	"fmt"
 	"github.com/goby-lang/goby/compiler"
 	"github.com/goby-lang/goby/compiler/bytecode"
@@ -24,16 +25,6 @@ const (
 	TestMode
 )
 
-type isIndexTable struct {
-	Data map[string]int
-}
-
-func newISIndexTable() *isIndexTable {
-	return &isIndexTable{Data: make(map[string]int)}
-}
-
-type isTable map[string][]*instructionSet
-
 type filename = string
 
 type errorMessage = string
@@ -52,14 +43,6 @@ type VM struct {
 	mainObj     *RObject
 	mainThread  *thread
 	objectClass *RClass
-	// a map holds different types of instruction set tables
-	isTables map[setType]isTable
-	// method instruction set table
-	methodISIndexTables map[filename]*isIndexTable
-	// class instruction set table
-	classISIndexTables map[filename]*isIndexTable
-	// block instruction set table
-	blockTables map[filename]map[string]*instructionSet
 	// fileDir indicates executed file's directory
 	fileDir string
 	// args are command line arguments
@@ -69,13 +52,22 @@ type VM struct {
 
 	stackTraceCount int
 
-	channelObjectMap *objectMap
+	// symbolTable interns symbols by their names
+	symbolTable *sync.Map
 
 	sync.Mutex
 
 	mode int
 
 	libFiles []string
+
+	// cacheDir is where the bytecode of required files is cached, caching is disabled if it's empty
+	cacheDir string
+
+	// atExitHooks are the blocks registered by `at_exit`
+	atExitHooks []*callFrame
+	// signalTraps are the handlers of the signals trapped by `Process.trap`
+	signalTraps map[os.Signal]*signalTrap
 }
 
 // New initializes a vm to initialize state and returns it.
@@ -83,17 +75,6 @@ func New(fileDir string, args []string) (vm *VM, e error) {
 	vm = &VM{args: args}
 	vm.mainThread = vm.newThread()
 
-	vm.methodISIndexTables = map[filename]*isIndexTable{
-		fileDir: newISIndexTable(),
-	}
-	vm.classISIndexTables = map[filename]*isIndexTable{
-		fileDir: newISIndexTable(),
-	}
-	vm.blockTables = make(map[filename]map[string]*instructionSet)
-	vm.isTables = map[setType]isTable{
-		bytecode.MethodDef: make(isTable),
-		bytecode.ClassDef:  make(isTable),
-	}
 	vm.fileDir = fileDir
 
 	gobyRoot := os.Getenv("GOBY_ROOT")
@@ -118,9 +99,10 @@ func New(fileDir string, args []string) (vm *VM, e error) {
 		vm.projectRoot = gobyRoot
 	}
 
+	vm.cacheDir = bytecodeCacheDir()
+	vm.symbolTable = &sync.Map{}
 	vm.initConstants()
 	vm.mainObj = vm.initMainObj()
-	vm.channelObjectMap = &objectMap{store: &sync.Map{}}
 
 	for _, fn := range vm.libFiles {
 		vm.execGobyLib(fn)
@@ -145,33 +127,21 @@ func (vm *VM) ExecInstructions(sets []*bytecode.InstructionSet, fn string) {
 	p.vm = vm
 	p.transferInstructionSets(sets)
 
-	// Keep instruction set table updated after parsed new files.
-	// TODO: Find more efficient way to do this.
-	for setType, table := range p.setTable {
-		for name, is := range table {
-			vm.isTables[setType][name] = is
-		}
-	}
-
-	vm.blockTables[p.filename] = p.blockTable
-	vm.SetClassISIndexTable(p.filename)
-	vm.SetMethodISIndexTable(p.filename)
-
 	cf := newCallFrame(p.program)
 	cf.self = vm.mainObj
 	vm.mainThread.callFrameStack.push(cf)
 	vm.startFromTopFrame()
 }
 
-// SetClassISIndexTable adds new instruction set's index table to vm.classISIndexTables
-func (vm *VM) SetClassISIndexTable(fn filename) {
-	vm.classISIndexTables[fn] = newISIndexTable()
-}
+// SetClassISIndexTable used to add the file's class instruction set index table.
+//
+// Deprecated: class instruction sets are linked to their definitions when they're loaded, so it does nothing now.
+func (vm *VM) SetClassISIndexTable(fn filename) {}
 
-// SetMethodISIndexTable adds new instruction set's index table to vm.methodISIndexTables
-func (vm *VM) SetMethodISIndexTable(fn filename) {
-	vm.methodISIndexTables[fn] = newISIndexTable()
-}
+// SetMethodISIndexTable used to add the file's method instruction set index table.
+//
+// Deprecated: method instruction sets are linked to their definitions when they're loaded, so it does nothing now.
+func (vm *VM) SetMethodISIndexTable(fn filename) {}
 
 // main object singleton methods -----------------------------------------------------
 func builtinMainObjSingletonMethods() []*BuiltinMethodObject {
@@ -190,7 +160,7 @@ func builtinMainObjSingletonMethods() []*BuiltinMethodObject {
 func (vm *VM) initMainObj() *RObject {
 	obj := vm.objectClass.initializeInstance()
 	singletonClass := vm.initializeClass(fmt.Sprintf("#<Class:%s>", obj.toString()), false)
-	singletonClass.Methods.set("include", vm.topLevelClass(classes.ClassClass).lookupMethod("include"))
+	singletonClass.setMethod("include", vm.topLevelClass(classes.ClassClass).lookupMethod("include"))
 	singletonClass.setBuiltinMethods(builtinMainObjSingletonMethods(), false)
 	obj.singletonClass = singletonClass
 
@@ -204,19 +174,34 @@ func (vm *VM) initConstants() {
 	vm.topLevelClass(classes.ObjectClass).setClassConstant(cClass)
 
 	// Init builtin classes
+	// Builtin modules are initialized first, so builtin classes can include them
+	vm.objectClass.setClassConstant(vm.initComparableModule())
+	vm.objectClass.setClassConstant(vm.initEnumerableModule())
+	vm.objectClass.setClassConstant(vm.initSyncModule())
+	vm.objectClass.setClassConstant(vm.initProcessModule())
+
 	builtinClasses := []*RClass{
 		vm.initIntegerClass(),
 		vm.initFloatClass(),
 		vm.initStringClass(),
+		vm.initSymbolClass(),
+		vm.initRegexpClass(),
+		vm.initMatchDataClass(),
 		vm.initBoolClass(),
 		vm.initNullClass(),
 		vm.initArrayClass(),
 		vm.initHashClass(),
 		vm.initRangeClass(),
+		vm.initProcClass(),
 		vm.initMethodClass(),
 		vm.initChannelClass(),
+		vm.initThreadClass(),
+		vm.initTimeClass(),
+		vm.initDurationClass(),
+		vm.initRandomClass(),
 		vm.initGoClass(),
 		vm.initFileClass(),
+		vm.initDirClass(),
 		vm.initGoMapClass(),
 	}
 
@@ -227,6 +212,11 @@ func (vm *VM) initConstants() {
 		vm.objectClass.setClassConstant(c)
 	}
 
+	// These need String, Integer and Float classes to initialize their constants
+	vm.objectClass.setClassConstant(vm.initMathModule())
+	vm.initTimeLayouts()
+	vm.initFileConstants()
+
 	// Init ARGV
 	args := []Object{}
 
@@ -269,46 +259,6 @@ func (vm *VM) currentFilePath() string {
 	return string(vm.mainThread.callFrameStack.top().instructionSet.filename)
 }
 
-func (vm *VM) getBlock(name string, filename filename) *instructionSet {
-	// The "name" here is actually an index of block
-	// for example <Block:1>'s name is "1"
-	is, ok := vm.blockTables[filename][name]
-
-	if !ok {
-		panic(fmt.Sprintf("Can't find block %s", name))
-	}
-
-	return is
-}
-
-func (vm *VM) getMethodIS(name string, filename filename) (*instructionSet, bool) {
-	iss, ok := vm.isTables[bytecode.MethodDef][name]
-
-	if !ok {
-		return nil, false
-	}
-
-	is := iss[vm.methodISIndexTables[filename].Data[name]]
-
-	vm.methodISIndexTables[filename].Data[name]++
-
-	return is, ok
-}
-
-func (vm *VM) getClassIS(name string, filename filename) *instructionSet {
-	iss, ok := vm.isTables[bytecode.ClassDef][name]
-
-	if !ok {
-		panic(fmt.Sprintf("Can't find class %s's instructions", name))
-	}
-
-	is := iss[vm.classISIndexTables[filename].Data[name]]
-
-	vm.classISIndexTables[filename].Data[name]++
-
-	return is
-}
-
 // loadConstant makes sure we don't create a class twice.
 func (vm *VM) loadConstant(name string, isModule bool) *RClass {
 	var c *RClass
@@ -378,27 +328,108 @@ func (vm *VM) execRequiredFile(filepath string, file []byte) {
 		return
 	}
 
-	oldMethodTable := isTable{}
-	oldClassTable := isTable{}
+	vm.ExecInstructions(instructionSets, filepath)
+}
 
-	// Copy current file's instruction sets.
-	for name, is := range vm.isTables[bytecode.MethodDef] {
-		oldMethodTable[name] = is
+// loadRequiredFile returns the instruction sets of a required file, the path is given without extension.
+// A ".gbc" file compiled by `goby -c` next to the source is used if it's compiled from the current source,
+// or if there's no source. Otherwise the source's compiled bytecode is cached in the VM's cache directory,
+// and reused until the source's modification time changes.
+func (vm *VM) loadRequiredFile(path string) ([]*bytecode.InstructionSet, error) {
+	sourcePath := path + ".gb"
+	info, statErr := os.Stat(sourcePath)
+
+	if instructionSets, modTime, ok := readBytecodeFile(path + "." + bytecode.FileExtension); ok {
+		if statErr != nil || modTime == info.ModTime().UnixNano() {
+			return instructionSets, nil
+		}
 	}
 
-	for name, is := range vm.isTables[bytecode.ClassDef] {
-		oldClassTable[name] = is
+	if statErr != nil {
+		return nil, statErr
 	}
 
-	// This creates new execution environments for required file, including new instruction set table.
-	// So we need to copy old instruction sets and restore them later, otherwise current program's instruction set would be overwrite.
-	vm.ExecInstructions(instructionSets, filepath)
+	cachePath := vm.bytecodeCachePath(sourcePath)
+
+	if cachePath != "" {
+		if instructionSets, modTime, ok := readBytecodeFile(cachePath); ok && modTime == info.ModTime().UnixNano() {
+			return instructionSets, nil
+		}
+	}
+
+	file, err := ioutil.ReadFile(sourcePath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	instructionSets, err := compiler.CompileToInstructions(string(file), parser.NormalMode)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// The cache is only an optimization, so failing to write it (like in a read-only directory) is ignored
+	if cachePath != "" {
+		if data, err := bytecode.Marshal(instructionSets, info.ModTime().UnixNano()); err == nil && os.MkdirAll(filepath.Dir(cachePath), 0755) == nil {
+			ioutil.WriteFile(cachePath, data, 0644)
+		}
+	}
+
+	return instructionSets, nil
+}
+
+// readBytecodeFile decodes the ".gbc" file, it returns false if the file can't be read or decoded
+func readBytecodeFile(path string) ([]*bytecode.InstructionSet, int64, bool) {
+	data, err := ioutil.ReadFile(path)
+
+	if err != nil {
+		return nil, 0, false
+	}
+
+	instructionSets, modTime, err := bytecode.Unmarshal(data)
+
+	if err != nil {
+		return nil, 0, false
+	}
+
+	return instructionSets, modTime, true
+}
+
+// bytecodeCachePath returns the path of the source's bytecode cache, which is named by the hash of the source's absolute path.
+// It returns an empty string if caching is disabled.
+func (vm *VM) bytecodeCachePath(sourcePath string) string {
+	if vm.cacheDir == "" {
+		return ""
+	}
+
+	absPath, err := filepath.Abs(sourcePath)
+
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(vm.cacheDir, fmt.Sprintf("%x.%s", sha1.Sum([]byte(absPath)), bytecode.FileExtension))
+}
+
+// bytecodeCacheDir returns the directory of bytecode caches, which is $GOBY_CACHE_DIR,
+// or "goby" in the user's cache directory ($XDG_CACHE_HOME or ~/.cache).
+func bytecodeCacheDir() string {
+	if dir := os.Getenv("GOBY_CACHE_DIR"); dir != "" {
+		return dir
+	}
+
+	if dir := os.Getenv("XDG_CACHE_HOME"); dir != "" {
+		return filepath.Join(dir, "goby")
+	}
+
+	if home := os.Getenv("HOME"); home != "" {
+		return filepath.Join(home, ".cache", "goby")
+	}
 
-	// Restore instruction sets.
-	vm.isTables[bytecode.MethodDef] = oldMethodTable
-	vm.isTables[bytecode.ClassDef] = oldClassTable
+	return ""
 }
 
 func newError(format string, args ...interface{}) *Error {
-	return &Error{Message: fmt.Sprintf(format, args...)}
+	return &Error{Message: fmt.Sprintf(format, args...), raised: true}
 }
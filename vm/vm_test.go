@@ -310,12 +310,12 @@ func testHashObject(t *testing.T, index int, objectResult Object, expected map[s
 		return false
 	}
 
-	if len(result.Pairs) != len(expected) {
-		t.Errorf("Unexpected result size. Expected %d, got=%d", len(expected), len(result.Pairs))
+	if result.length() != len(expected) {
+		t.Errorf("Unexpected result size. Expected %d, got=%d", len(expected), result.length())
 	}
 
 	for expectedKey, expectedValue := range expected {
-		resultValue := result.Pairs[expectedKey]
+		resultValue, _ := result.getByName(expectedKey)
 
 		checkExpected(t, i, resultValue, expectedValue)
 	}